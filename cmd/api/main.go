@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"log/slog"
@@ -16,13 +18,31 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/invoice-app-be/config"
+	"github.com/invoice-app-be/internal/domain/dunning"
+	"github.com/invoice-app-be/internal/domain/fx"
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
 	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/latefee"
+	"github.com/invoice-app-be/internal/domain/mfa"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/payment"
+	"github.com/invoice-app-be/internal/domain/portal"
+	"github.com/invoice-app-be/internal/domain/project"
+	"github.com/invoice-app-be/internal/domain/quote"
+	"github.com/invoice-app-be/internal/domain/recurring"
+	"github.com/invoice-app-be/internal/domain/report"
+	"github.com/invoice-app-be/internal/domain/session"
+	"github.com/invoice-app-be/internal/domain/sso"
 	"github.com/invoice-app-be/internal/domain/timeentry"
+	"github.com/invoice-app-be/internal/domain/timesheet"
 	"github.com/invoice-app-be/internal/domain/user"
 	"github.com/invoice-app-be/internal/infrastructure/auth"
 	"github.com/invoice-app-be/internal/infrastructure/database/postgres"
+	"github.com/invoice-app-be/internal/infrastructure/email"
+	fxrates "github.com/invoice-app-be/internal/infrastructure/fx"
 	"github.com/invoice-app-be/internal/infrastructure/integrations/jira"
 	"github.com/invoice-app-be/internal/infrastructure/integrations/square"
+	"github.com/invoice-app-be/internal/infrastructure/oidc"
 	"github.com/invoice-app-be/internal/infrastructure/pdf"
 	infraHTTP "github.com/invoice-app-be/internal/interfaces/http"
 	"github.com/invoice-app-be/internal/interfaces/http/handlers"
@@ -73,16 +93,35 @@ func main() {
 	timeEntryRepo := postgres.NewTimeEntryRepository(db)
 	userRepo := postgres.NewUserRepository(db)
 	clientRepo := postgres.NewClientRepository(db)
+	taxCodeRepo := postgres.NewTaxCodeRepository(db)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	fxRepo := postgres.NewExchangeRateRepository(db)
+	deliveryRepo := postgres.NewDeliveryRepository(db)
+	reportRepo := postgres.NewReportRepository(db)
+	recurringRepo := postgres.NewRecurringScheduleRepository(db)
+	dunningRepo := postgres.NewDunningRepository(db)
+	lateFeeRepo := postgres.NewLateFeeRepository(db)
+	shareRepo := postgres.NewShareRepository(db)
+	quoteRepo := postgres.NewQuoteRepository(db)
+	timerRepo := postgres.NewTimerRepository(db)
+	projectRepo := postgres.NewProjectRepository(db)
+	hourlyRateRepo := postgres.NewHourlyRateRepository(db)
+	timesheetRepo := postgres.NewTimesheetRepository(db)
+	organizationRepo := postgres.NewOrganizationRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
+	mfaRepo := postgres.NewMFARepository(db)
+	ssoRepo := postgres.NewSSORepository(db)
 
 	// Initialize Jira integration
 	var jiraSyncService *jira.SyncService
-	var jiraClient *jira.Client // Add this
+	var jiraClient timeentry.JiraClient // Keep the interface nil when Jira is off
 	if cfg.Jira.Enabled {
 		if cfg.Jira.BaseURL == "" || cfg.Jira.Email == "" || cfg.Jira.APIToken == "" {
 			logger.Warn("Jira is enabled but credentials are missing")
 		} else {
-			jiraClient := jira.NewClient(cfg.Jira.BaseURL, cfg.Jira.Email, cfg.Jira.APIToken)
-			jiraSyncService = jira.NewSyncService(jiraClient, timeEntryRepo)
+			client := jira.NewClient(cfg.Jira.BaseURL, cfg.Jira.Email, cfg.Jira.APIToken)
+			jiraClient = client
+			jiraSyncService = jira.NewSyncService(client, timeEntryRepo, projectRepo, timesheetRepo)
 			logger.Info("Jira integration enabled",
 				"base_url", cfg.Jira.BaseURL,
 				"email", cfg.Jira.Email)
@@ -91,28 +130,106 @@ func main() {
 		logger.Info("Jira integration disabled")
 	}
 
-	var squareClient *square.Client
+	// Keep the interface nil when Square is off so the service skips it
+	var squareClient invoice.SquareAPI
 	if cfg.Square.Enabled && cfg.Square.AccessToken != "" {
-		squareClient = square.NewClient(cfg.Square.AccessToken, cfg.Square.Environment)
+		squareClient = square.NewClient(cfg.Square.AccessToken, cfg.Square.Environment, cfg.Square.LocationID)
 		logger.Info("Square integration enabled", "environment", cfg.Square.Environment)
 	}
 
+	// Published exchange rates back up the user's manual rates
+	var rateProvider fx.RateProvider
+	if cfg.FX.RatesFile != "" {
+		fileProvider, err := fxrates.NewFileProvider(cfg.FX.RatesFile)
+		if err != nil {
+			logger.Warn("Failed to load exchange rates file", "path", cfg.FX.RatesFile, "error", err)
+		} else {
+			rateProvider = fileProvider
+		}
+	}
+
 	// Initialize PDF generator
 	pdfGenerator := pdf.NewGenerator()
 
+	var mailer invoice.EmailSender
+	if cfg.Email.Enabled && cfg.Email.Host != "" {
+		templates, err := email.NewTemplates()
+		if err != nil {
+			logger.Error("Failed to load email templates", "error", err)
+			os.Exit(1)
+		}
+		mailer = email.NewSMTPSender(&cfg.Email, templates)
+		logger.Info("Email delivery enabled", "host", cfg.Email.Host)
+	}
+
 	// Initialize services
-	invoiceService := invoice.NewService(invoiceRepo, pdfGenerator, squareClient)
-	timeEntryService := timeentry.NewService(timeEntryRepo, jiraClient)
-	userService := user.NewService(userRepo, cfg.Auth.JWTSecret, appLogger)
+	fxService := fx.NewService(fxRepo, rateProvider, organizationRepo)
+	invoiceService := invoice.NewService(invoiceRepo, taxCodeRepo, deliveryRepo, clientRepo, organizationRepo, userRepo, fxService,
+		pdfGenerator, squareClient, mailer)
+	if cfg.Auth.RequireVerifiedEmail {
+		invoiceService.RequireVerifiedEmail()
+	}
+	paymentService := payment.NewService(paymentRepo, invoiceRepo, fxService)
+	reportService := report.NewService(reportRepo, organizationRepo)
+	recurringService := recurring.NewService(recurringRepo, invoiceService, invoiceRepo)
+	dunningService := dunning.NewService(dunningRepo, invoiceRepo, invoiceService)
+	lateFeeService := latefee.NewService(lateFeeRepo, invoiceRepo, clientRepo, invoiceService)
+	shareTokens := auth.NewShareTokenManager(cfg.Auth.JWTSecret)
+	portalService := portal.NewService(shareRepo, invoiceRepo, paymentRepo, clientRepo, organizationRepo, invoiceService,
+		shareTokens, cfg.Portal.TokenDuration)
+	quoteService := quote.NewService(quoteRepo, clientRepo, organizationRepo, userRepo, invoiceService, shareTokens,
+		mailer, cfg.Portal.BaseURL)
+	projectService := project.NewService(projectRepo, clientRepo)
+	hourlyRateService := hourlyrate.NewService(hourlyRateRepo, projectRepo, clientRepo, organizationRepo)
+	timeEntryService := timeentry.NewService(timeEntryRepo, timerRepo, timesheetRepo, projectRepo, hourlyRateService,
+		invoiceService, jiraClient)
+	timesheetService := timesheet.NewService(timesheetRepo, timeEntryRepo, projectRepo, clientRepo, organizationRepo, userRepo,
+		shareTokens, mailer, cfg.Portal.BaseURL)
+	userService := user.NewService(userRepo, cfg.Auth.JWTSecret, appLogger, mailer, cfg.Auth.AppURL)
+	organizationService := organization.NewService(organizationRepo, userRepo)
+	sessionService := session.NewService(sessionRepo, cfg.Auth.RefreshTokenDuration, cfg.Auth.SessionMaxLifetime)
+	secretBox, err := loadSecretBox(&cfg.Auth, cfg.Server.Environment)
+	if err != nil {
+		logger.Error("Failed to load MFA encryption key", "error", err)
+		os.Exit(1)
+	}
+	mfaService := mfa.NewService(mfaRepo, userRepo, secretBox, cfg.Auth.MFAIssuer)
+	if sealed, err := mfaService.SealSecrets(context.Background()); err != nil {
+		logger.Error("Failed to encrypt authenticator secrets", "error", err)
+	} else if sealed > 0 {
+		logger.Info("Encrypted authenticator secrets", "count", sealed)
+	}
+	ssoService := sso.NewService(ssoRepo, userService, identityProviders(cfg.OIDC)...)
 
 	// Initialize auth components
-	jwtManager := auth.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.TokenDuration)
-	authMiddleware := middleware.NewAuthMiddleware(jwtManager)
+	signingKeys, err := loadSigningKeys(&cfg.Auth, cfg.Server.Environment)
+	if err != nil {
+		logger.Error("Failed to load signing keys", "error", err)
+		os.Exit(1)
+	}
+	jwtManager := auth.NewJWTManager(signingKeys, cfg.Auth.TokenDuration)
+	authMiddleware := middleware.NewAuthMiddleware(jwtManager, organizationService, sessionService)
 
 	// Initialize HTTP handlers
-	authHandler := handlers.NewAuthHandler(userService, jwtManager)
+	authHandler := handlers.NewAuthHandler(userService, organizationService, sessionService, mfaService, ssoService, jwtManager)
 	invoiceHandler := handlers.NewInvoiceHandler(invoiceService, clientRepo)
 	timeEntryHandler := handlers.NewTimeEntryHandler(timeEntryService)
+	taxCodeHandler := handlers.NewTaxCodeHandler(invoiceService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	fxHandler := handlers.NewExchangeRateHandler(fxService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	accountHandler := handlers.NewAccountHandler(userService, sessionService)
+	mfaHandler := handlers.NewMFAHandler(mfaService, sessionService)
+	recurringHandler := handlers.NewRecurringHandler(recurringService)
+	dunningHandler := handlers.NewDunningHandler(dunningService)
+	lateFeeHandler := handlers.NewLateFeeHandler(lateFeeService)
+	portalHandler := handlers.NewPortalHandler(portalService, cfg.Portal.BaseURL)
+	quoteHandler := handlers.NewQuoteHandler(quoteService)
+	projectHandler := handlers.NewProjectHandler(projectService)
+	hourlyRateHandler := handlers.NewHourlyRateHandler(hourlyRateService)
+	timesheetHandler := handlers.NewTimesheetHandler(timesheetService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService, userService, sessionService,
+		jwtManager)
 
 	// Only create Jira handler if Jira is configured
 	var jiraHandler *handlers.JiraHandler
@@ -125,7 +242,22 @@ func main() {
 		invoiceHandler,
 		timeEntryHandler,
 		authHandler,
+		taxCodeHandler,
+		paymentHandler,
+		fxHandler,
+		reportHandler,
+		accountHandler,
+		recurringHandler,
+		dunningHandler,
+		lateFeeHandler,
+		portalHandler,
+		quoteHandler,
+		projectHandler,
+		hourlyRateHandler,
 		jiraHandler,
+		timesheetHandler,
+		organizationHandler,
+		mfaHandler,
 		authMiddleware,
 	)
 	handler := router.Setup()
@@ -185,3 +317,61 @@ func runMigrations(cfg *config.DatabaseConfig) error {
 
 	return nil
 }
+
+// loadSigningKeys reads the access token keys. Outside production a missing
+// signing key is generated, which invalidates access tokens on restart;
+// clients refresh to get new ones.
+func loadSigningKeys(cfg *config.AuthConfig, environment string) (*auth.KeySet, error) {
+	if cfg.SigningKeyFile != "" {
+		return auth.LoadKeySet(cfg.SigningKeyFile, cfg.VerificationKeyFiles)
+	}
+	if environment == "production" {
+		return nil, fmt.Errorf("auth.signing_key_file is required in production")
+	}
+
+	slog.Warn("No auth.signing_key_file set; generating a temporary signing key")
+	key, err := auth.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewKeySet(key)
+}
+
+// loadSecretBox creates the box authenticator secrets are encrypted with.
+// Outside production a missing key is derived from the JWT secret, so
+// secrets stay readable across restarts.
+func loadSecretBox(cfg *config.AuthConfig, environment string) (*auth.SecretBox, error) {
+	if cfg.MFAEncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.MFAEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding auth.mfa_encryption_key: %w", err)
+		}
+		return auth.NewSecretBox(key)
+	}
+	if environment == "production" {
+		return nil, fmt.Errorf("auth.mfa_encryption_key is required in production")
+	}
+
+	slog.Warn("No auth.mfa_encryption_key set; deriving one from the JWT secret")
+	key := sha256.Sum256([]byte("mfa-secrets:" + cfg.JWTSecret))
+	return auth.NewSecretBox(key[:])
+}
+
+// identityProviders creates the configured OpenID Connect providers. They
+// discover their endpoints on first use, so a provider that's down doesn't
+// keep the API from starting.
+func identityProviders(cfg config.OIDCConfig) []sso.Provider {
+	providers := make([]sso.Provider, len(cfg.Providers))
+	for i, p := range cfg.Providers {
+		providers[i] = oidc.NewProvider(oidc.Config{
+			Name:           p.Name,
+			Issuer:         p.Issuer,
+			ClientID:       p.ClientID,
+			ClientSecret:   p.ClientSecret,
+			RedirectURL:    p.RedirectURL,
+			Scopes:         p.Scopes,
+			AllowedDomains: p.AllowedDomains,
+		})
+	}
+	return providers
+}
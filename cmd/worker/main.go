@@ -1,9 +1,129 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/invoice-app-be/config"
+	"github.com/invoice-app-be/internal/domain/dunning"
+	"github.com/invoice-app-be/internal/domain/fx"
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/latefee"
+	"github.com/invoice-app-be/internal/domain/quote"
+	"github.com/invoice-app-be/internal/domain/recurring"
+	"github.com/invoice-app-be/internal/domain/timeentry"
+	"github.com/invoice-app-be/internal/infrastructure/auth"
+	"github.com/invoice-app-be/internal/infrastructure/database/postgres"
+	"github.com/invoice-app-be/internal/infrastructure/email"
+	fxrates "github.com/invoice-app-be/internal/infrastructure/fx"
+	"github.com/invoice-app-be/internal/infrastructure/integrations/square"
+	"github.com/invoice-app-be/internal/infrastructure/pdf"
+	"github.com/invoice-app-be/internal/interfaces/jobs"
 )
 
 func main() {
 	log.Println("Starting background worker...")
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	logLevel := slog.LevelInfo
+	if cfg.Server.Environment == "development" {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: logLevel,
+	}))
+	slog.SetDefault(logger)
+
+	db, err := postgres.NewConnection(&cfg.Database)
+	if err != nil {
+		logger.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Initialize repositories
+	invoiceRepo := postgres.NewInvoiceRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	clientRepo := postgres.NewClientRepository(db)
+	taxCodeRepo := postgres.NewTaxCodeRepository(db)
+	fxRepo := postgres.NewExchangeRateRepository(db)
+	deliveryRepo := postgres.NewDeliveryRepository(db)
+	recurringRepo := postgres.NewRecurringScheduleRepository(db)
+	dunningRepo := postgres.NewDunningRepository(db)
+	lateFeeRepo := postgres.NewLateFeeRepository(db)
+	quoteRepo := postgres.NewQuoteRepository(db)
+	timeEntryRepo := postgres.NewTimeEntryRepository(db)
+	timerRepo := postgres.NewTimerRepository(db)
+	projectRepo := postgres.NewProjectRepository(db)
+	hourlyRateRepo := postgres.NewHourlyRateRepository(db)
+	organizationRepo := postgres.NewOrganizationRepository(db)
+
+	var rateProvider fx.RateProvider
+	if cfg.FX.RatesFile != "" {
+		fileProvider, err := fxrates.NewFileProvider(cfg.FX.RatesFile)
+		if err != nil {
+			logger.Warn("Failed to load exchange rates file", "path", cfg.FX.RatesFile, "error", err)
+		} else {
+			rateProvider = fileProvider
+		}
+	}
+
+	// Keep the interface nil when Square is off so the service skips it
+	var squareClient invoice.SquareAPI
+	if cfg.Square.Enabled && cfg.Square.AccessToken != "" {
+		squareClient = square.NewClient(cfg.Square.AccessToken, cfg.Square.Environment, cfg.Square.LocationID)
+	}
+
+	var mailer invoice.EmailSender
+	if cfg.Email.Enabled && cfg.Email.Host != "" {
+		templates, err := email.NewTemplates()
+		if err != nil {
+			logger.Error("Failed to load email templates", "error", err)
+			os.Exit(1)
+		}
+		mailer = email.NewSMTPSender(&cfg.Email, templates)
+		logger.Info("Email delivery enabled", "host", cfg.Email.Host)
+	}
+
+	// Initialize services
+	fxService := fx.NewService(fxRepo, rateProvider, organizationRepo)
+	invoiceService := invoice.NewService(invoiceRepo, taxCodeRepo, deliveryRepo, clientRepo, organizationRepo, userRepo,
+		fxService, pdf.NewGenerator(), squareClient, mailer)
+	recurringService := recurring.NewService(recurringRepo, invoiceService, invoiceRepo)
+	dunningService := dunning.NewService(dunningRepo, invoiceRepo, invoiceService)
+	lateFeeService := latefee.NewService(lateFeeRepo, invoiceRepo, clientRepo, invoiceService)
+	quoteService := quote.NewService(quoteRepo, clientRepo, organizationRepo, userRepo, invoiceService,
+		auth.NewShareTokenManager(cfg.Auth.JWTSecret), mailer, cfg.Portal.BaseURL)
+	timeEntryService := timeentry.NewService(timeEntryRepo, timerRepo, postgres.NewTimesheetRepository(db), projectRepo,
+		hourlyrate.NewService(hourlyRateRepo, projectRepo, clientRepo, organizationRepo), invoiceService, nil)
+
+	workerJobs := []jobs.Job{
+		jobs.NewRecurringInvoicesJob(recurringService),
+		jobs.NewOverdueInvoicesJob(invoiceService),
+		jobs.NewLateFeesJob(lateFeeService),
+		jobs.NewExpireQuotesJob(quoteService),
+		jobs.NewStopTimersJob(timeEntryService, cfg.Worker.TimerLimit),
+	}
+	// Reminders go out by email, so they wait until email is configured
+	if mailer != nil {
+		workerJobs = append(workerJobs, jobs.NewPaymentRemindersJob(dunningService))
+	}
+
+	runner := jobs.NewRunner(cfg.Worker.Interval, workerJobs...)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("Worker started", "interval", cfg.Worker.Interval)
+	runner.Start(ctx)
+	logger.Info("Worker stopped")
 }
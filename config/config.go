@@ -15,7 +15,12 @@ type Config struct {
 	Auth     AuthConfig
 	Jira     JiraConfig
 	Square   SquareConfig
+	FX       FXConfig
+	Worker   WorkerConfig
+	Email    EmailConfig
+	Portal   PortalConfig
 	Redis    RedisConfig
+	OIDC     OIDCConfig
 }
 
 type ServerConfig struct {
@@ -37,8 +42,16 @@ type DatabaseConfig struct {
 }
 
 type AuthConfig struct {
-	JWTSecret     string        `mapstructure:"jwt_secret"`
-	TokenDuration time.Duration `mapstructure:"token_duration"`
+	JWTSecret            string        `mapstructure:"jwt_secret"`
+	TokenDuration        time.Duration `mapstructure:"token_duration"`         // Lifetime of access tokens
+	RefreshTokenDuration time.Duration `mapstructure:"refresh_token_duration"` // Sessions end after going unused this long
+	SessionMaxLifetime   time.Duration `mapstructure:"session_max_lifetime"`   // Sessions end this long after login however often refreshed
+	SigningKeyFile       string        `mapstructure:"signing_key_file"`       // PEM RSA or Ed25519 private key access tokens are signed with
+	VerificationKeyFiles []string      `mapstructure:"verification_key_files"` // PEM keys of retired signing keys, still accepted
+	AppURL               string        `mapstructure:"app_url"`                // Front end the verification and reset links open
+	RequireVerifiedEmail bool          `mapstructure:"require_verified_email"` // Only verified users may send invoices
+	MFAIssuer            string        `mapstructure:"mfa_issuer"`             // Names accounts in authenticator apps
+	MFAEncryptionKey     string        `mapstructure:"mfa_encryption_key"`     // Base64 32-byte key authenticator secrets are encrypted with
 }
 
 type JiraConfig struct {
@@ -51,9 +64,34 @@ type JiraConfig struct {
 type SquareConfig struct {
 	AccessToken string
 	Environment string // sandbox or production
+	LocationID  string `mapstructure:"location_id"`
 	Enabled     bool
 }
 
+type FXConfig struct {
+	RatesFile string `mapstructure:"rates_file"` // JSON file of published rates
+}
+
+type WorkerConfig struct {
+	Interval   time.Duration // How often background jobs run
+	TimerLimit time.Duration `mapstructure:"timer_limit"` // Running timers are stopped after this long
+}
+
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	FromName string `mapstructure:"from_name"`
+	Enabled  bool
+}
+
+type PortalConfig struct {
+	BaseURL       string        `mapstructure:"base_url"`       // Public URL of the portal routes
+	TokenDuration time.Duration `mapstructure:"token_duration"` // Default lifetime of share links
+}
+
 type RedisConfig struct {
 	Host     string
 	Port     int
@@ -61,6 +99,20 @@ type RedisConfig struct {
 	DB       int
 }
 
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig // Identity providers users can log in with
+}
+
+type OIDCProviderConfig struct {
+	Name           string   // In the login routes, such as google
+	Issuer         string   // Discovery is fetched from its /.well-known/openid-configuration
+	ClientID       string   `mapstructure:"client_id"`
+	ClientSecret   string   `mapstructure:"client_secret"` // Empty for public clients
+	RedirectURL    string   `mapstructure:"redirect_url"`  // Front end page that posts the code back
+	Scopes         []string // openid email profile by default
+	AllowedDomains []string `mapstructure:"allowed_domains"` // Email domains allowed to log in; any when empty
+}
+
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -72,6 +124,16 @@ func Load() (*Config, error) {
 	viper.SetDefault("server.environment", "development")
 	viper.SetDefault("database.sslmode", "disable")
 	viper.SetDefault("database.maxconns", 25)
+	viper.SetDefault("auth.token_duration", 15*time.Minute)
+	viper.SetDefault("auth.refresh_token_duration", 30*24*time.Hour)
+	viper.SetDefault("auth.session_max_lifetime", 90*24*time.Hour)
+	viper.SetDefault("auth.app_url", "http://localhost:3000")
+	viper.SetDefault("auth.mfa_issuer", "Invoice App")
+	viper.SetDefault("worker.interval", time.Hour)
+	viper.SetDefault("worker.timer_limit", 12*time.Hour)
+	viper.SetDefault("email.port", 587)
+	viper.SetDefault("portal.base_url", "http://localhost:8080/api/v1/portal")
+	viper.SetDefault("portal.token_duration", 30*24*time.Hour)
 
 	// Read config file (if exists)
 	if err := viper.ReadInConfig(); err != nil {
@@ -0,0 +1,30 @@
+// internal/domain/client/entity.go
+package client
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client is an invoice recipient
+type Client struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Name           string    `db:"name"`
+	Email          string    `db:"email"`
+	CompanyName    string    `db:"company_name"`
+	Address        string    `db:"address"`
+	Phone          string    `db:"phone"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// DisplayName is the company name when set, otherwise the contact name
+func (c *Client) DisplayName() string {
+	if c.CompanyName != "" {
+		return c.CompanyName
+	}
+	return c.Name
+}
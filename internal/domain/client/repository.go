@@ -0,0 +1,13 @@
+// internal/domain/client/repository.go
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for client persistence
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Client, error)
+}
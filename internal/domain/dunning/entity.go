@@ -0,0 +1,95 @@
+// internal/domain/dunning/entity.go
+package dunning
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email templates a reminder step can use
+const (
+	TemplateUpcoming = "reminder_upcoming" // Before the due date
+	TemplateDue      = "reminder_due"      // On the due date
+	TemplateOverdue  = "reminder_overdue"  // After the due date
+	TemplateFinal    = "reminder_final"    // Last notice
+)
+
+type LateFeeType string
+
+const (
+	LateFeeNone    LateFeeType = "none"
+	LateFeePercent LateFeeType = "percent" // Percent of the balance due
+	LateFeeFixed   LateFeeType = "fixed"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending" // Claimed and being sent
+	ReminderSent    ReminderStatus = "sent"
+	ReminderFailed  ReminderStatus = "failed"
+	ReminderSkipped ReminderStatus = "skipped" // Superseded by a later step that was due at the same time
+)
+
+// Sequence is an organization's series of payment reminders. The default sequence
+// applies to every invoice that doesn't name its own.
+type Sequence struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Name           string    `db:"name"`
+	IsDefault      bool      `db:"is_default"`
+	Steps          []Step
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Step is one reminder, sent OffsetDays from the due date (negative is before)
+type Step struct {
+	ID           uuid.UUID   `db:"id"`
+	SequenceID   uuid.UUID   `db:"sequence_id"`
+	OffsetDays   int         `db:"offset_days"`
+	Template     string      `db:"template"`
+	Message      string      `db:"message"` // Optional note added to the template
+	LateFeeType  LateFeeType `db:"late_fee_type"`
+	LateFeeValue float64     `db:"late_fee_value"`
+	SortOrder    int         `db:"sort_order"`
+}
+
+// Reminder records that a step was handled for an invoice, so it runs once
+type Reminder struct {
+	ID         uuid.UUID      `db:"id"`
+	InvoiceID  uuid.UUID      `db:"invoice_id"`
+	StepID     *uuid.UUID     `db:"step_id"`
+	Status     ReminderStatus `db:"status"`
+	DeliveryID *uuid.UUID     `db:"delivery_id"`
+	Error      string         `db:"error"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+// DueStep is a step that is due for an invoice and hasn't run yet
+type DueStep struct {
+	InvoiceID uuid.UUID `db:"invoice_id"`
+	Step
+}
+
+// LateFee returns the fee the step charges on the given balance
+func (s *Step) LateFee(balance float64) float64 {
+	switch s.LateFeeType {
+	case LateFeePercent:
+		return math.Max(balance, 0) * s.LateFeeValue / 100
+	case LateFeeFixed:
+		return s.LateFeeValue
+	}
+	return 0
+}
+
+func IsValidTemplate(template string) bool {
+	switch template {
+	case TemplateUpcoming, TemplateDue, TemplateOverdue, TemplateFinal:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+// internal/domain/dunning/repository.go
+package dunning
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+// Repository defines the contract for reminder sequence persistence
+type Repository interface {
+	// Create and Update save the steps too, and clear the organization's
+	// other default when the sequence is the default
+	Create(ctx context.Context, sequence *Sequence) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Sequence, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]Sequence, error)
+	Update(ctx context.Context, sequence *Sequence) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetDueSteps returns the steps due on or before asOf that haven't run,
+	// for invoices still awaiting payment with reminders on, ordered by
+	// invoice then offset
+	GetDueSteps(ctx context.Context, asOf time.Time) ([]DueStep, error)
+	RecordReminder(ctx context.Context, reminder *Reminder) error
+	// ClaimStep records the reminder and adds the step's late fee line, if
+	// any, to the invoice in one transaction. It reports false, charging
+	// nothing, when the step was already recorded for the invoice.
+	ClaimStep(ctx context.Context, reminder *Reminder, lateFee *invoice.InvoiceItem) (bool, error)
+	// FinishReminder saves the outcome of a claimed reminder
+	FinishReminder(ctx context.Context, reminder *Reminder) error
+	GetReminders(ctx context.Context, invoiceID uuid.UUID) ([]Reminder, error)
+}
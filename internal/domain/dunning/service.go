@@ -0,0 +1,384 @@
+// internal/domain/dunning/service.go
+package dunning
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrSequenceNotFound = fmt.Errorf("reminder sequence not found")
+	ErrUnauthorized     = fmt.Errorf("unauthorized access")
+	ErrInvalidSequence  = fmt.Errorf("invalid reminder sequence")
+)
+
+// Notifier emails invoices and records changes on their timeline
+type Notifier interface {
+	EmailConfigured() bool
+	EmailInvoice(ctx context.Context, inv *invoice.Invoice, template string, opts invoice.SendOptions) (*invoice.Delivery, error)
+	LogActivity(ctx context.Context, activity *invoice.Activity) error
+}
+
+type Service struct {
+	repo     Repository
+	invoices invoice.Repository
+	notifier Notifier
+}
+
+func NewService(repo Repository, invoices invoice.Repository, notifier Notifier) *Service {
+	return &Service{
+		repo:     repo,
+		invoices: invoices,
+		notifier: notifier,
+	}
+}
+
+func (s *Service) CreateSequence(ctx context.Context, p organization.Principal, req SequenceRequest) (*Sequence, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByOrganizationID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting reminder sequences: %w", err)
+	}
+
+	sequence := &Sequence{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		Name:           req.Name,
+		IsDefault:      req.IsDefault || len(existing) == 0, // An organization's first sequence is its default
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if sequence.Steps, err = buildSteps(sequence.ID, req.Steps, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, sequence); err != nil {
+		return nil, fmt.Errorf("creating reminder sequence: %w", err)
+	}
+
+	return sequence, nil
+}
+
+func (s *Service) GetSequence(ctx context.Context, p organization.Principal, sequenceID uuid.UUID) (*Sequence, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	sequence, err := s.repo.GetByID(ctx, sequenceID)
+	if err != nil {
+		return nil, ErrSequenceNotFound
+	}
+
+	if !p.Owns(sequence.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return sequence, nil
+}
+
+func (s *Service) ListSequences(ctx context.Context, p organization.Principal) ([]Sequence, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrganizationID(ctx, p.OrganizationID)
+}
+
+// manageSequence returns the sequence if the principal may change it
+func (s *Service) manageSequence(ctx context.Context, p organization.Principal, sequenceID uuid.UUID) (*Sequence, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	return s.GetSequence(ctx, p, sequenceID)
+}
+
+func (s *Service) UpdateSequence(ctx context.Context, p organization.Principal, sequenceID uuid.UUID, req SequenceRequest) (*Sequence, error) {
+	sequence, err := s.manageSequence(ctx, p, sequenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := buildSteps(sequence.ID, req.Steps, sequence.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence.Name = req.Name
+	sequence.IsDefault = req.IsDefault
+	sequence.Steps = steps
+	sequence.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, sequence); err != nil {
+		return nil, fmt.Errorf("updating reminder sequence: %w", err)
+	}
+
+	return sequence, nil
+}
+
+func (s *Service) DeleteSequence(ctx context.Context, p organization.Principal, sequenceID uuid.UUID) error {
+	if _, err := s.manageSequence(ctx, p, sequenceID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, sequenceID)
+}
+
+// AssignSequence sets the sequence an invoice follows; nil reverts to the
+// organization's default
+func (s *Service) AssignSequence(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, sequenceID *uuid.UUID) (*invoice.Invoice, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, invoice.ErrUnauthorized
+	}
+
+	if sequenceID != nil {
+		if _, err := s.GetSequence(ctx, p, *sequenceID); err != nil {
+			return nil, err
+		}
+	}
+
+	inv.DunningSequenceID = sequenceID
+	inv.UpdatedAt = time.Now()
+	if err := s.invoices.Update(ctx, inv); err != nil {
+		return nil, fmt.Errorf("updating invoice: %w", err)
+	}
+
+	return inv, nil
+}
+
+func (s *Service) ListReminders(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Reminder, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, invoice.ErrUnauthorized
+	}
+
+	return s.repo.GetReminders(ctx, invoiceID)
+}
+
+// SendDue runs every reminder step due on or before asOf and returns the
+// number of reminders sent. When several steps of one invoice are due at once
+// (say the worker was down) only the latest is sent and the rest are skipped.
+// Nothing runs, and no late fee is charged, without email configured.
+func (s *Service) SendDue(ctx context.Context, asOf time.Time) (int, error) {
+	if !s.notifier.EmailConfigured() {
+		return 0, invoice.ErrEmailNotConfigured
+	}
+
+	due, err := s.repo.GetDueSteps(ctx, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("getting due reminders: %w", err)
+	}
+
+	sent := 0
+	for start := 0; start < len(due); {
+		end := start + 1
+		for end < len(due) && due[end].InvoiceID == due[start].InvoiceID {
+			end++
+		}
+		group := due[start:end]
+		start = end
+
+		for _, skipped := range group[:len(group)-1] {
+			s.skip(ctx, skipped)
+		}
+
+		ok, err := s.remind(ctx, group[len(group)-1])
+		if err != nil {
+			slog.Error("failed to send payment reminder", "invoice_id", group[0].InvoiceID, "error", err)
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Service) remind(ctx context.Context, due DueStep) (bool, error) {
+	inv, err := s.invoices.GetByID(ctx, due.InvoiceID)
+	if err != nil {
+		return false, fmt.Errorf("getting invoice: %w", err)
+	}
+
+	// Stop conditions are checked again in case the invoice changed since the query
+	if !inv.AcceptsReminders() {
+		return false, nil
+	}
+
+	// The step is claimed before anything is charged or sent, so a run that
+	// fails halfway never charges its late fee again
+	stepID := due.ID
+	reminder := &Reminder{
+		ID:        uuid.New(),
+		InvoiceID: inv.ID,
+		StepID:    &stepID,
+		Status:    ReminderPending,
+		CreatedAt: time.Now(),
+	}
+	var fee *invoice.InvoiceItem
+	if amount := currency.Round(due.LateFee(inv.BalanceDue()), inv.Currency); amount > 0 {
+		item := inv.NewLateFee(fmt.Sprintf("Late fee: payment reminder, %s", describeOffset(due.OffsetDays)), amount)
+		fee = &item
+	}
+	claimed, err := s.repo.ClaimStep(ctx, reminder, fee)
+	if err != nil {
+		return false, fmt.Errorf("recording reminder: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	if fee != nil {
+		inv.AddLateFee(*fee)
+		s.logActivity(ctx, invoice.NewActivity(inv, invoice.ActivityLateFee, fmt.Sprintf("Late fee of %s charged: payment reminder",
+			currency.Format(fee.Amount, inv.Currency))))
+	}
+
+	delivery, err := s.notifier.EmailInvoice(ctx, inv, due.Template, invoice.SendOptions{Message: due.Message})
+	if delivery != nil {
+		reminder.DeliveryID = &delivery.ID
+	}
+
+	if err != nil {
+		s.finish(ctx, reminder, ReminderFailed, err.Error())
+		s.logActivity(ctx, invoice.NewActivity(inv, invoice.ActivityReminderFailed,
+			fmt.Sprintf("Payment reminder could not be sent: %v", err)))
+		return false, err
+	}
+
+	s.finish(ctx, reminder, ReminderSent, "")
+	s.logActivity(ctx, invoice.NewActivity(inv, invoice.ActivityReminderSent,
+		fmt.Sprintf("Payment reminder sent to %s (%s)", delivery.Recipient, describeOffset(due.OffsetDays))))
+	return true, nil
+}
+
+func (s *Service) finish(ctx context.Context, reminder *Reminder, status ReminderStatus, errMsg string) {
+	reminder.Status = status
+	reminder.Error = errMsg
+	if err := s.repo.FinishReminder(ctx, reminder); err != nil {
+		slog.Error("failed to record payment reminder", "invoice_id", reminder.InvoiceID, "step_id", *reminder.StepID,
+			"error", err)
+	}
+}
+
+func (s *Service) skip(ctx context.Context, due DueStep) {
+	stepID := due.ID
+	reminder := &Reminder{
+		ID:        uuid.New(),
+		InvoiceID: due.InvoiceID,
+		StepID:    &stepID,
+		Status:    ReminderSkipped,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.RecordReminder(ctx, reminder); err != nil {
+		slog.Error("failed to record payment reminder", "invoice_id", due.InvoiceID, "step_id", due.ID, "error", err)
+	}
+}
+
+func (s *Service) logActivity(ctx context.Context, activity *invoice.Activity) {
+	if err := s.notifier.LogActivity(ctx, activity); err != nil {
+		slog.Error("failed to record invoice activity", "invoice_id", activity.InvoiceID, "error", err)
+	}
+}
+
+// buildSteps validates the requested steps and orders them by offset. Steps
+// that carry the ID of an existing step keep it.
+func buildSteps(sequenceID uuid.UUID, reqs []StepRequest, existing []Step) ([]Step, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: at least one step is required", ErrInvalidSequence)
+	}
+
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, step := range existing {
+		known[step.ID] = true
+	}
+
+	offsets := make(map[int]bool, len(reqs))
+	steps := make([]Step, len(reqs))
+	for i, req := range reqs {
+		if !IsValidTemplate(req.Template) {
+			return nil, fmt.Errorf("%w: unknown template %q", ErrInvalidSequence, req.Template)
+		}
+		if offsets[req.OffsetDays] {
+			return nil, fmt.Errorf("%w: two steps at %d days", ErrInvalidSequence, req.OffsetDays)
+		}
+		offsets[req.OffsetDays] = true
+
+		feeType := req.LateFeeType
+		if feeType == "" {
+			feeType = LateFeeNone
+		}
+		switch {
+		case feeType == LateFeeNone:
+		case feeType == LateFeePercent && req.LateFeeValue > 0 && req.LateFeeValue <= 100:
+		case feeType == LateFeeFixed && req.LateFeeValue > 0:
+		default:
+			return nil, fmt.Errorf("%w: invalid late fee", ErrInvalidSequence)
+		}
+		if feeType != LateFeeNone && req.OffsetDays <= 0 {
+			return nil, fmt.Errorf("%w: late fees only apply after the due date", ErrInvalidSequence)
+		}
+
+		id := uuid.New()
+		if req.ID != nil && known[*req.ID] {
+			id = *req.ID
+		}
+
+		steps[i] = Step{
+			ID:           id,
+			SequenceID:   sequenceID,
+			OffsetDays:   req.OffsetDays,
+			Template:     req.Template,
+			Message:      req.Message,
+			LateFeeType:  feeType,
+			LateFeeValue: req.LateFeeValue,
+		}
+	}
+
+	sort.Slice(steps, func(i, j int) bool { return steps[i].OffsetDays < steps[j].OffsetDays })
+	for i := range steps {
+		steps[i].SortOrder = i
+	}
+
+	return steps, nil
+}
+
+func describeOffset(days int) string {
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%d days before due", -days)
+	case days == 0:
+		return "due today"
+	default:
+		return fmt.Sprintf("%d days overdue", days)
+	}
+}
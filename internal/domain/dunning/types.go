@@ -0,0 +1,19 @@
+// internal/domain/dunning/types.go
+package dunning
+
+import "github.com/google/uuid"
+
+type SequenceRequest struct {
+	Name      string
+	IsDefault bool
+	Steps     []StepRequest
+}
+
+type StepRequest struct {
+	ID           *uuid.UUID // Keeps the step's reminder history when updating
+	OffsetDays   int
+	Template     string
+	Message      string
+	LateFeeType  LateFeeType
+	LateFeeValue float64
+}
@@ -0,0 +1,22 @@
+// internal/domain/fx/entity.go
+package fx
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rate is a manually entered exchange rate: one unit of BaseCurrency buys
+// Rate units of QuoteCurrency from EffectiveDate until a newer rate exists.
+type Rate struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	BaseCurrency   string    `db:"base_currency"`
+	QuoteCurrency  string    `db:"quote_currency"`
+	Rate           float64   `db:"rate"`
+	EffectiveDate  time.Time `db:"effective_date"`
+	Source         string    `db:"source"`
+	CreatedAt      time.Time `db:"created_at"`
+}
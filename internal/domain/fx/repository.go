@@ -0,0 +1,17 @@
+// internal/domain/fx/repository.go
+package fx
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, rate *Rate) error
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]Rate, error)
+	// FindLatest returns the newest rate effective on or before the given
+	// date, or ErrRateNotFound
+	FindLatest(ctx context.Context, organizationID uuid.UUID, base, quote string, on time.Time) (*Rate, error)
+}
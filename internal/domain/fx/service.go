@@ -0,0 +1,128 @@
+// internal/domain/fx/service.go
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrRateNotFound    = fmt.Errorf("exchange rate not found")
+	ErrInvalidCurrency = fmt.Errorf("invalid currency code")
+	ErrInvalidRate     = fmt.Errorf("exchange rate must be positive")
+)
+
+// RateProvider supplies exchange rates from outside the database, such as a
+// rates file or a market data API. It returns ErrRateNotFound when it has no
+// rate for the pair.
+type RateProvider interface {
+	Rate(ctx context.Context, base, quote string, on time.Time) (float64, error)
+}
+
+type Service struct {
+	repo          Repository
+	provider      RateProvider
+	organizations organization.Repository
+}
+
+// NewService creates the exchange rate service. Manually entered rates win
+// over the provider, which may be nil.
+func NewService(repo Repository, provider RateProvider, organizations organization.Repository) *Service {
+	return &Service{
+		repo:          repo,
+		provider:      provider,
+		organizations: organizations,
+	}
+}
+
+type CreateRateRequest struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          float64
+	EffectiveDate time.Time
+}
+
+// BaseCurrency returns the currency the organization reports in
+func (s *Service) BaseCurrency(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	org, err := s.organizations.GetByID(ctx, organizationID)
+	if err != nil {
+		return "", fmt.Errorf("getting organization: %w", err)
+	}
+	return org.BaseCurrency, nil
+}
+
+// Rate returns how many units of the to currency one unit of the from
+// currency was worth on the given date, by the organization's rates
+func (s *Service) Rate(ctx context.Context, organizationID uuid.UUID, from, to string, on time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	if rate, err := s.repo.FindLatest(ctx, organizationID, from, to, on); err == nil {
+		return rate.Rate, nil
+	} else if !errors.Is(err, ErrRateNotFound) {
+		return 0, err
+	}
+
+	if rate, err := s.repo.FindLatest(ctx, organizationID, to, from, on); err == nil {
+		return 1 / rate.Rate, nil
+	} else if !errors.Is(err, ErrRateNotFound) {
+		return 0, err
+	}
+
+	if s.provider != nil {
+		return s.provider.Rate(ctx, from, to, on)
+	}
+
+	return 0, ErrRateNotFound
+}
+
+func (s *Service) CreateRate(ctx context.Context, p organization.Principal, req CreateRateRequest) (*Rate, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	if !currency.IsValid(req.BaseCurrency) || !currency.IsValid(req.QuoteCurrency) || req.BaseCurrency == req.QuoteCurrency {
+		return nil, ErrInvalidCurrency
+	}
+
+	if req.Rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+
+	rate := &Rate{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		BaseCurrency:   req.BaseCurrency,
+		QuoteCurrency:  req.QuoteCurrency,
+		Rate:           req.Rate,
+		EffectiveDate:  req.EffectiveDate,
+		Source:         "manual",
+		CreatedAt:      time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, rate); err != nil {
+		return nil, fmt.Errorf("creating exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (s *Service) ListRates(ctx context.Context, p organization.Principal) ([]Rate, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	rates, err := s.repo.GetByOrganizationID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("listing exchange rates: %w", err)
+	}
+	return rates, nil
+}
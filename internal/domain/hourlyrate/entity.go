@@ -0,0 +1,51 @@
+// internal/domain/hourlyrate/entity.go
+package hourlyrate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope is what a rate applies to
+type Scope string
+
+const (
+	ScopeTask    Scope = "task"
+	ScopeProject Scope = "project"
+	ScopeClient  Scope = "client"
+	ScopeUser    Scope = "user" // A member's default rate; ScopeID is their user ID
+)
+
+// Source is where a time entry's rate came from: the entry's own rate, or
+// the scope it was resolved from
+type Source string
+
+const SourceEntry Source = "entry"
+
+// Rate is an hourly rate for a scope from EffectiveDate until a newer rate
+// for the same scope takes over
+type Rate struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Scope          Scope     `db:"scope"`
+	ScopeID        uuid.UUID `db:"scope_id"`
+	HourlyRate     float64   `db:"hourly_rate"`
+	EffectiveDate  time.Time `db:"effective_date"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// Level is one step of the rate hierarchy. Standing is the rate set on the
+// task or project itself, used when no dated rate is effective.
+type Level struct {
+	Scope    Scope
+	ID       uuid.UUID
+	Standing *float64
+}
+
+// Resolved is the rate a time entry is billed at
+type Resolved struct {
+	HourlyRate float64
+	Source     Source
+}
@@ -0,0 +1,24 @@
+// internal/domain/hourlyrate/repository.go
+package hourlyrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ListFilters struct {
+	Scope   *Scope
+	ScopeID *uuid.UUID
+}
+
+type Repository interface {
+	Create(ctx context.Context, rate *Rate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Rate, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters ListFilters) ([]Rate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindLatest returns the newest rate for the scope effective on or
+	// before the given date, or ErrRateNotFound
+	FindLatest(ctx context.Context, organizationID uuid.UUID, scope Scope, scopeID uuid.UUID, on time.Time) (*Rate, error)
+}
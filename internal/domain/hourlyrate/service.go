@@ -0,0 +1,174 @@
+// internal/domain/hourlyrate/service.go
+package hourlyrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+var (
+	ErrRateNotFound  = fmt.Errorf("hourly rate not found")
+	ErrUnauthorized  = fmt.Errorf("unauthorized access")
+	ErrInvalidRate   = fmt.Errorf("invalid hourly rate")
+	ErrNoRate        = fmt.Errorf("no hourly rate")
+	ErrScopeNotFound = fmt.Errorf("rate scope not found")
+)
+
+type Service struct {
+	repo          Repository
+	projects      project.Repository
+	clients       client.Repository
+	organizations organization.Repository
+}
+
+func NewService(repo Repository, projects project.Repository, clients client.Repository,
+	organizations organization.Repository) *Service {
+	return &Service{
+		repo:          repo,
+		projects:      projects,
+		clients:       clients,
+		organizations: organizations,
+	}
+}
+
+type CreateRateRequest struct {
+	Scope         Scope
+	ScopeID       *uuid.UUID // For the user scope, defaults to the principal
+	HourlyRate    float64
+	EffectiveDate time.Time
+}
+
+// CreateRate sets the rate for a scope from a date. A rate already set for
+// that scope and date is replaced.
+func (s *Service) CreateRate(ctx context.Context, p organization.Principal, req CreateRateRequest) (*Rate, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	if req.HourlyRate < 0 {
+		return nil, fmt.Errorf("%w: rates can't be negative", ErrInvalidRate)
+	}
+
+	scopeID, err := s.scopeID(ctx, p, req.Scope, req.ScopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := &Rate{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		Scope:          req.Scope,
+		ScopeID:        scopeID,
+		HourlyRate:     req.HourlyRate,
+		EffectiveDate:  req.EffectiveDate,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, rate); err != nil {
+		return nil, fmt.Errorf("creating hourly rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (s *Service) ListRates(ctx context.Context, p organization.Principal, filters ListFilters) ([]Rate, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	rates, err := s.repo.GetByOrganizationID(ctx, p.OrganizationID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("listing hourly rates: %w", err)
+	}
+	return rates, nil
+}
+
+func (s *Service) DeleteRate(ctx context.Context, p organization.Principal, rateID uuid.UUID) error {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return err
+	}
+
+	rate, err := s.repo.GetByID(ctx, rateID)
+	if err != nil {
+		return ErrRateNotFound
+	}
+
+	if !p.Owns(rate.OrganizationID) {
+		return ErrUnauthorized
+	}
+
+	return s.repo.Delete(ctx, rateID)
+}
+
+// Resolve works out the rate for time worked on the given date. The entry's
+// own rate wins; otherwise each level is tried in order, first for a dated
+// rate and then for its standing rate. It returns ErrNoRate when no level
+// has a rate.
+func (s *Service) Resolve(ctx context.Context, organizationID uuid.UUID, on time.Time, override *float64, levels []Level) (*Resolved, error) {
+	if override != nil {
+		return &Resolved{HourlyRate: *override, Source: SourceEntry}, nil
+	}
+
+	for _, level := range levels {
+		rate, err := s.repo.FindLatest(ctx, organizationID, level.Scope, level.ID, on)
+		if err == nil {
+			return &Resolved{HourlyRate: rate.HourlyRate, Source: Source(level.Scope)}, nil
+		}
+		if !errors.Is(err, ErrRateNotFound) {
+			return nil, err
+		}
+
+		if level.Standing != nil {
+			return &Resolved{HourlyRate: *level.Standing, Source: Source(level.Scope)}, nil
+		}
+	}
+
+	return nil, ErrNoRate
+}
+
+// scopeID checks the scope belongs to the organization and returns its ID
+func (s *Service) scopeID(ctx context.Context, p organization.Principal, scope Scope, id *uuid.UUID) (uuid.UUID, error) {
+	if scope == ScopeUser {
+		if id == nil || *id == p.UserID {
+			return p.UserID, nil
+		}
+		if _, err := s.organizations.GetMember(ctx, p.OrganizationID, *id); err != nil {
+			return uuid.Nil, ErrScopeNotFound
+		}
+		return *id, nil
+	}
+	if id == nil {
+		return uuid.Nil, fmt.Errorf("%w: a scope ID is required", ErrInvalidRate)
+	}
+
+	switch scope {
+	case ScopeTask:
+		task, err := s.projects.GetTask(ctx, *id)
+		if err != nil {
+			return uuid.Nil, ErrScopeNotFound
+		}
+		if proj, err := s.projects.GetByID(ctx, task.ProjectID); err != nil || !p.Owns(proj.OrganizationID) {
+			return uuid.Nil, ErrScopeNotFound
+		}
+	case ScopeProject:
+		if proj, err := s.projects.GetByID(ctx, *id); err != nil || !p.Owns(proj.OrganizationID) {
+			return uuid.Nil, ErrScopeNotFound
+		}
+	case ScopeClient:
+		if c, err := s.clients.GetByID(ctx, *id); err != nil || !p.Owns(c.OrganizationID) {
+			return uuid.Nil, ErrScopeNotFound
+		}
+	default:
+		return uuid.Nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidRate, scope)
+	}
+
+	return *id, nil
+}
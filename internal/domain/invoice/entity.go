@@ -2,35 +2,86 @@
 package invoice
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
 	"github.com/google/uuid"
 
 	"time"
+
+	"github.com/invoice-app-be/internal/pkg/currency"
 )
 
 type Status string
 
 const (
-	StatusDraft     Status = "draft"
-	StatusSent      Status = "sent"
-	StatusPaid      Status = "paid"
-	StatusOverdue   Status = "overdue"
-	StatusCancelled Status = "cancelled"
+	StatusDraft         Status = "draft"
+	StatusSent          Status = "sent"
+	StatusPartiallyPaid Status = "partially_paid"
+	StatusPaid          Status = "paid"
+	StatusOverdue       Status = "overdue"
+	StatusCancelled     Status = "cancelled"
+)
+
+type DiscountType string
+
+const (
+	DiscountNone    DiscountType = "none"
+	DiscountPercent DiscountType = "percent"
+	DiscountFixed   DiscountType = "fixed"
 )
 
 type Invoice struct {
-	ID            uuid.UUID `db:"id"`
-	UserID        uuid.UUID `db:"user_id"`
-	ClientID      uuid.UUID `db:"client_id"`
-	InvoiceNumber string    `db:"invoice_number"`
-	Status        Status    `db:"status"`
-	IssueDate     time.Time `db:"issue_date"`
-	DueDate       time.Time `db:"due_date"`
-	Subtotal      float64   `db:"subtotal"`
-	TaxRate       float64   `db:"tax_rate"`
-	TaxAmount     float64   `db:"tax_amount"`
-	Total         float64   `db:"total"`
-	Currency      string    `db:"currency"`
-	Notes         string    `db:"notes"`
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	ClientID       uuid.UUID `db:"client_id"`
+	InvoiceNumber  string    `db:"invoice_number"`
+	Status         Status    `db:"status"`
+	IssueDate      time.Time `db:"issue_date"`
+	DueDate        time.Time `db:"due_date"`
+	Subtotal       float64   `db:"subtotal"` // Sum of line amounts, after line discounts
+	TaxRate        float64   `db:"tax_rate"` // Default rate for lines without tax codes
+	TaxAmount      float64   `db:"tax_amount"`
+	Total          float64   `db:"total"`
+	AmountPaid     float64   `db:"amount_paid"` // Net of refunds
+	Currency       string    `db:"currency"`
+	Notes          string    `db:"notes"`
+
+	// Exchange rate from Currency to the issuer's base currency on the issue date
+	BaseCurrency string  `db:"base_currency"`
+	ExchangeRate float64 `db:"exchange_rate"`
+
+	// PricesIncludeTax means item unit prices are gross and tax is backed out of them
+	PricesIncludeTax bool `db:"prices_include_tax"`
+
+	// Invoice-level discount, taken off the subtotal before tax
+	DiscountType   DiscountType `db:"discount_type"`
+	DiscountValue  float64      `db:"discount_value"`
+	DiscountAmount float64      `db:"discount_amount"`
+
+	// Early payment terms, e.g. 2/10 net 30 is 2 percent off when paid within 10 days
+	EarlyPaymentDiscountPercent float64 `db:"early_payment_discount_percent"`
+	EarlyPaymentDiscountDays    int     `db:"early_payment_discount_days"`
+
+	// Late fees charged after the due date, added to the total after tax.
+	// Each is also one of the invoice's late fee lines.
+	LateFees float64 `db:"late_fees"`
+
+	// Payment reminders; DunningSequenceID overrides the user's default sequence
+	RemindersPaused   bool       `db:"reminders_paused"`
+	DunningSequenceID *uuid.UUID `db:"dunning_sequence_id"`
+
+	// Set the first time the client opens the invoice through a share link
+	ViewedAt *time.Time `db:"viewed_at"`
+
+	// Set when the invoice was generated by a recurring schedule
+	RecurringScheduleID *uuid.UUID `db:"recurring_schedule_id"`
+
+	// Set when the invoice was converted from an accepted quote
+	QuoteID *uuid.UUID `db:"quote_id"`
 
 	// Integration fields
 	SquareInvoiceID *string `db:"square_invoice_id"`
@@ -47,19 +98,282 @@ type InvoiceItem struct {
 	Description string    `db:"description"`
 	Quantity    float64   `db:"quantity"`
 	UnitPrice   float64   `db:"unit_price"`
-	Amount      float64   `db:"amount"`
+	Amount      float64   `db:"amount"` // Net line amount, after line discount and excluding tax
+	TaxExempt   bool      `db:"tax_exempt"`
+	TaxAmount   float64   `db:"tax_amount"`
 	SortOrder   int       `db:"sort_order"`
 	CreatedAt   time.Time `db:"created_at"`
+
+	// IsLateFee marks a fee charged after the due date. Late fee lines are
+	// left out of the subtotal, discounts and tax, and add to LateFees.
+	IsLateFee bool `db:"is_late_fee"`
+
+	// Line discount, taken off quantity times unit price
+	DiscountType   DiscountType `db:"discount_type"`
+	DiscountValue  float64      `db:"discount_value"`
+	DiscountAmount float64      `db:"discount_amount"`
+
+	Taxes []ItemTax
+}
+
+// ItemTax is a tax applied to a single line. Simple taxes are charged on the
+// line's net amount; compound taxes are charged on the net amount plus every
+// tax applied before them.
+type ItemTax struct {
+	ID            uuid.UUID  `db:"id"`
+	InvoiceItemID uuid.UUID  `db:"invoice_item_id"`
+	TaxCodeID     *uuid.UUID `db:"tax_code_id"`
+	Name          string     `db:"name"`
+	Rate          float64    `db:"rate"`
+	IsCompound    bool       `db:"is_compound"`
+	TaxableAmount float64    `db:"taxable_amount"`
+	Amount        float64    `db:"amount"`
+	SortOrder     int        `db:"sort_order"`
+}
+
+// TaxSummary is one row of an invoice's tax breakdown
+type TaxSummary struct {
+	Name          string
+	Rate          float64
+	IsCompound    bool
+	TaxableAmount float64
+	Amount        float64
+}
+
+// TaxCode is a reusable tax rate from an organization's catalog
+type TaxCode struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Name           string    `db:"name"`
+	Rate           float64   `db:"rate"`
+	IsCompound     bool      `db:"is_compound"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliverySent   DeliveryStatus = "sent"
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery is one attempt to email an invoice to its client
+type Delivery struct {
+	ID        uuid.UUID      `db:"id"`
+	InvoiceID uuid.UUID      `db:"invoice_id"`
+	UserID    uuid.UUID      `db:"user_id"`
+	Recipient string         `db:"recipient"`
+	CC        string         `db:"cc"`  // Comma separated
+	BCC       string         `db:"bcc"` // Comma separated
+	ReplyTo   string         `db:"reply_to"`
+	Template  string         `db:"template"` // invoice, or the reminder template
+	Status    DeliveryStatus `db:"status"`
+	MessageID string         `db:"message_id"`
+	Error     string         `db:"error"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type ActivityType string
+
+const (
+	ActivitySent             ActivityType = "sent"
+	ActivityOverdue          ActivityType = "overdue"
+	ActivityReminderSent     ActivityType = "reminder_sent"
+	ActivityReminderFailed   ActivityType = "reminder_failed"
+	ActivityLateFee          ActivityType = "late_fee"
+	ActivityRemindersPaused  ActivityType = "reminders_paused"
+	ActivityRemindersResumed ActivityType = "reminders_resumed"
+	ActivityViewed           ActivityType = "viewed"
+	ActivityRevertedToDraft  ActivityType = "reverted_to_draft"
+)
+
+// Activity is an entry in an invoice's timeline
+type Activity struct {
+	ID        uuid.UUID    `db:"id"`
+	InvoiceID uuid.UUID    `db:"invoice_id"`
+	UserID    uuid.UUID    `db:"user_id"`
+	Type      ActivityType `db:"type"`
+	Message   string       `db:"message"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func NewActivity(inv *Invoice, activityType ActivityType, message string) *Activity {
+	return &Activity{
+		ID:        uuid.New(),
+		InvoiceID: inv.ID,
+		UserID:    inv.UserID,
+		Type:      activityType,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
 }
 
 // Business logic methods
+
+// CalculateTotals applies discounts and taxes in a fixed order: line
+// discounts first, then the invoice discount spread across the lines in
+// proportion to their amounts, then taxes on what remains.
 func (i *Invoice) CalculateTotals() {
+	places := currency.MinorUnits(i.Currency)
 	i.Subtotal = 0
+	for idx := range i.Items {
+		if i.Items[idx].IsLateFee {
+			continue
+		}
+		i.Items[idx].calculateAmount(i.PricesIncludeTax, places)
+		i.Subtotal += i.Items[idx].Amount
+	}
+	i.Subtotal = i.round(i.Subtotal)
+	i.DiscountAmount = discountAmount(i.DiscountType, i.DiscountValue, i.Subtotal, places)
+
+	shares := allocate(i.DiscountAmount, i.Items, places)
+	i.TaxAmount = 0
+	for idx := range i.Items {
+		if i.Items[idx].IsLateFee {
+			continue
+		}
+		i.Items[idx].calculateTaxes(i.PricesIncludeTax, shares[idx], places)
+		i.TaxAmount += i.Items[idx].TaxAmount
+	}
+	i.Subtotal = 0
+	for _, item := range i.Items {
+		if !item.IsLateFee {
+			i.Subtotal += item.Amount
+		}
+	}
+	i.Subtotal = i.round(i.Subtotal)
+	i.TaxAmount = i.round(i.TaxAmount)
+	i.Total = i.round(i.Subtotal - i.DiscountAmount + i.TaxAmount + i.LateFees)
+}
+
+// NewLateFee returns a tax-exempt late fee line for the invoice, to be
+// charged with AddLateFee
+func (i *Invoice) NewLateFee(description string, amount float64) InvoiceItem {
+	amount = i.round(amount)
+	sortOrder := 0
 	for _, item := range i.Items {
-		i.Subtotal += item.Amount
+		sortOrder = max(sortOrder, item.SortOrder+1)
+	}
+	return InvoiceItem{
+		ID:           uuid.New(),
+		InvoiceID:    i.ID,
+		Description:  description,
+		Quantity:     1,
+		UnitPrice:    amount,
+		Amount:       amount,
+		TaxExempt:    true,
+		SortOrder:    sortOrder,
+		CreatedAt:    time.Now(),
+		DiscountType: DiscountNone,
+		IsLateFee:    true,
+	}
+}
+
+// AddLateFee adds the late fee line and charges it on top of the current total
+func (i *Invoice) AddLateFee(fee InvoiceItem) {
+	i.Items = append(i.Items, fee)
+	i.LateFees = i.round(i.LateFees + fee.Amount)
+	i.Total = i.round(i.Total + fee.Amount)
+	i.UpdatedAt = time.Now()
+}
+
+// LateFeeItems returns the invoice's late fee lines
+func (i *Invoice) LateFeeItems() []InvoiceItem {
+	var fees []InvoiceItem
+	for _, item := range i.Items {
+		if item.IsLateFee {
+			fees = append(fees, item)
+		}
+	}
+	return fees
+}
+
+// MarkAsOverdue moves a sent invoice past its due date to overdue
+func (i *Invoice) MarkAsOverdue(asOf time.Time) bool {
+	if i.Status != StatusSent || !truncateDay(asOf).After(truncateDay(i.DueDate)) {
+		return false
+	}
+	i.Status = StatusOverdue
+	i.UpdatedAt = time.Now()
+	return true
+}
+
+// AcceptsReminders reports whether payment reminders may go out: the invoice
+// has been sent, still has a balance, and reminders aren't paused
+func (i *Invoice) AcceptsReminders() bool {
+	switch i.Status {
+	case StatusSent, StatusPartiallyPaid, StatusOverdue:
+		return !i.RemindersPaused && i.BalanceDue() > 0
 	}
-	i.TaxAmount = i.Subtotal * i.TaxRate / 100
-	i.Total = i.Subtotal + i.TaxAmount
+	return false
+}
+
+// DaysOverdue is the number of days past the due date, or negative when not yet due
+func (i *Invoice) DaysOverdue(asOf time.Time) int {
+	return int(truncateDay(asOf).Sub(truncateDay(i.DueDate)).Hours() / 24)
+}
+
+// EarlyPaymentDeadline returns the last day the early payment discount applies
+func (i *Invoice) EarlyPaymentDeadline() (time.Time, bool) {
+	if i.EarlyPaymentDiscountPercent <= 0 || i.EarlyPaymentDiscountDays <= 0 {
+		return time.Time{}, false
+	}
+	return i.IssueDate.AddDate(0, 0, i.EarlyPaymentDiscountDays), true
+}
+
+// EarlyPaymentDiscount returns the amount taken off the total for early payment
+func (i *Invoice) EarlyPaymentDiscount() float64 {
+	if _, ok := i.EarlyPaymentDeadline(); !ok {
+		return 0
+	}
+	return i.round(i.Total * i.EarlyPaymentDiscountPercent / 100)
+}
+
+// AmountDueOn returns what the client owes when paying on the given date
+func (i *Invoice) AmountDueOn(date time.Time) float64 {
+	deadline, ok := i.EarlyPaymentDeadline()
+	if ok && !truncateDay(date).After(truncateDay(deadline)) {
+		return i.round(i.Total - i.EarlyPaymentDiscount())
+	}
+	return i.Total
+}
+
+// PaymentTerms describes the terms in the usual shorthand, e.g. "2/10 net 30"
+func (i *Invoice) PaymentTerms() string {
+	netDays := int(truncateDay(i.DueDate).Sub(truncateDay(i.IssueDate)).Hours() / 24)
+	if _, ok := i.EarlyPaymentDeadline(); !ok {
+		return fmt.Sprintf("net %d", netDays)
+	}
+	return fmt.Sprintf("%s/%d net %d", strconv.FormatFloat(i.EarlyPaymentDiscountPercent, 'f', -1, 64),
+		i.EarlyPaymentDiscountDays, netDays)
+}
+
+// TaxBreakdown groups the line taxes by name and rate, in order of first use
+func (i *Invoice) TaxBreakdown() []TaxSummary {
+	type key struct {
+		name     string
+		rate     float64
+		compound bool
+	}
+
+	index := make(map[key]int)
+	var summary []TaxSummary
+	for _, item := range i.Items {
+		for _, tax := range item.Taxes {
+			k := key{tax.Name, tax.Rate, tax.IsCompound}
+			pos, ok := index[k]
+			if !ok {
+				pos = len(summary)
+				index[k] = pos
+				summary = append(summary, TaxSummary{Name: tax.Name, Rate: tax.Rate, IsCompound: tax.IsCompound})
+			}
+			summary[pos].TaxableAmount = i.round(summary[pos].TaxableAmount + tax.TaxableAmount)
+			summary[pos].Amount = i.round(summary[pos].Amount + tax.Amount)
+		}
+	}
+	return summary
 }
 
 func (i *Invoice) MarkAsSent() error {
@@ -70,11 +384,167 @@ func (i *Invoice) MarkAsSent() error {
 	return nil
 }
 
-func (i *Invoice) MarkAsPaid(paymentID string) error {
-	if i.Status != StatusSent && i.Status != StatusOverdue {
+// RevertToDraft takes a sent invoice back to draft so it can be corrected,
+// which is only possible while nothing has been paid against it
+func (i *Invoice) RevertToDraft() error {
+	if (i.Status != StatusSent && i.Status != StatusOverdue) || i.AmountPaid != 0 {
 		return ErrInvalidStatusTransition
 	}
-	i.Status = StatusPaid
-	i.SquarePaymentID = &paymentID
+	i.Status = StatusDraft
+	i.UpdatedAt = time.Now()
 	return nil
 }
+
+// AcceptsPayments reports whether payments can be recorded against the invoice
+func (i *Invoice) AcceptsPayments() bool {
+	return i.Status != StatusDraft && i.Status != StatusCancelled
+}
+
+// BaseTotal is the invoice total in the issuer's base currency at the issue date rate
+func (i *Invoice) BaseTotal() float64 {
+	return currency.Round(i.Total*i.ExchangeRate, i.BaseCurrency)
+}
+
+// BalanceDue is what is still owed, ignoring any early payment discount
+func (i *Invoice) BalanceDue() float64 {
+	return i.round(i.Total - i.AmountPaid)
+}
+
+// ApplyPayments sets the amount paid from the payment ledger and moves the
+// status to match. The invoice counts as paid once payments cover what was
+// due on the date of the latest payment, so early payment discounts apply.
+func (i *Invoice) ApplyPayments(totalPaid float64, lastPaidAt time.Time) {
+	i.AmountPaid = i.round(totalPaid)
+
+	switch {
+	case i.AmountPaid > 0 && i.AmountPaid >= i.AmountDueOn(lastPaidAt):
+		i.Status = StatusPaid
+	case i.AmountPaid > 0:
+		i.Status = StatusPartiallyPaid
+	case truncateDay(time.Now()).After(truncateDay(i.DueDate)):
+		i.Status = StatusOverdue
+	default:
+		i.Status = StatusSent
+	}
+}
+
+// calculateAmount sets the line's discount and net amount. When prices
+// include tax, the net amount is backed out of the discounted gross.
+func (it *InvoiceItem) calculateAmount(pricesIncludeTax bool, places int) {
+	if it.TaxExempt {
+		it.Taxes = nil
+	}
+
+	// Simple taxes go first so compound taxes can build on them
+	sort.SliceStable(it.Taxes, func(a, b int) bool {
+		return !it.Taxes[a].IsCompound && it.Taxes[b].IsCompound
+	})
+
+	gross := currency.RoundTo(it.Quantity*it.UnitPrice, places)
+	it.DiscountAmount = discountAmount(it.DiscountType, it.DiscountValue, gross, places)
+	it.Amount = currency.RoundTo(gross-it.DiscountAmount, places)
+
+	if pricesIncludeTax && len(it.Taxes) > 0 {
+		it.Amount = currency.RoundTo(it.Amount/it.taxFactor(), places)
+	}
+}
+
+// calculateTaxes sets the line's tax amounts on its net amount less its share
+// of the invoice discount. When prices include tax and nothing was taken off
+// at invoice level, rounding drift is absorbed into the net amount so that net
+// plus tax still adds up to the discounted gross price.
+func (it *InvoiceItem) calculateTaxes(pricesIncludeTax bool, invoiceDiscount float64, places int) {
+	it.TaxAmount = 0
+	if len(it.Taxes) == 0 {
+		return
+	}
+
+	base := currency.RoundTo(it.Amount-invoiceDiscount, places)
+	running := base
+	for idx := range it.Taxes {
+		tax := &it.Taxes[idx]
+		tax.SortOrder = idx
+		tax.TaxableAmount = base
+		if tax.IsCompound {
+			tax.TaxableAmount = running
+		}
+		tax.Amount = currency.RoundTo(tax.TaxableAmount*tax.Rate/100, places)
+		running += tax.Amount
+		it.TaxAmount += tax.Amount
+	}
+	it.TaxAmount = currency.RoundTo(it.TaxAmount, places)
+
+	if pricesIncludeTax && invoiceDiscount == 0 {
+		gross := currency.RoundTo(it.Quantity*it.UnitPrice-it.DiscountAmount, places)
+		it.Amount = currency.RoundTo(gross-it.TaxAmount, places)
+	}
+}
+
+// taxFactor is the multiplier from net to gross for the line's taxes
+func (it *InvoiceItem) taxFactor() float64 {
+	simple, compound := 0.0, 1.0
+	for _, tax := range it.Taxes {
+		if tax.IsCompound {
+			compound *= 1 + tax.Rate/100
+		} else {
+			simple += tax.Rate / 100
+		}
+	}
+	return (1 + simple) * compound
+}
+
+// discountAmount returns the discount on base, never more than base itself
+func discountAmount(discountType DiscountType, value, base float64, places int) float64 {
+	var amount float64
+	switch discountType {
+	case DiscountPercent:
+		amount = currency.RoundTo(base*value/100, places)
+	case DiscountFixed:
+		amount = currency.RoundTo(value, places)
+	}
+	return math.Max(0, math.Min(amount, base))
+}
+
+// allocate spreads an invoice discount over the lines, late fees aside, in
+// proportion to their amounts. The last line with an amount takes the
+// rounding remainder.
+func allocate(total float64, items []InvoiceItem, places int) []float64 {
+	shares := make([]float64, len(items))
+	var base float64
+	last := -1
+	for idx, item := range items {
+		if item.IsLateFee {
+			continue
+		}
+		base += item.Amount
+		if item.Amount > 0 {
+			last = idx
+		}
+	}
+	if total <= 0 || base <= 0 {
+		return shares
+	}
+
+	remaining := total
+	for idx, item := range items {
+		if item.IsLateFee {
+			continue
+		}
+		if idx == last {
+			shares[idx] = currency.RoundTo(remaining, places)
+			break
+		}
+		shares[idx] = currency.RoundTo(total*item.Amount/base, places)
+		remaining -= shares[idx]
+	}
+	return shares
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// round rounds an amount to the invoice currency's minor units
+func (i *Invoice) round(v float64) float64 {
+	return currency.Round(v, i.Currency)
+}
@@ -10,12 +10,39 @@ import (
 
 // Repository defines the contract for invoice persistence
 type Repository interface {
+	// Create returns ErrQuoteInvoiced when another invoice was converted
+	// from the same quote
 	Create(ctx context.Context, invoice *Invoice) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Invoice, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, filters ListFilters) ([]Invoice, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters ListFilters) ([]Invoice, error)
 	Update(ctx context.Context, invoice *Invoice) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	GetNextInvoiceNumber(ctx context.Context, userID uuid.UUID) (string, error)
+	// ReplaceItems swaps the invoice's lines for its current ones and saves
+	// its totals. Late fee lines are kept as they are.
+	ReplaceItems(ctx context.Context, invoice *Invoice) error
+	GetNextInvoiceNumber(ctx context.Context, organizationID uuid.UUID) (string, error)
+	// GetPastDue returns sent invoices whose due date is before asOf
+	GetPastDue(ctx context.Context, asOf time.Time) ([]Invoice, error)
+	// MarkViewed sets viewed_at unless already set and reports whether it did
+	MarkViewed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	AddActivity(ctx context.Context, activity *Activity) error
+	GetActivities(ctx context.Context, invoiceID uuid.UUID) ([]Activity, error)
+}
+
+// TaxCodeRepository defines the contract for the per-organization tax rate
+// catalog
+type TaxCodeRepository interface {
+	Create(ctx context.Context, code *TaxCode) error
+	GetByID(ctx context.Context, id uuid.UUID) (*TaxCode, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]TaxCode, error)
+	Update(ctx context.Context, code *TaxCode) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DeliveryRepository records each attempt to email an invoice
+type DeliveryRepository interface {
+	Create(ctx context.Context, delivery *Delivery) error
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]Delivery, error)
 }
 
 type ListFilters struct {
@@ -23,6 +50,8 @@ type ListFilters struct {
 	ClientID *uuid.UUID
 	DateFrom *time.Time
 	DateTo   *time.Time
-	Limit    int
-	Offset   int
+	// RecurringScheduleID limits the list to invoices generated by a schedule
+	RecurringScheduleID *uuid.UUID
+	Limit               int
+	Offset              int
 }
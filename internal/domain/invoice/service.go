@@ -4,55 +4,152 @@ package invoice
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/pkg/currency"
+	"github.com/invoice-app-be/internal/pkg/mail"
 )
 
 var (
 	ErrInvoiceNotFound         = fmt.Errorf("invoice not found")
 	ErrInvalidStatusTransition = fmt.Errorf("invalid status transition")
 	ErrUnauthorized            = fmt.Errorf("unauthorized access")
+	ErrTaxCodeNotFound         = fmt.Errorf("tax code not found")
+	ErrInvalidDiscount         = fmt.Errorf("invalid discount")
+	ErrInvalidCurrency         = fmt.Errorf("invalid currency code")
+	ErrExchangeRateNotFound    = fmt.Errorf("no exchange rate to base currency")
+	ErrClientHasNoEmail        = fmt.Errorf("client has no email address")
+	ErrDeliveryFailed          = fmt.Errorf("email delivery failed")
+	ErrEmailNotConfigured      = fmt.Errorf("email delivery is not configured")
+	ErrNotDraft                = fmt.Errorf("only draft invoices can be changed")
+	ErrEmailNotVerified        = fmt.Errorf("verify your email address before sending invoices")
+	ErrQuoteInvoiced           = fmt.Errorf("the quote already has an invoice")
 )
 
 type Service struct {
-	repo      Repository
-	pdfGen    PDFGenerator
-	squareAPI SquareAPI
+	repo          Repository
+	taxCodes      TaxCodeRepository
+	deliveries    DeliveryRepository
+	clients       client.Repository
+	organizations organization.Repository
+	users         user.Repository
+	rates         ExchangeRates
+	pdfGen        PDFGenerator
+	squareAPI     SquareAPI
+	mailer        EmailSender
+
+	requireVerifiedEmail bool
 }
 
-func NewService(repo Repository, pdfGen PDFGenerator, squareAPI SquareAPI) *Service {
+func NewService(
+	repo Repository,
+	taxCodes TaxCodeRepository,
+	deliveries DeliveryRepository,
+	clients client.Repository,
+	organizations organization.Repository,
+	users user.Repository,
+	rates ExchangeRates,
+	pdfGen PDFGenerator,
+	squareAPI SquareAPI,
+	mailer EmailSender,
+) *Service {
 	return &Service{
-		repo:      repo,
-		pdfGen:    pdfGen,
-		squareAPI: squareAPI,
+		repo:          repo,
+		taxCodes:      taxCodes,
+		deliveries:    deliveries,
+		clients:       clients,
+		organizations: organizations,
+		users:         users,
+		rates:         rates,
+		pdfGen:        pdfGen,
+		squareAPI:     squareAPI,
+		mailer:        mailer,
 	}
 }
 
-func (s *Service) CreateInvoice(ctx context.Context, userID uuid.UUID, req CreateInvoiceRequest) (*Invoice, error) {
+// RequireVerifiedEmail stops users who haven't verified their email address
+// from sending invoices
+func (s *Service) RequireVerifiedEmail() *Service {
+	s.requireVerifiedEmail = true
+	return s
+}
+
+func (s *Service) CreateInvoice(ctx context.Context, p organization.Principal, req CreateInvoiceRequest) (*Invoice, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.PriceInvoice(ctx, p, req)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.BaseCurrency, invoice.ExchangeRate, err = s.baseRate(ctx, p.OrganizationID, req.Currency, req.IssueDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate invoice number
-	invoiceNum, err := s.repo.GetNextInvoiceNumber(ctx, userID)
+	invoice.InvoiceNumber, err = s.repo.GetNextInvoiceNumber(ctx, p.OrganizationID)
 	if err != nil {
 		return nil, fmt.Errorf("generating invoice number: %w", err)
 	}
 
+	if err := s.repo.Create(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("creating invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// PriceInvoice builds the draft invoice a request describes, with discounts,
+// taxes and totals worked out, without numbering or saving it
+func (s *Service) PriceInvoice(ctx context.Context, p organization.Principal, req CreateInvoiceRequest) (*Invoice, error) {
+	if !currency.IsValid(req.Currency) {
+		return nil, ErrInvalidCurrency
+	}
+
 	invoice := &Invoice{
-		ID:            uuid.New(),
-		UserID:        userID,
-		ClientID:      req.ClientID,
-		InvoiceNumber: invoiceNum,
-		Status:        StatusDraft,
-		IssueDate:     req.IssueDate,
-		DueDate:       req.DueDate,
-		TaxRate:       req.TaxRate,
-		Currency:      req.Currency,
-		Notes:         req.Notes,
-		Items:         make([]InvoiceItem, len(req.Items)),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		ID:               uuid.New(),
+		OrganizationID:   p.OrganizationID,
+		UserID:           p.UserID,
+		ClientID:         req.ClientID,
+		Status:           StatusDraft,
+		IssueDate:        req.IssueDate,
+		DueDate:          req.DueDate,
+		TaxRate:          req.TaxRate,
+		PricesIncludeTax: req.PricesIncludeTax,
+		Currency:         req.Currency,
+		Notes:            req.Notes,
+		Items:            make([]InvoiceItem, len(req.Items)),
+
+		RecurringScheduleID: req.RecurringScheduleID,
+		QuoteID:             req.QuoteID,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+
+		DiscountType:                normalizeDiscountType(req.DiscountType),
+		DiscountValue:               req.DiscountValue,
+		EarlyPaymentDiscountPercent: req.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    req.EarlyPaymentDiscountDays,
+	}
+
+	if err := validateDiscount(invoice.DiscountType, invoice.DiscountValue); err != nil {
+		return nil, err
+	}
+	if req.EarlyPaymentDiscountPercent < 0 || req.EarlyPaymentDiscountPercent > 100 || req.EarlyPaymentDiscountDays < 0 {
+		return nil, ErrInvalidDiscount
 	}
 
 	// Add items
+	codes := make(map[uuid.UUID]*TaxCode)
 	for i, item := range req.Items {
 		invoice.Items[i] = InvoiceItem{
 			ID:          uuid.New(),
@@ -60,33 +157,191 @@ func (s *Service) CreateInvoice(ctx context.Context, userID uuid.UUID, req Creat
 			Description: item.Description,
 			Quantity:    item.Quantity,
 			UnitPrice:   item.UnitPrice,
-			Amount:      item.Quantity * item.UnitPrice,
+			TaxExempt:   item.TaxExempt,
 			SortOrder:   i,
 			CreatedAt:   time.Now(),
+
+			DiscountType:  normalizeDiscountType(item.DiscountType),
+			DiscountValue: item.DiscountValue,
+		}
+
+		if err := validateDiscount(invoice.Items[i].DiscountType, item.DiscountValue); err != nil {
+			return nil, err
+		}
+
+		if item.TaxExempt {
+			continue
+		}
+		if item.Taxes != nil {
+			invoice.Items[i].Taxes = copyTaxes(invoice.Items[i].ID, item.Taxes)
+			continue
+		}
+
+		taxes, err := s.lineTaxes(ctx, p.OrganizationID, invoice.Items[i].ID, item.TaxCodeIDs, req.TaxRate, codes)
+		if err != nil {
+			return nil, err
 		}
+		invoice.Items[i].Taxes = taxes
 	}
 
 	invoice.CalculateTotals()
 
-	if err := s.repo.Create(ctx, invoice); err != nil {
-		return nil, fmt.Errorf("creating invoice: %w", err)
+	return invoice, nil
+}
+
+// DeleteDraft deletes a draft invoice, as one left over when billing time on
+// it failed
+func (s *Service) DeleteDraft(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) error {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return err
 	}
 
-	return invoice, nil
+	invoice, err := s.repo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return ErrInvoiceNotFound
+	}
+	if !p.Owns(invoice.OrganizationID) {
+		return ErrUnauthorized
+	}
+	if invoice.Status != StatusDraft {
+		return ErrInvalidStatusTransition
+	}
+
+	if err := s.repo.Delete(ctx, invoiceID); err != nil {
+		return fmt.Errorf("deleting invoice: %w", err)
+	}
+	return nil
 }
 
-func (s *Service) SendInvoice(ctx context.Context, userID, invoiceID uuid.UUID) error {
+func (s *Service) GetInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) (*Invoice, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
 	invoice, err := s.repo.GetByID(ctx, invoiceID)
 	if err != nil {
-		return err
+		return nil, ErrInvoiceNotFound
 	}
 
-	if invoice.UserID != userID {
-		return ErrUnauthorized
+	if !p.Owns(invoice.OrganizationID) {
+		return nil, ErrUnauthorized
 	}
 
-	if err := invoice.MarkAsSent(); err != nil {
-		return err
+	return invoice, nil
+}
+
+// manageInvoice returns the invoice if the principal may change it
+func (s *Service) manageInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) (*Invoice, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	return s.GetInvoice(ctx, p, invoiceID)
+}
+
+// ReplaceItems reprices a draft invoice with new lines, keeping its number,
+// dates, discounts, default tax rate and late fees
+func (s *Service) ReplaceItems(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, items []CreateInvoiceItemRequest) (*Invoice, error) {
+	invoice, err := s.manageInvoice(ctx, p, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Status != StatusDraft {
+		return nil, ErrNotDraft
+	}
+
+	priced, err := s.PriceInvoice(ctx, p, CreateInvoiceRequest{
+		ClientID:         invoice.ClientID,
+		IssueDate:        invoice.IssueDate,
+		DueDate:          invoice.DueDate,
+		TaxRate:          invoice.TaxRate,
+		PricesIncludeTax: invoice.PricesIncludeTax,
+		Currency:         invoice.Currency,
+		Items:            items,
+
+		DiscountType:                invoice.DiscountType,
+		DiscountValue:               invoice.DiscountValue,
+		EarlyPaymentDiscountPercent: invoice.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    invoice.EarlyPaymentDiscountDays,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range priced.Items {
+		priced.Items[i].InvoiceID = invoice.ID
+	}
+	// Late fees charged before the invoice went back to draft stay on it
+	invoice.Items = append(priced.Items, invoice.LateFeeItems()...)
+	invoice.CalculateTotals()
+	invoice.UpdatedAt = time.Now()
+
+	if err := s.repo.ReplaceItems(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("replacing invoice items: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// RevertToDraft takes an unpaid sent invoice back to draft, which unlocks
+// the time billed on it for correction
+func (s *Service) RevertToDraft(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) (*Invoice, error) {
+	invoice, err := s.manageInvoice(ctx, p, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.RevertToDraft(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("updating invoice: %w", err)
+	}
+
+	s.logActivity(ctx, NewActivity(invoice, ActivityRevertedToDraft, "Invoice reverted to draft"))
+
+	return invoice, nil
+}
+
+// SendInvoice emails the invoice PDF to the client and marks a draft as sent.
+// Invoices already sent can be sent again; each attempt is recorded as a
+// delivery. Without a mailer configured the invoice is only marked as sent.
+func (s *Service) SendInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, opts SendOptions) (*Invoice, *Delivery, error) {
+	invoice, err := s.manageInvoice(ctx, p, invoiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.requireVerifiedEmail {
+		sender, err := s.users.GetByID(ctx, p.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting user: %w", err)
+		}
+		if !sender.IsVerified() {
+			return nil, nil, ErrEmailNotVerified
+		}
+	}
+
+	firstSend := invoice.Status == StatusDraft
+	if firstSend {
+		if err := invoice.MarkAsSent(); err != nil {
+			return nil, nil, err
+		}
+	} else if !invoice.AcceptsPayments() || invoice.Status == StatusPaid {
+		return nil, nil, ErrInvalidStatusTransition
+	}
+
+	var delivery *Delivery
+	if s.mailer != nil {
+		delivery, err = s.EmailInvoice(ctx, invoice, "invoice", opts)
+		if err != nil {
+			return nil, delivery, err
+		}
+	}
+
+	if !firstSend {
+		return invoice, delivery, nil
 	}
 
 	// Optionally sync to Square
@@ -94,33 +349,433 @@ func (s *Service) SendInvoice(ctx context.Context, userID, invoiceID uuid.UUID)
 		squareID, err := s.squareAPI.CreateInvoice(ctx, invoice)
 		if err != nil {
 			// Log but don't fail - Square is optional
-			// logger.Error("failed to sync to Square", "error", err)
+			slog.Error("failed to sync invoice to Square", "invoice_id", invoice.ID, "error", err)
 		} else {
 			invoice.SquareInvoiceID = &squareID
 		}
 	}
 
-	return s.repo.Update(ctx, invoice)
+	invoice.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, invoice); err != nil {
+		return nil, delivery, fmt.Errorf("updating invoice: %w", err)
+	}
+	s.logActivity(ctx, NewActivity(invoice, ActivitySent, "Invoice sent"))
+
+	return invoice, delivery, nil
 }
 
-func (s *Service) GeneratePDF(ctx context.Context, userID, invoiceID uuid.UUID) ([]byte, error) {
-	invoice, err := s.repo.GetByID(ctx, invoiceID)
+func (s *Service) ListDeliveries(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Delivery, error) {
+	if _, err := s.GetInvoice(ctx, p, invoiceID); err != nil {
+		return nil, err
+	}
+
+	return s.deliveries.GetByInvoiceID(ctx, invoiceID)
+}
+
+// EmailConfigured reports whether invoices can be emailed
+func (s *Service) EmailConfigured() bool {
+	return s.mailer != nil
+}
+
+// EmailInvoice emails the invoice PDF to its client using the named template
+// and records the attempt as a delivery
+func (s *Service) EmailInvoice(ctx context.Context, invoice *Invoice, template string, opts SendOptions) (*Delivery, error) {
+	if s.mailer == nil {
+		return nil, ErrEmailNotConfigured
+	}
+
+	recipient, err := s.clients.GetByID(ctx, invoice.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("getting client: %w", err)
+	}
+	if recipient.Email == "" {
+		return nil, ErrClientHasNoEmail
+	}
+
+	sender, err := s.organizations.GetByID(ctx, invoice.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting sender: %w", err)
+	}
+
+	pdfBytes, err := s.pdfGen.Generate(ctx, invoice)
+	if err != nil {
+		return nil, fmt.Errorf("generating PDF: %w", err)
+	}
+
+	senderName := sender.Name
+
+	// Replies go to whoever issued the invoice unless the sender says otherwise
+	replyTo := opts.ReplyTo
+	if replyTo == "" {
+		issuer, err := s.users.GetByID(ctx, invoice.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("getting issuer: %w", err)
+		}
+		replyTo = issuer.Email
+	}
+
+	msg := mail.Message{
+		To:       []string{recipient.Email},
+		CC:       opts.CC,
+		BCC:      opts.BCC,
+		ReplyTo:  replyTo,
+		FromName: senderName,
+		Template: template,
+		Data: InvoiceEmail{
+			Invoice:      invoice,
+			Client:       recipient,
+			SenderName:   senderName,
+			Total:        currency.Format(invoice.BalanceDue(), invoice.Currency),
+			DueDate:      invoice.DueDate.Format("January 2, 2006"),
+			PaymentTerms: invoice.PaymentTerms(),
+			DaysOverdue:  invoice.DaysOverdue(time.Now()),
+			LateFees:     currency.Format(invoice.LateFees, invoice.Currency),
+			Message:      opts.Message,
+		},
+		Attachments: []mail.Attachment{{
+			Filename:    fmt.Sprintf("invoice-%s.pdf", invoice.InvoiceNumber),
+			ContentType: "application/pdf",
+			Data:        pdfBytes,
+		}},
+	}
+
+	delivery := &Delivery{
+		ID:        uuid.New(),
+		InvoiceID: invoice.ID,
+		UserID:    invoice.UserID,
+		Recipient: recipient.Email,
+		CC:        strings.Join(opts.CC, ", "),
+		BCC:       strings.Join(opts.BCC, ", "),
+		ReplyTo:   replyTo,
+		Template:  template,
+		Status:    DeliverySent,
+		CreatedAt: time.Now(),
+	}
+
+	messageID, sendErr := s.mailer.Send(ctx, msg)
+	if sendErr != nil {
+		delivery.Status = DeliveryFailed
+		delivery.Error = sendErr.Error()
+	} else {
+		delivery.MessageID = messageID
+	}
+
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("recording delivery: %w", err)
+	}
+
+	if sendErr != nil {
+		return delivery, fmt.Errorf("%w: %v", ErrDeliveryFailed, sendErr)
+	}
+	return delivery, nil
+}
+
+// SetRemindersPaused stops or restarts payment reminders for an invoice
+func (s *Service) SetRemindersPaused(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, paused bool) (*Invoice, error) {
+	invoice, err := s.manageInvoice(ctx, p, invoiceID)
 	if err != nil {
 		return nil, err
 	}
 
-	if invoice.UserID != userID {
-		return nil, ErrUnauthorized
+	if invoice.RemindersPaused == paused {
+		return invoice, nil
+	}
+
+	invoice.RemindersPaused = paused
+	invoice.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("updating invoice: %w", err)
+	}
+
+	if paused {
+		s.logActivity(ctx, NewActivity(invoice, ActivityRemindersPaused, "Payment reminders paused"))
+	} else {
+		s.logActivity(ctx, NewActivity(invoice, ActivityRemindersResumed, "Payment reminders resumed"))
+	}
+
+	return invoice, nil
+}
+
+// MarkOverdue moves every sent invoice past its due date to overdue and
+// returns how many changed
+func (s *Service) MarkOverdue(ctx context.Context, asOf time.Time) (int, error) {
+	invoices, err := s.repo.GetPastDue(ctx, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("getting past due invoices: %w", err)
+	}
+
+	marked := 0
+	for i := range invoices {
+		invoice := &invoices[i]
+		if !invoice.MarkAsOverdue(asOf) {
+			continue
+		}
+		if err := s.repo.Update(ctx, invoice); err != nil {
+			return marked, fmt.Errorf("updating invoice %s: %w", invoice.InvoiceNumber, err)
+		}
+		s.logActivity(ctx, NewActivity(invoice, ActivityOverdue, "Invoice is overdue"))
+		marked++
+	}
+
+	return marked, nil
+}
+
+// MarkViewed records the first time the client opened the invoice
+func (s *Service) MarkViewed(ctx context.Context, invoice *Invoice, at time.Time) error {
+	first, err := s.repo.MarkViewed(ctx, invoice.ID, at)
+	if err != nil {
+		return fmt.Errorf("marking invoice viewed: %w", err)
+	}
+	if !first {
+		return nil
+	}
+
+	invoice.ViewedAt = &at
+	s.logActivity(ctx, NewActivity(invoice, ActivityViewed, "Invoice viewed by the client"))
+	return nil
+}
+
+func (s *Service) ListActivities(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Activity, error) {
+	if _, err := s.GetInvoice(ctx, p, invoiceID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetActivities(ctx, invoiceID)
+}
+
+// LogActivity adds an entry to the invoice timeline
+func (s *Service) LogActivity(ctx context.Context, activity *Activity) error {
+	return s.repo.AddActivity(ctx, activity)
+}
+
+// logActivity records a timeline entry for a change that has already been
+// saved, so a failure is logged rather than returned
+func (s *Service) logActivity(ctx context.Context, activity *Activity) {
+	if err := s.repo.AddActivity(ctx, activity); err != nil {
+		slog.Error("failed to record invoice activity", "invoice_id", activity.InvoiceID,
+			"type", activity.Type, "error", err)
+	}
+}
+
+func (s *Service) GeneratePDF(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]byte, error) {
+	invoice, err := s.GetInvoice(ctx, p, invoiceID)
+	if err != nil {
+		return nil, err
 	}
 
 	return s.pdfGen.Generate(ctx, invoice)
 }
 
+// lineTaxes resolves the tax codes for a line. Lines without codes fall back
+// to the invoice's header tax rate, and a code listed twice is charged once.
+func (s *Service) lineTaxes(ctx context.Context, organizationID, itemID uuid.UUID, codeIDs []uuid.UUID, defaultRate float64, cache map[uuid.UUID]*TaxCode) ([]ItemTax, error) {
+	if len(codeIDs) == 0 {
+		if defaultRate <= 0 {
+			return nil, nil
+		}
+		return []ItemTax{{
+			ID:            uuid.New(),
+			InvoiceItemID: itemID,
+			Name:          "Tax",
+			Rate:          defaultRate,
+		}}, nil
+	}
+
+	taxes := make([]ItemTax, 0, len(codeIDs))
+	seen := make(map[uuid.UUID]bool, len(codeIDs))
+	for _, codeID := range codeIDs {
+		if seen[codeID] {
+			continue
+		}
+		seen[codeID] = true
+
+		code, ok := cache[codeID]
+		if !ok {
+			var err error
+			code, err = s.taxCodes.GetByID(ctx, codeID)
+			if err != nil || code.OrganizationID != organizationID {
+				return nil, ErrTaxCodeNotFound
+			}
+			cache[codeID] = code
+		}
+
+		taxes = append(taxes, ItemTax{
+			ID:            uuid.New(),
+			InvoiceItemID: itemID,
+			TaxCodeID:     &code.ID,
+			Name:          code.Name,
+			Rate:          code.Rate,
+			IsCompound:    code.IsCompound,
+		})
+	}
+	return taxes, nil
+}
+
+// copyTaxes copies taxes agreed elsewhere, their names, rates and compounding,
+// onto a line; their amounts are worked out again with the line's totals
+func copyTaxes(itemID uuid.UUID, agreed []ItemTax) []ItemTax {
+	taxes := make([]ItemTax, len(agreed))
+	for i, tax := range agreed {
+		taxes[i] = ItemTax{
+			ID:            uuid.New(),
+			InvoiceItemID: itemID,
+			TaxCodeID:     tax.TaxCodeID,
+			Name:          tax.Name,
+			Rate:          tax.Rate,
+			IsCompound:    tax.IsCompound,
+			SortOrder:     tax.SortOrder,
+		}
+	}
+	return taxes
+}
+
+// baseRate looks up the organization's base currency and the rate to it on
+// the given date. Without a rate source every invoice is its own base
+// currency.
+func (s *Service) baseRate(ctx context.Context, organizationID uuid.UUID, invoiceCurrency string, on time.Time) (string, float64, error) {
+	if s.rates == nil {
+		return invoiceCurrency, 1, nil
+	}
+
+	baseCurrency, err := s.rates.BaseCurrency(ctx, organizationID)
+	if err != nil {
+		return "", 0, fmt.Errorf("getting base currency: %w", err)
+	}
+
+	rate, err := s.rates.Rate(ctx, organizationID, invoiceCurrency, baseCurrency, on)
+	if err != nil {
+		return "", 0, ErrExchangeRateNotFound
+	}
+
+	return baseCurrency, rate, nil
+}
+
+func normalizeDiscountType(t DiscountType) DiscountType {
+	if t == "" {
+		return DiscountNone
+	}
+	return t
+}
+
+func validateDiscount(t DiscountType, value float64) error {
+	switch t {
+	case DiscountNone:
+		return nil
+	case DiscountPercent:
+		if value < 0 || value > 100 {
+			return ErrInvalidDiscount
+		}
+	case DiscountFixed:
+		if value < 0 {
+			return ErrInvalidDiscount
+		}
+	default:
+		return ErrInvalidDiscount
+	}
+	return nil
+}
+
+func (s *Service) CreateTaxCode(ctx context.Context, p organization.Principal, req TaxCodeRequest) (*TaxCode, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	code := &TaxCode{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		Name:           req.Name,
+		Rate:           req.Rate,
+		IsCompound:     req.IsCompound,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := s.taxCodes.Create(ctx, code); err != nil {
+		return nil, fmt.Errorf("creating tax code: %w", err)
+	}
+
+	return code, nil
+}
+
+func (s *Service) ListTaxCodes(ctx context.Context, p organization.Principal) ([]TaxCode, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.taxCodes.GetByOrganizationID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tax codes: %w", err)
+	}
+	return codes, nil
+}
+
+// UpdateTaxCode changes a catalog entry. Invoices keep a copy of the name and
+// rate on each line, so existing invoices are not affected.
+func (s *Service) UpdateTaxCode(ctx context.Context, p organization.Principal, codeID uuid.UUID, req TaxCodeRequest) (*TaxCode, error) {
+	code, err := s.manageTaxCode(ctx, p, codeID)
+	if err != nil {
+		return nil, err
+	}
+
+	code.Name = req.Name
+	code.Rate = req.Rate
+	code.IsCompound = req.IsCompound
+	code.UpdatedAt = time.Now()
+
+	if err := s.taxCodes.Update(ctx, code); err != nil {
+		return nil, fmt.Errorf("updating tax code: %w", err)
+	}
+
+	return code, nil
+}
+
+func (s *Service) DeleteTaxCode(ctx context.Context, p organization.Principal, codeID uuid.UUID) error {
+	if _, err := s.manageTaxCode(ctx, p, codeID); err != nil {
+		return err
+	}
+
+	if err := s.taxCodes.Delete(ctx, codeID); err != nil {
+		return fmt.Errorf("deleting tax code: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) manageTaxCode(ctx context.Context, p organization.Principal, codeID uuid.UUID) (*TaxCode, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	code, err := s.taxCodes.GetByID(ctx, codeID)
+	if err != nil {
+		return nil, ErrTaxCodeNotFound
+	}
+
+	if !p.Owns(code.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return code, nil
+}
+
 // Interfaces for dependencies (ports)
 type PDFGenerator interface {
 	Generate(ctx context.Context, invoice *Invoice) ([]byte, error)
 }
 
+// ExchangeRates converts between an invoice's currency and the
+// organization's base currency
+type ExchangeRates interface {
+	BaseCurrency(ctx context.Context, organizationID uuid.UUID) (string, error)
+	Rate(ctx context.Context, organizationID uuid.UUID, from, to string, on time.Time) (float64, error)
+}
+
+// EmailSender delivers an email and returns its message ID
+type EmailSender interface {
+	Send(ctx context.Context, msg mail.Message) (string, error)
+}
+
 type SquareAPI interface {
 	CreateInvoice(ctx context.Context, invoice *Invoice) (string, error)
 	GetPaymentStatus(ctx context.Context, invoiceID string) (string, error)
@@ -5,20 +5,67 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
 )
 
 type CreateInvoiceRequest struct {
-	ClientID  uuid.UUID
-	IssueDate time.Time
-	DueDate   time.Time
-	TaxRate   float64
-	Currency  string
-	Notes     string
-	Items     []CreateInvoiceItemRequest
+	ClientID         uuid.UUID
+	IssueDate        time.Time
+	DueDate          time.Time
+	TaxRate          float64
+	PricesIncludeTax bool
+	Currency         string
+	Notes            string
+	Items            []CreateInvoiceItemRequest
+
+	// RecurringScheduleID links an invoice generated by a recurring schedule
+	RecurringScheduleID *uuid.UUID
+	// QuoteID links an invoice converted from an accepted quote
+	QuoteID *uuid.UUID
+
+	DiscountType                DiscountType
+	DiscountValue               float64
+	EarlyPaymentDiscountPercent float64
+	EarlyPaymentDiscountDays    int
 }
 
 type CreateInvoiceItemRequest struct {
 	Description string
 	Quantity    float64
 	UnitPrice   float64
+	TaxCodeIDs  []uuid.UUID
+	TaxExempt   bool
+	// Taxes, when not nil, are the line's taxes as already agreed, kept as
+	// they are in place of resolving TaxCodeIDs, as for an accepted quote
+	Taxes []ItemTax
+
+	DiscountType  DiscountType
+	DiscountValue float64
+}
+
+type TaxCodeRequest struct {
+	Name       string
+	Rate       float64
+	IsCompound bool
+}
+
+type SendOptions struct {
+	CC      []string
+	BCC     []string
+	ReplyTo string // Defaults to the sender's account email
+	Message string // Optional note shown above the invoice summary
+}
+
+// InvoiceEmail is the data the invoice email template is rendered with
+type InvoiceEmail struct {
+	Invoice      *Invoice
+	Client       *client.Client
+	SenderName   string
+	Total        string
+	DueDate      string
+	PaymentTerms string
+	DaysOverdue  int    // Negative before the due date
+	LateFees     string // Late fees charged so far
+	Message      string
 }
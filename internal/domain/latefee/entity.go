@@ -0,0 +1,142 @@
+// internal/domain/latefee/entity.go
+package latefee
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeeType string
+
+const (
+	FeeFlat    FeeType = "flat"    // One-time fee once the grace period ends
+	FeePercent FeeType = "percent" // Interest at Amount percent per month
+)
+
+type Compounding string
+
+const (
+	CompoundNone    Compounding = "none" // Flat fees
+	CompoundDaily   Compounding = "daily"
+	CompoundMonthly Compounding = "monthly"
+)
+
+type ChargeMode string
+
+const (
+	ModeLineItem ChargeMode = "line_item" // Added to the overdue invoice as a late fee line
+	ModeFollowUp ChargeMode = "follow_up" // Billed on a separate invoice to the same client
+)
+
+// Policy decides what an overdue invoice is charged. A policy with a ClientID
+// applies to that client's invoices; the one without is the
+// organization's default.
+type Policy struct {
+	ID             uuid.UUID   `db:"id"`
+	OrganizationID uuid.UUID   `db:"organization_id"`
+	UserID         uuid.UUID   `db:"user_id"`
+	ClientID       *uuid.UUID  `db:"client_id"`
+	FeeType        FeeType     `db:"fee_type"`
+	Amount         float64     `db:"amount"` // Flat fee, or percent per month
+	Compounding    Compounding `db:"compounding"`
+	GraceDays      int         `db:"grace_days"`
+	Mode           ChargeMode  `db:"mode"`
+
+	// Caps on the total charged per invoice; zero means no cap
+	MaxAmount  float64 `db:"max_amount"`
+	MaxPercent float64 `db:"max_percent"` // Percent of the original invoice amount
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Charge is one late fee charged on an invoice, kept so every fee can be
+// traced back to the balance and period it was worked out from
+type Charge struct {
+	ID                uuid.UUID  `db:"id"`
+	InvoiceID         uuid.UUID  `db:"invoice_id"`
+	PolicyID          *uuid.UUID `db:"policy_id"`
+	FeeType           FeeType    `db:"fee_type"`
+	Rate              float64    `db:"rate"`  // Percent per month, or zero for a flat fee
+	Basis             float64    `db:"basis"` // Balance the interest was charged on
+	PeriodStart       time.Time  `db:"period_start"`
+	PeriodEnd         time.Time  `db:"period_end"`
+	Amount            float64    `db:"amount"`
+	InvoiceItemID     *uuid.UUID `db:"invoice_item_id"` // Late fee line on the overdue invoice
+	FollowUpInvoiceID *uuid.UUID `db:"follow_up_invoice_id"`
+	CreatedAt         time.Time  `db:"created_at"`
+}
+
+// DueInvoice is an overdue invoice along with the policy that applies to it
+// and the late fees charged on it so far
+type DueInvoice struct {
+	InvoiceID uuid.UUID  `db:"invoice_id"`
+	Charged   float64    `db:"charged"`
+	Charges   int        `db:"charges"`
+	AccruedTo *time.Time `db:"accrued_to"` // End of the last period charged
+	Policy
+}
+
+// GraceEnd is the last day an invoice due on dueDate can be paid without a fee
+func (p *Policy) GraceEnd(dueDate time.Time) time.Time {
+	return truncateDay(dueDate).AddDate(0, 0, p.GraceDays)
+}
+
+// Accrue works out the fee for the period from from to asOf on the given
+// balance and returns it with the end of the period it covers. Monthly
+// compounding only charges whole months, counted from the anchor date, so
+// the period may end before asOf.
+func (p *Policy) Accrue(balance float64, anchor, from, asOf time.Time) (float64, time.Time) {
+	from, asOf = truncateDay(from), truncateDay(asOf)
+	if p.FeeType == FeeFlat {
+		return p.Amount, from
+	}
+	if balance <= 0 || !asOf.After(from) {
+		return 0, from
+	}
+
+	rate := p.Amount / 100
+	if p.Compounding == CompoundMonthly {
+		anchor = truncateDay(anchor)
+		next := 1
+		for !addMonths(anchor, next).After(from) {
+			next++
+		}
+		months, end := 0, from
+		for !addMonths(anchor, next+months).After(asOf) {
+			end = addMonths(anchor, next+months)
+			months++
+		}
+		return balance * (math.Pow(1+rate, float64(months)) - 1), end
+	}
+
+	days := asOf.Sub(from).Hours() / 24
+	daily := rate * 12 / 365
+	return balance * (math.Pow(1+daily, days) - 1), asOf
+}
+
+// Cap limits a fee so the total charged stays within the policy's caps
+func (p *Policy) Cap(fee, charged, original float64) float64 {
+	limit := math.Inf(1)
+	if p.MaxAmount > 0 {
+		limit = p.MaxAmount
+	}
+	if p.MaxPercent > 0 {
+		limit = math.Min(limit, original*p.MaxPercent/100)
+	}
+	return math.Max(math.Min(fee, limit-charged), 0)
+}
+
+// addMonths steps n months from t, keeping to the last day of shorter months
+// rather than rolling over into the next one, so Jan 31 steps to Feb 28
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
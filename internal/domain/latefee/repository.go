@@ -0,0 +1,34 @@
+// internal/domain/latefee/repository.go
+package latefee
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+// Repository defines the contract for late fee policy persistence
+type Repository interface {
+	Create(ctx context.Context, policy *Policy) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Policy, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]Policy, error)
+	Update(ctx context.Context, policy *Policy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetDue returns the invoices still awaiting payment whose due date is
+	// before asOf, each with the client's policy or else the organization's
+	// default.
+	// Follow-up invoices for late fees are left out.
+	GetDue(ctx context.Context, asOf time.Time) ([]DueInvoice, error)
+	// CreateCharge records the charge, adding its late fee line to the
+	// invoice in the same transaction when there is one. It reports false,
+	// recording nothing, when the invoice was already charged for the period.
+	CreateCharge(ctx context.Context, charge *Charge, lateFee *invoice.InvoiceItem) (bool, error)
+	// SetFollowUpInvoice links a charge to the invoice it was billed on
+	SetFollowUpInvoice(ctx context.Context, chargeID, invoiceID uuid.UUID) error
+	DeleteCharge(ctx context.Context, id uuid.UUID) error
+	GetCharges(ctx context.Context, invoiceID uuid.UUID) ([]Charge, error)
+}
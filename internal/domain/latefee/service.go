@@ -0,0 +1,390 @@
+// internal/domain/latefee/service.go
+package latefee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrPolicyNotFound = fmt.Errorf("late fee policy not found")
+	ErrUnauthorized   = fmt.Errorf("unauthorized access")
+	ErrInvalidPolicy  = fmt.Errorf("invalid late fee policy")
+	ErrPolicyExists   = fmt.Errorf("a late fee policy already exists for this client")
+	ErrClientNotFound = fmt.Errorf("client not found")
+)
+
+// Biller bills late fees on follow-up invoices and logs them on the overdue one
+type Biller interface {
+	CreateInvoice(ctx context.Context, p organization.Principal, req invoice.CreateInvoiceRequest) (*invoice.Invoice, error)
+	SendInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, opts invoice.SendOptions) (*invoice.Invoice, *invoice.Delivery, error)
+	LogActivity(ctx context.Context, activity *invoice.Activity) error
+}
+
+type Service struct {
+	repo     Repository
+	invoices invoice.Repository
+	clients  client.Repository
+	biller   Biller
+}
+
+func NewService(repo Repository, invoices invoice.Repository, clients client.Repository, biller Biller) *Service {
+	return &Service{
+		repo:     repo,
+		invoices: invoices,
+		clients:  clients,
+		biller:   biller,
+	}
+}
+
+func (s *Service) CreatePolicy(ctx context.Context, p organization.Principal, req PolicyRequest) (*Policy, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := s.apply(ctx, policy, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, policy); err != nil {
+		return nil, fmt.Errorf("creating late fee policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (s *Service) GetPolicy(ctx context.Context, p organization.Principal, policyID uuid.UUID) (*Policy, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.repo.GetByID(ctx, policyID)
+	if err != nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	if !p.Owns(policy.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return policy, nil
+}
+
+func (s *Service) ListPolicies(ctx context.Context, p organization.Principal) ([]Policy, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrganizationID(ctx, p.OrganizationID)
+}
+
+// managePolicy returns the policy if the principal may change it
+func (s *Service) managePolicy(ctx context.Context, p organization.Principal, policyID uuid.UUID) (*Policy, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	return s.GetPolicy(ctx, p, policyID)
+}
+
+func (s *Service) UpdatePolicy(ctx context.Context, p organization.Principal, policyID uuid.UUID, req PolicyRequest) (*Policy, error) {
+	policy, err := s.managePolicy(ctx, p, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, policy, req); err != nil {
+		return nil, err
+	}
+
+	policy.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, policy); err != nil {
+		return nil, fmt.Errorf("updating late fee policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (s *Service) DeletePolicy(ctx context.Context, p organization.Principal, policyID uuid.UUID) error {
+	if _, err := s.managePolicy(ctx, p, policyID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, policyID)
+}
+
+func (s *Service) ListCharges(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Charge, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, invoice.ErrUnauthorized
+	}
+
+	return s.repo.GetCharges(ctx, invoiceID)
+}
+
+// ChargeDue charges the late fees that have accrued on overdue invoices up to
+// asOf and returns how many invoices were charged. Interest accrues from the
+// due date but is only charged once the grace period is over; each run
+// charges what accrued since the last charge, compounding on the late fees
+// already charged.
+func (s *Service) ChargeDue(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.repo.GetDue(ctx, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("getting overdue invoices: %w", err)
+	}
+
+	charged := 0
+	for _, d := range due {
+		ok, err := s.charge(ctx, d, asOf)
+		if err != nil {
+			slog.Error("failed to charge late fee", "invoice_id", d.InvoiceID, "policy_id", d.ID, "error", err)
+			continue
+		}
+		if ok {
+			charged++
+		}
+	}
+
+	return charged, nil
+}
+
+func (s *Service) charge(ctx context.Context, d DueInvoice, asOf time.Time) (bool, error) {
+	inv, err := s.invoices.GetByID(ctx, d.InvoiceID)
+	if err != nil {
+		return false, fmt.Errorf("getting invoice: %w", err)
+	}
+
+	graceEnd := d.GraceEnd(inv.DueDate)
+	if !awaitingPayment(inv) || !truncateDay(asOf).After(graceEnd) {
+		return false, nil
+	}
+
+	// The original amount excludes every late fee, so caps and interest
+	// never grow with fees charged by reminders
+	original := inv.Total - inv.LateFees
+	principal := math.Max(original-inv.AmountPaid, 0)
+
+	basis, start, rate := principal, graceEnd, 0.0
+	if d.FeeType == FeeFlat {
+		if d.Charges > 0 {
+			return false, nil
+		}
+	} else {
+		basis, start, rate = principal+d.Charged, truncateDay(inv.DueDate), d.Amount
+		if d.AccruedTo != nil {
+			start = *d.AccruedTo
+		}
+	}
+	fee, end := d.Accrue(basis, inv.DueDate, start, asOf)
+
+	// Fees too small to round to a cent keep accruing until the next run
+	fee = currency.Round(d.Cap(fee, d.Charged, original), inv.Currency)
+	if fee <= 0 {
+		return false, nil
+	}
+
+	policyID := d.ID
+	charge := &Charge{
+		ID:          uuid.New(),
+		InvoiceID:   inv.ID,
+		PolicyID:    &policyID,
+		FeeType:     d.FeeType,
+		Rate:        rate,
+		Basis:       currency.Round(basis, inv.Currency),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Amount:      fee,
+		CreatedAt:   time.Now(),
+	}
+
+	// The charge is recorded before a follow-up invoice is raised, and along
+	// with the fee otherwise, so no period is ever charged twice
+	reason := describeCharge(&d.Policy, charge)
+	var lateFee *invoice.InvoiceItem
+	if d.Mode != ModeFollowUp {
+		item := inv.NewLateFee("Late fee: "+reason, fee)
+		lateFee = &item
+		charge.InvoiceItemID = &item.ID
+	}
+	claimed, err := s.repo.CreateCharge(ctx, charge, lateFee)
+	if err != nil {
+		return false, fmt.Errorf("recording late fee charge: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	if lateFee != nil {
+		activity := invoice.NewActivity(inv, invoice.ActivityLateFee, fmt.Sprintf("Late fee of %s charged: %s",
+			currency.Format(fee, inv.Currency), reason))
+		if err := s.biller.LogActivity(ctx, activity); err != nil {
+			slog.Error("failed to record invoice activity", "invoice_id", inv.ID, "error", err)
+		}
+		return true, nil
+	}
+
+	followUp, err := s.billFollowUp(ctx, inv, fee, reason, asOf)
+	if err != nil {
+		// Nothing was billed, so the next run can try again
+		if err := s.repo.DeleteCharge(ctx, charge.ID); err != nil {
+			slog.Error("failed to remove unbilled late fee charge", "charge_id", charge.ID, "error", err)
+		}
+		return false, err
+	}
+	if err := s.repo.SetFollowUpInvoice(ctx, charge.ID, followUp.ID); err != nil {
+		return true, fmt.Errorf("linking follow-up invoice: %w", err)
+	}
+
+	return true, nil
+}
+
+// billFollowUp raises a separate invoice for the fee, on the same payment
+// terms as the overdue invoice, and sends it
+func (s *Service) billFollowUp(ctx context.Context, inv *invoice.Invoice, fee float64, reason string, asOf time.Time) (*invoice.Invoice, error) {
+	issueDate := truncateDay(asOf)
+	terms := int(truncateDay(inv.DueDate).Sub(truncateDay(inv.IssueDate)).Hours() / 24)
+	issuer := organization.System(inv.OrganizationID, inv.UserID)
+
+	followUp, err := s.biller.CreateInvoice(ctx, issuer, invoice.CreateInvoiceRequest{
+		ClientID:  inv.ClientID,
+		IssueDate: issueDate,
+		DueDate:   issueDate.AddDate(0, 0, terms),
+		Currency:  inv.Currency,
+		Notes:     fmt.Sprintf("Late fee on invoice %s", inv.InvoiceNumber),
+		Items: []invoice.CreateInvoiceItemRequest{{
+			Description: fmt.Sprintf("Late fee on invoice %s: %s", inv.InvoiceNumber, reason),
+			Quantity:    1,
+			UnitPrice:   fee,
+			TaxExempt:   true,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating follow-up invoice: %w", err)
+	}
+
+	if _, _, err := s.biller.SendInvoice(ctx, issuer, followUp.ID, invoice.SendOptions{}); err != nil {
+		slog.Error("failed to send late fee invoice", "invoice_id", followUp.ID, "error", err)
+	}
+
+	activity := invoice.NewActivity(inv, invoice.ActivityLateFee, fmt.Sprintf("Late fee of %s billed on invoice %s: %s",
+		currency.Format(fee, inv.Currency), followUp.InvoiceNumber, reason))
+	if err := s.biller.LogActivity(ctx, activity); err != nil {
+		slog.Error("failed to record invoice activity", "invoice_id", inv.ID, "error", err)
+	}
+
+	return followUp, nil
+}
+
+// apply validates the request and copies it onto the policy
+func (s *Service) apply(ctx context.Context, policy *Policy, req PolicyRequest) error {
+	compounding := req.Compounding
+	mode := req.Mode
+	if mode == "" {
+		mode = ModeLineItem
+	}
+
+	switch req.FeeType {
+	case FeeFlat:
+		if req.Amount <= 0 {
+			return fmt.Errorf("%w: the fee must be positive", ErrInvalidPolicy)
+		}
+		compounding = CompoundNone
+	case FeePercent:
+		if req.Amount <= 0 || req.Amount > 100 {
+			return fmt.Errorf("%w: the monthly rate must be between 0 and 100 percent", ErrInvalidPolicy)
+		}
+		if compounding == "" {
+			compounding = CompoundMonthly
+		}
+		if compounding != CompoundDaily && compounding != CompoundMonthly {
+			return fmt.Errorf("%w: compounding must be daily or monthly", ErrInvalidPolicy)
+		}
+		// A follow-up invoice for every day's interest would swamp the client
+		if mode == ModeFollowUp && compounding == CompoundDaily {
+			return fmt.Errorf("%w: follow-up invoices need monthly compounding", ErrInvalidPolicy)
+		}
+	default:
+		return fmt.Errorf("%w: unknown fee type %q", ErrInvalidPolicy, req.FeeType)
+	}
+
+	if mode != ModeLineItem && mode != ModeFollowUp {
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidPolicy, mode)
+	}
+	if req.GraceDays < 0 || req.MaxAmount < 0 || req.MaxPercent < 0 || req.MaxPercent > 100 {
+		return ErrInvalidPolicy
+	}
+
+	if req.ClientID != nil {
+		c, err := s.clients.GetByID(ctx, *req.ClientID)
+		if err != nil || c.OrganizationID != policy.OrganizationID {
+			return ErrClientNotFound
+		}
+	}
+
+	existing, err := s.repo.GetByOrganizationID(ctx, policy.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("getting late fee policies: %w", err)
+	}
+	for _, other := range existing {
+		if other.ID != policy.ID && sameClient(other.ClientID, req.ClientID) {
+			return ErrPolicyExists
+		}
+	}
+
+	policy.ClientID = req.ClientID
+	policy.FeeType = req.FeeType
+	policy.Amount = req.Amount
+	policy.Compounding = compounding
+	policy.GraceDays = req.GraceDays
+	policy.Mode = mode
+	policy.MaxAmount = req.MaxAmount
+	policy.MaxPercent = req.MaxPercent
+	return nil
+}
+
+func awaitingPayment(inv *invoice.Invoice) bool {
+	switch inv.Status {
+	case invoice.StatusSent, invoice.StatusPartiallyPaid, invoice.StatusOverdue:
+		return inv.BalanceDue() > 0
+	}
+	return false
+}
+
+func sameClient(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func describeCharge(p *Policy, c *Charge) string {
+	if p.FeeType == FeeFlat {
+		return "flat late fee"
+	}
+	return fmt.Sprintf("interest at %g%% per month compounded %s, %s to %s", p.Amount, p.Compounding,
+		c.PeriodStart.Format("Jan 2, 2006"), c.PeriodEnd.Format("Jan 2, 2006"))
+}
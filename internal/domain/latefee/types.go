@@ -0,0 +1,15 @@
+// internal/domain/latefee/types.go
+package latefee
+
+import "github.com/google/uuid"
+
+type PolicyRequest struct {
+	ClientID    *uuid.UUID // nil for the user's default policy
+	FeeType     FeeType
+	Amount      float64
+	Compounding Compounding
+	GraceDays   int
+	Mode        ChargeMode
+	MaxAmount   float64
+	MaxPercent  float64
+}
@@ -0,0 +1,49 @@
+// internal/domain/mfa/entity.go
+package mfa
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Factor is a user's TOTP authenticator. It is pending from enrollment until
+// the user confirms it with a code, and only asked for at login once enabled.
+type Factor struct {
+	UserID         uuid.UUID  `db:"user_id"`
+	Secret         string     `db:"secret"` // Sealed by a SecretSealer; base32 once opened
+	EnabledAt      *time.Time `db:"enabled_at"`
+	LastUsedStep   int64      `db:"last_used_step"` // Codes up to this time step can't be used again
+	FailedAttempts int        `db:"failed_attempts"`
+	LastFailedAt   *time.Time `db:"last_failed_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+func (f *Factor) IsEnabled() bool {
+	return f.EnabledAt != nil
+}
+
+// RecoveryCode stands in for a code from the authenticator once, for users
+// who lost it. Only its hash is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	CodeHash  string     `db:"code_hash"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// Enrollment is what the user adds to their authenticator app, by scanning
+// the URI as a QR code or typing the secret
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// Status describes a user's two-factor authentication
+type Status struct {
+	Enabled           bool
+	EnabledAt         *time.Time
+	RecoveryCodesLeft int
+}
@@ -0,0 +1,41 @@
+// internal/domain/mfa/repository.go
+package mfa
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// GetFactor returns ErrNotEnabled when the user never enrolled
+	GetFactor(ctx context.Context, userID uuid.UUID) (*Factor, error)
+	GetFactors(ctx context.Context) ([]Factor, error)
+	// SaveFactor creates or replaces the user's factor
+	SaveFactor(ctx context.Context, f *Factor) error
+	// Enable saves the enabled factor and replaces the user's recovery codes
+	// in one transaction
+	Enable(ctx context.Context, f *Factor, codes []RecoveryCode) error
+	// CountAttempt counts a code about to be checked as a wrong one, the
+	// count starting over when the last was at or before since. It reports
+	// false, counting nothing, when max wrong codes were given after since.
+	CountAttempt(ctx context.Context, userID uuid.UUID, at, since time.Time, max int) (bool, error)
+	// UseStep records an authenticator code's time step as used and clears
+	// the wrong codes, reporting false when the step or a later one already
+	// was
+	UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) (bool, error)
+	// ResetAttempts clears the wrong codes
+	ResetAttempts(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// ReplaceSecret swaps the user's secret for another, unless it changed
+	// from old since it was read
+	ReplaceSecret(ctx context.Context, userID uuid.UUID, old, secret string) error
+	// DeleteFactor removes the user's factor and recovery codes
+	DeleteFactor(ctx context.Context, userID uuid.UUID) error
+
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []RecoveryCode) error
+	// UseRecoveryCode marks the user's unused code with the hash used,
+	// returning ErrInvalidCode when there is none
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
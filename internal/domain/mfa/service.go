@@ -0,0 +1,333 @@
+// internal/domain/mfa/service.go
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/pkg/totp"
+)
+
+var (
+	ErrNotEnabled      = fmt.Errorf("two-factor authentication is not enabled")
+	ErrAlreadyEnabled  = fmt.Errorf("two-factor authentication is already enabled")
+	ErrNotEnrolled     = fmt.Errorf("start two-factor enrollment first")
+	ErrInvalidCode     = fmt.Errorf("invalid authentication code")
+	ErrTooManyAttempts = fmt.Errorf("too many invalid codes; try again later")
+)
+
+const (
+	recoveryCodeCount = 10
+
+	// After this many wrong codes in a row, codes are refused until
+	// lockoutPeriod has passed since the last
+	maxFailedAttempts = 5
+	lockoutPeriod     = 15 * time.Minute
+)
+
+// SecretSealer encrypts authenticator secrets for storage. Each is sealed
+// with its user's ID as associated data, so one copied to another user's
+// factor doesn't open.
+type SecretSealer interface {
+	Seal(plaintext string, associatedData []byte) (string, error)
+	Open(value string, associatedData []byte) (string, error)
+	// IsSealed tells sealed secrets from those stored before encryption
+	IsSealed(value string) bool
+}
+
+type Service struct {
+	repo   Repository
+	users  user.Repository
+	sealer SecretSealer
+	issuer string // Names the account in authenticator apps
+}
+
+func NewService(repo Repository, users user.Repository, sealer SecretSealer, issuer string) *Service {
+	return &Service{
+		repo:   repo,
+		users:  users,
+		sealer: sealer,
+		issuer: issuer,
+	}
+}
+
+// Enroll starts setting up an authenticator for the user, replacing any
+// enrollment they didn't finish. The factor isn't used until Activate
+// confirms the app produces the right codes.
+func (s *Service) Enroll(ctx context.Context, userID uuid.UUID) (*Enrollment, error) {
+	existing, err := s.repo.GetFactor(ctx, userID)
+	if err != nil && !errors.Is(err, ErrNotEnabled) {
+		return nil, err
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.sealer.Seal(secret, userID[:])
+	if err != nil {
+		return nil, fmt.Errorf("sealing secret: %w", err)
+	}
+
+	now := time.Now()
+	f := &Factor{
+		UserID:    userID,
+		Secret:    sealed,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.SaveFactor(ctx, f); err != nil {
+		return nil, fmt.Errorf("saving factor: %w", err)
+	}
+
+	return &Enrollment{Secret: secret, URI: totp.URI(s.issuer, u.Email, secret)}, nil
+}
+
+// Activate enables the enrolled authenticator once the user gives a code
+// from it, returning their recovery codes. They are shown only this once.
+func (s *Service) Activate(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	f, err := s.repo.GetFactor(ctx, userID)
+	if errors.Is(err, ErrNotEnabled) {
+		return nil, ErrNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := s.secret(f)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	step, ok := totp.Validate(secret, normalizeCode(code), now, 0)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, records, err := newRecoveryCodes(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	f.EnabledAt = &now
+	f.LastUsedStep = step
+	f.UpdatedAt = now
+	if err := s.repo.Enable(ctx, f, records); err != nil {
+		return nil, fmt.Errorf("enabling factor: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Enabled reports whether the user has to give a code to log in
+func (s *Service) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	f, err := s.repo.GetFactor(ctx, userID)
+	if errors.Is(err, ErrNotEnabled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return f.IsEnabled(), nil
+}
+
+func (s *Service) Status(ctx context.Context, userID uuid.UUID) (*Status, error) {
+	f, err := s.repo.GetFactor(ctx, userID)
+	if errors.Is(err, ErrNotEnabled) {
+		return &Status{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsEnabled() {
+		return &Status{}, nil
+	}
+
+	left, err := s.repo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("counting recovery codes: %w", err)
+	}
+	return &Status{Enabled: true, EnabledAt: f.EnabledAt, RecoveryCodesLeft: left}, nil
+}
+
+// Verify checks a code from the user's authenticator, or one of their
+// recovery codes, which is then used up. Authenticator codes can't be used
+// twice either. Wrong codes count towards a temporary lockout.
+func (s *Service) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	f, err := s.repo.GetFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !f.IsEnabled() {
+		return ErrNotEnabled
+	}
+
+	// Every code counts as wrong until it checks out, so codes tried at the
+	// same time can't get past the lockout between them
+	now := time.Now()
+	allowed, err := s.repo.CountAttempt(ctx, userID, now, now.Add(-lockoutPeriod), maxFailedAttempts)
+	if err != nil {
+		return fmt.Errorf("counting attempt: %w", err)
+	}
+	if !allowed {
+		return ErrTooManyAttempts
+	}
+
+	code = normalizeCode(code)
+	if len(code) != totp.Digits {
+		err := s.repo.UseRecoveryCode(ctx, userID, hashCode(code), now)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCode) {
+				return err
+			}
+			return fmt.Errorf("using recovery code: %w", err)
+		}
+		if err := s.repo.ResetAttempts(ctx, userID, now); err != nil {
+			return fmt.Errorf("resetting attempts: %w", err)
+		}
+		return nil
+	}
+
+	secret, err := s.secret(f)
+	if err != nil {
+		return err
+	}
+	step, ok := totp.Validate(secret, code, now, f.LastUsedStep)
+	if !ok {
+		return ErrInvalidCode
+	}
+	// Only one use of a code wins, however many are made at once
+	used, err := s.repo.UseStep(ctx, userID, step, now)
+	if err != nil {
+		return fmt.Errorf("using code: %w", err)
+	}
+	if !used {
+		return ErrInvalidCode
+	}
+	return nil
+}
+
+// Disable turns two-factor authentication off after checking a code, and
+// discards the recovery codes
+func (s *Service) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteFactor(ctx, userID); err != nil {
+		return fmt.Errorf("deleting factor: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking
+// a code, returning the new ones
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, records, err := newRecoveryCodes(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, fmt.Errorf("replacing recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// SealSecrets encrypts the secrets stored before they were sealed, returning
+// how many it sealed
+func (s *Service) SealSecrets(ctx context.Context) (int, error) {
+	factors, err := s.repo.GetFactors(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting factors: %w", err)
+	}
+
+	sealed := 0
+	for _, f := range factors {
+		if s.sealer.IsSealed(f.Secret) {
+			continue
+		}
+		value, err := s.sealer.Seal(f.Secret, f.UserID[:])
+		if err != nil {
+			return sealed, fmt.Errorf("sealing secret: %w", err)
+		}
+		if err := s.repo.ReplaceSecret(ctx, f.UserID, f.Secret, value); err != nil {
+			return sealed, fmt.Errorf("saving secret: %w", err)
+		}
+		sealed++
+	}
+	return sealed, nil
+}
+
+// secret returns the factor's secret in the clear. One stored before
+// secrets were sealed, and not sealed since, is used as it is.
+func (s *Service) secret(f *Factor) (string, error) {
+	if !s.sealer.IsSealed(f.Secret) {
+		return f.Secret, nil
+	}
+	secret, err := s.sealer.Open(f.Secret, f.UserID[:])
+	if err != nil {
+		return "", fmt.Errorf("opening secret: %w", err)
+	}
+	return secret, nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes returns a fresh set of codes, written xxxxx-xxxxx, and
+// their records
+func newRecoveryCodes(userID uuid.UUID, now time.Time) ([]string, []RecoveryCode, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+
+		codes[i] = code[:5] + "-" + code[5:]
+		records[i] = RecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  hashCode(code),
+			CreatedAt: now,
+		}
+	}
+	return codes, records, nil
+}
+
+// normalizeCode drops the separators people type or paste with codes
+func normalizeCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
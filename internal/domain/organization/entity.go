@@ -0,0 +1,62 @@
+// internal/domain/organization/entity.go
+package organization
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleOwner      Role = "owner"
+	RoleAdmin      Role = "admin"
+	RoleAccountant Role = "accountant"
+	RoleMember     Role = "member"
+	RoleViewer     Role = "viewer"
+)
+
+func (r Role) IsValid() bool {
+	_, ok := permissions[r]
+	return ok
+}
+
+// Organization owns clients, projects, invoices and time, and the settings
+// they're billed with. Its members work in it according to their role.
+type Organization struct {
+	ID           uuid.UUID `db:"id"`
+	Name         string    `db:"name"`          // Shown as the sender on invoices and quotes
+	BaseCurrency string    `db:"base_currency"` // Currency reports are totalled in
+	RequireMFA   bool      `db:"require_mfa"`   // Members must log in with a second factor to work in it
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// Member is a user's place in an organization
+type Member struct {
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Role           Role      `db:"role"`
+	Email          string    `db:"email"`     // The user's, when listed
+	FullName       string    `db:"full_name"` // The user's, when listed
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Membership is an organization a user belongs to, with their role in it
+type Membership struct {
+	Organization
+	Role Role `db:"role"`
+}
+
+type CreateRequest struct {
+	Name         string
+	BaseCurrency string
+}
+
+type UpdateRequest struct {
+	Name         string
+	BaseCurrency string
+	RequireMFA   bool
+}
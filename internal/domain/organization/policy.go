@@ -0,0 +1,89 @@
+// internal/domain/organization/policy.go
+package organization
+
+import (
+	"github.com/google/uuid"
+)
+
+// Action is something a role may be allowed to do in an organization
+type Action string
+
+const (
+	// Clients, projects and tasks
+	ActionViewProjects   Action = "projects:view"
+	ActionManageProjects Action = "projects:manage"
+	// Invoices, quotes, payments, rates, reports and the settings for them
+	ActionViewBilling   Action = "billing:view"
+	ActionManageBilling Action = "billing:manage"
+	// The member's own time, timer and timesheets
+	ActionTrackTime Action = "time:track"
+	// Everyone's time
+	ActionViewTeamTime   Action = "time:view"
+	ActionManageTeamTime Action = "time:manage"
+	// Who belongs to the organization, and its name and base currency
+	ActionManageMembers      Action = "members:manage"
+	ActionManageOrganization Action = "organization:manage"
+)
+
+var permissions = map[Role][]Action{
+	RoleOwner: {
+		ActionViewProjects, ActionManageProjects, ActionViewBilling, ActionManageBilling, ActionTrackTime,
+		ActionViewTeamTime, ActionManageTeamTime, ActionManageMembers, ActionManageOrganization,
+	},
+	RoleAdmin: {
+		ActionViewProjects, ActionManageProjects, ActionViewBilling, ActionManageBilling, ActionTrackTime,
+		ActionViewTeamTime, ActionManageTeamTime, ActionManageMembers, ActionManageOrganization,
+	},
+	RoleAccountant: {
+		ActionViewProjects, ActionViewBilling, ActionManageBilling, ActionTrackTime, ActionViewTeamTime,
+	},
+	RoleMember: {
+		ActionViewProjects, ActionTrackTime,
+	},
+	RoleViewer: {
+		ActionViewProjects, ActionViewBilling, ActionViewTeamTime,
+	},
+}
+
+// Allows reports whether the role may take the action
+func (r Role) Allows(action Action) bool {
+	for _, a := range permissions[r] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal is a user acting in their active organization
+type Principal struct {
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Role           Role
+}
+
+// System is the principal background jobs act as for work in an
+// organization, such as issuing a recurring invoice. userID is who the work
+// is recorded against.
+func System(organizationID, userID uuid.UUID) Principal {
+	return Principal{UserID: userID, OrganizationID: organizationID, Role: RoleOwner}
+}
+
+// Require returns ErrForbidden unless the principal's role allows the action
+func (p Principal) Require(action Action) error {
+	if !p.Role.Allows(action) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Owns reports whether something belonging to the organization is within
+// the principal's reach
+func (p Principal) Owns(organizationID uuid.UUID) bool {
+	return organizationID == p.OrganizationID
+}
+
+// Can reports whether the principal's role allows the action
+func (p Principal) Can(action Action) bool {
+	return p.Role.Allows(action)
+}
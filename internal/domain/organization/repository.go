@@ -0,0 +1,26 @@
+// internal/domain/organization/repository.go
+package organization
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Create saves the organization along with its first member
+	Create(ctx context.Context, org *Organization, owner *Member) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	Update(ctx context.Context, org *Organization) error
+	// GetMemberships returns the organizations the user belongs to, oldest
+	// membership first
+	GetMemberships(ctx context.Context, userID uuid.UUID) ([]Membership, error)
+	GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*Member, error)
+	// GetMembers returns the organization's members with their names and
+	// emails
+	GetMembers(ctx context.Context, organizationID uuid.UUID) ([]Member, error)
+	AddMember(ctx context.Context, member *Member) error
+	UpdateMember(ctx context.Context, member *Member) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+	CountOwners(ctx context.Context, organizationID uuid.UUID) (int, error)
+}
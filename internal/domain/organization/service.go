@@ -0,0 +1,270 @@
+// internal/domain/organization/service.go
+package organization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrOrganizationNotFound = fmt.Errorf("organization not found")
+	ErrNotMember            = fmt.Errorf("not a member of the organization")
+	ErrNoOrganization       = fmt.Errorf("user doesn't belong to any organization")
+	ErrForbidden            = fmt.Errorf("your role doesn't allow this")
+	ErrMemberNotFound       = fmt.Errorf("member not found")
+	ErrUserNotFound         = fmt.Errorf("no user with that email")
+	ErrAlreadyMember        = fmt.Errorf("user is already a member")
+	ErrLastOwner            = fmt.Errorf("an organization needs at least one owner")
+	ErrInvalidRole          = fmt.Errorf("invalid role")
+	ErrInvalidOrganization  = fmt.Errorf("invalid organization")
+	ErrInvalidCurrency      = fmt.Errorf("invalid currency code")
+)
+
+type Service struct {
+	repo  Repository
+	users user.Repository
+}
+
+func NewService(repo Repository, users user.Repository) *Service {
+	return &Service{
+		repo:  repo,
+		users: users,
+	}
+}
+
+// Create starts an organization with the user as its owner
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req CreateRequest) (*Organization, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("%w: a name is required", ErrInvalidOrganization)
+	}
+	if !currency.IsValid(req.BaseCurrency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	now := time.Now()
+	org := &Organization{
+		ID:           uuid.New(),
+		Name:         strings.TrimSpace(req.Name),
+		BaseCurrency: req.BaseCurrency,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	owner := &Member{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Role:           RoleOwner,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.repo.Create(ctx, org, owner); err != nil {
+		return nil, fmt.Errorf("creating organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// CreatePersonal starts the organization a newly registered user works in
+func (s *Service) CreatePersonal(ctx context.Context, u *user.User) (*Organization, error) {
+	name := u.FullName
+	if strings.TrimSpace(name) == "" {
+		name = u.Email
+	}
+	return s.Create(ctx, u.ID, CreateRequest{Name: name, BaseCurrency: "USD"})
+}
+
+// Memberships returns the organizations the user belongs to
+func (s *Service) Memberships(ctx context.Context, userID uuid.UUID) ([]Membership, error) {
+	return s.repo.GetMemberships(ctx, userID)
+}
+
+// Principal returns the user acting in the organization, with their
+// current role
+func (s *Service) Principal(ctx context.Context, organizationID, userID uuid.UUID) (Principal, error) {
+	member, err := s.repo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return Principal{}, ErrNotMember
+	}
+	return Principal{UserID: userID, OrganizationID: organizationID, Role: member.Role}, nil
+}
+
+// DefaultPrincipal returns the user acting in the organization they joined
+// first, which is where they start after logging in
+func (s *Service) DefaultPrincipal(ctx context.Context, userID uuid.UUID) (Principal, error) {
+	memberships, err := s.repo.GetMemberships(ctx, userID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("getting memberships: %w", err)
+	}
+	if len(memberships) == 0 {
+		return Principal{}, ErrNoOrganization
+	}
+	return Principal{UserID: userID, OrganizationID: memberships[0].ID, Role: memberships[0].Role}, nil
+}
+
+func (s *Service) GetOrganization(ctx context.Context, p Principal) (*Organization, error) {
+	org, err := s.repo.GetByID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// RequiresMFA reports whether the organization's members must log in with a
+// second factor to work in it
+func (s *Service) RequiresMFA(ctx context.Context, organizationID uuid.UUID) (bool, error) {
+	org, err := s.repo.GetByID(ctx, organizationID)
+	if err != nil {
+		return false, err
+	}
+	return org.RequireMFA, nil
+}
+
+// UpdateOrganization renames the organization, changes its base currency or
+// whether it requires two-factor authentication. Invoices keep the base
+// currency and rate they were issued with.
+func (s *Service) UpdateOrganization(ctx context.Context, p Principal, req UpdateRequest) (*Organization, error) {
+	if err := p.Require(ActionManageOrganization); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("%w: a name is required", ErrInvalidOrganization)
+	}
+	if !currency.IsValid(req.BaseCurrency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	org, err := s.GetOrganization(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	org.Name = strings.TrimSpace(req.Name)
+	org.BaseCurrency = req.BaseCurrency
+	org.RequireMFA = req.RequireMFA
+	org.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("updating organization: %w", err)
+	}
+
+	return org, nil
+}
+
+func (s *Service) ListMembers(ctx context.Context, p Principal) ([]Member, error) {
+	return s.repo.GetMembers(ctx, p.OrganizationID)
+}
+
+// AddMember adds an existing user to the organization. Only owners can add
+// other owners.
+func (s *Service) AddMember(ctx context.Context, p Principal, email string, role Role) (*Member, error) {
+	if err := p.Require(ActionManageMembers); err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if role == RoleOwner && p.Role != RoleOwner {
+		return nil, ErrForbidden
+	}
+
+	u, err := s.users.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if _, err := s.repo.GetMember(ctx, p.OrganizationID, u.ID); err == nil {
+		return nil, ErrAlreadyMember
+	}
+
+	now := time.Now()
+	member := &Member{
+		OrganizationID: p.OrganizationID,
+		UserID:         u.ID,
+		Role:           role,
+		Email:          u.Email,
+		FullName:       u.FullName,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("adding member: %w", err)
+	}
+
+	return member, nil
+}
+
+// UpdateMember changes a member's role. Only owners can change an owner's
+// role or make someone an owner, and the last owner can't step down.
+func (s *Service) UpdateMember(ctx context.Context, p Principal, userID uuid.UUID, role Role) (*Member, error) {
+	if err := p.Require(ActionManageMembers); err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	member, err := s.repo.GetMember(ctx, p.OrganizationID, userID)
+	if err != nil {
+		return nil, ErrMemberNotFound
+	}
+	if (member.Role == RoleOwner || role == RoleOwner) && p.Role != RoleOwner {
+		return nil, ErrForbidden
+	}
+	if member.Role == RoleOwner && role != RoleOwner {
+		if err := s.keepOwner(ctx, p.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = role
+	member.UpdatedAt = time.Now()
+	if err := s.repo.UpdateMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("updating member: %w", err)
+	}
+
+	return member, nil
+}
+
+// RemoveMember takes a user out of the organization. Members may always
+// leave; removing others takes the right to manage members, and only owners
+// can remove an owner. The last owner can't leave.
+func (s *Service) RemoveMember(ctx context.Context, p Principal, userID uuid.UUID) error {
+	if userID != p.UserID {
+		if err := p.Require(ActionManageMembers); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.repo.GetMember(ctx, p.OrganizationID, userID)
+	if err != nil {
+		return ErrMemberNotFound
+	}
+	if member.Role == RoleOwner {
+		if p.Role != RoleOwner {
+			return ErrForbidden
+		}
+		if err := s.keepOwner(ctx, p.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.RemoveMember(ctx, p.OrganizationID, userID)
+}
+
+// keepOwner returns ErrLastOwner unless another owner would remain after
+// one stepped down
+func (s *Service) keepOwner(ctx context.Context, organizationID uuid.UUID) error {
+	owners, err := s.repo.CountOwners(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("counting owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// internal/domain/payment/entity.go
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Method string
+
+const (
+	MethodSquare       Method = "square"
+	MethodBankTransfer Method = "bank_transfer"
+	MethodCash         Method = "cash"
+	MethodCheck        Method = "check"
+)
+
+// Payment is one entry in an invoice's payment ledger. Refunds are recorded
+// as payments with a negative amount.
+type Payment struct {
+	ID        uuid.UUID `db:"id"`
+	InvoiceID uuid.UUID `db:"invoice_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Amount    float64   `db:"amount"` // In the invoice currency
+	Currency  string    `db:"currency"`
+	Method    Method    `db:"method"`
+	PaidAt    time.Time `db:"paid_at"`
+	Reference string    `db:"reference"`
+	Notes     string    `db:"notes"`
+	CreatedAt time.Time `db:"created_at"`
+
+	// Rate from Currency to the invoice's base currency on the payment date,
+	// and the realized gain or loss in base currency against the issue rate
+	ExchangeRate float64 `db:"exchange_rate"`
+	FXGainLoss   float64 `db:"fx_gain_loss"`
+}
+
+func (p *Payment) IsRefund() bool {
+	return p.Amount < 0
+}
+
+func (m Method) IsValid() bool {
+	switch m {
+	case MethodSquare, MethodBankTransfer, MethodCash, MethodCheck:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+// internal/domain/payment/repository.go
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type Repository interface {
+	// Record saves the payment in one transaction with the invoice it's for,
+	// whose row stays locked meanwhile. apply gets the invoice and the sum of
+	// its payments including this one, and either updates the invoice's paid
+	// amount and status to save or returns an error to undo the payment.
+	Record(ctx context.Context, payment *Payment, apply func(inv *invoice.Invoice, totalPaid float64) error) error
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]Payment, error)
+}
@@ -0,0 +1,168 @@
+// internal/domain/payment/service.go
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrInvalidAmount     = fmt.Errorf("payment amount must not be zero")
+	ErrInvalidMethod     = fmt.Errorf("invalid payment method")
+	ErrInvoiceNotPayable = fmt.Errorf("invoice cannot take payments in its current status")
+	ErrRefundExceedsPaid = fmt.Errorf("refund exceeds amount paid")
+	ErrRateNotFound      = fmt.Errorf("no exchange rate to base currency")
+)
+
+// ExchangeRates looks up the rate between two currencies on a date
+type ExchangeRates interface {
+	Rate(ctx context.Context, organizationID uuid.UUID, from, to string, on time.Time) (float64, error)
+}
+
+type Service struct {
+	repo     Repository
+	invoices invoice.Repository
+	rates    ExchangeRates
+}
+
+func NewService(repo Repository, invoices invoice.Repository, rates ExchangeRates) *Service {
+	return &Service{
+		repo:     repo,
+		invoices: invoices,
+		rates:    rates,
+	}
+}
+
+type RecordPaymentRequest struct {
+	Amount    float64
+	Method    Method
+	PaidAt    time.Time
+	Reference string
+	Notes     string
+}
+
+// RecordPayment adds a payment (or a refund, when the amount is negative) to
+// the invoice's ledger and updates the invoice's paid amount and status.
+func (s *Service) RecordPayment(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, req RecordPaymentRequest) (*Payment, *invoice.Invoice, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, nil, invoice.ErrUnauthorized
+	}
+
+	if !inv.AcceptsPayments() {
+		return nil, nil, ErrInvoiceNotPayable
+	}
+
+	amount := currency.Round(req.Amount, inv.Currency)
+	if amount == 0 {
+		return nil, nil, ErrInvalidAmount
+	}
+
+	if !req.Method.IsValid() {
+		return nil, nil, ErrInvalidMethod
+	}
+
+	paidAt := req.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	rate, err := s.baseRate(ctx, inv, paidAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payment := &Payment{
+		ID:           uuid.New(),
+		InvoiceID:    inv.ID,
+		UserID:       p.UserID,
+		Amount:       amount,
+		Currency:     inv.Currency,
+		Method:       req.Method,
+		PaidAt:       paidAt,
+		Reference:    req.Reference,
+		Notes:        req.Notes,
+		CreatedAt:    time.Now(),
+		ExchangeRate: rate,
+		FXGainLoss:   currency.Round(amount*(rate-inv.ExchangeRate), inv.BaseCurrency),
+	}
+
+	// The checks are made again against the locked invoice, so concurrent
+	// payments and refunds can't each pass against a stale paid amount
+	err = s.repo.Record(ctx, payment, func(locked *invoice.Invoice, totalPaid float64) error {
+		if !locked.AcceptsPayments() {
+			return ErrInvoiceNotPayable
+		}
+		if amount < 0 && totalPaid < 0 {
+			return ErrRefundExceedsPaid
+		}
+		locked.ApplyPayments(totalPaid, paidAt)
+		locked.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvoiceNotPayable) || errors.Is(err, ErrRefundExceedsPaid) ||
+			errors.Is(err, invoice.ErrInvoiceNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("recording payment: %w", err)
+	}
+
+	inv, err = s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting invoice: %w", err)
+	}
+
+	return payment, inv, nil
+}
+
+// baseRate returns the rate from the invoice currency to its base currency
+// on the payment date
+func (s *Service) baseRate(ctx context.Context, inv *invoice.Invoice, on time.Time) (float64, error) {
+	if inv.Currency == inv.BaseCurrency || s.rates == nil {
+		return inv.ExchangeRate, nil
+	}
+
+	rate, err := s.rates.Rate(ctx, inv.OrganizationID, inv.Currency, inv.BaseCurrency, on)
+	if err != nil {
+		return 0, ErrRateNotFound
+	}
+	return rate, nil
+}
+
+func (s *Service) ListPayments(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Payment, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, invoice.ErrUnauthorized
+	}
+
+	payments, err := s.repo.GetByInvoiceID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing payments: %w", err)
+	}
+	return payments, nil
+}
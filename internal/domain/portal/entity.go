@@ -0,0 +1,65 @@
+// internal/domain/portal/entity.go
+package portal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+// Resource is the part of a shared invoice a client looked at
+type Resource string
+
+const (
+	ResourceInvoice  Resource = "invoice"
+	ResourcePDF      Resource = "pdf"
+	ResourcePayments Resource = "payments"
+)
+
+// Share grants access to one invoice through a signed link until it expires
+// or is revoked
+type Share struct {
+	ID        uuid.UUID  `db:"id"`
+	InvoiceID uuid.UUID  `db:"invoice_id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+
+	// Filled in when loading, from the share's views
+	ViewCount    int        `db:"view_count"`
+	LastViewedAt *time.Time `db:"last_viewed_at"`
+}
+
+// View records one visit to a shared invoice
+type View struct {
+	ID        uuid.UUID `db:"id"`
+	ShareID   uuid.UUID `db:"share_id"`
+	InvoiceID uuid.UUID `db:"invoice_id"`
+	Resource  Resource  `db:"resource"`
+	IPAddress string    `db:"ip_address"`
+	UserAgent string    `db:"user_agent"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Visitor describes who opened a share link
+type Visitor struct {
+	IPAddress string
+	UserAgent string
+}
+
+// Document is what the portal shows for a share
+type Document struct {
+	Share      *Share
+	Invoice    *invoice.Invoice
+	Client     *client.Client
+	IssuerName string
+}
+
+// IsActive reports whether the share can still be used at the given time
+func (s *Share) IsActive(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
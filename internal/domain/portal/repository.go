@@ -0,0 +1,20 @@
+// internal/domain/portal/repository.go
+package portal
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for invoice share persistence
+type Repository interface {
+	Create(ctx context.Context, share *Share) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Share, error)
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]Share, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	RecordView(ctx context.Context, view *View) error
+	GetViews(ctx context.Context, invoiceID uuid.UUID) ([]View, error)
+}
@@ -0,0 +1,276 @@
+// internal/domain/portal/service.go
+package portal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/payment"
+)
+
+var (
+	ErrShareNotFound = fmt.Errorf("share link not found")
+	ErrShareExpired  = fmt.Errorf("share link has expired")
+	ErrShareRevoked  = fmt.Errorf("share link has been revoked")
+	ErrInvalidToken  = fmt.Errorf("invalid share token")
+	ErrNotShareable  = fmt.Errorf("draft and cancelled invoices cannot be shared")
+	ErrInvalidExpiry = fmt.Errorf("invalid share expiry")
+)
+
+// MaxLifetime is the longest a share link may stay valid
+const MaxLifetime = 365 * 24 * time.Hour
+
+// TokenSigner turns a share into the token used in its link and back
+type TokenSigner interface {
+	// Sign is deterministic, so a share's link can be shown again later
+	Sign(share *Share) (string, error)
+	// Verify returns the share ID, or ErrShareExpired or ErrInvalidToken
+	Verify(token string) (uuid.UUID, error)
+}
+
+// Issuer renders invoices and records the client's first view
+type Issuer interface {
+	GeneratePDF(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]byte, error)
+	MarkViewed(ctx context.Context, inv *invoice.Invoice, at time.Time) error
+}
+
+type Service struct {
+	repo          Repository
+	invoices      invoice.Repository
+	payments      payment.Repository
+	clients       client.Repository
+	organizations organization.Repository
+	issuer        Issuer
+	signer        TokenSigner
+	lifetime      time.Duration // Default share lifetime
+}
+
+func NewService(
+	repo Repository,
+	invoices invoice.Repository,
+	payments payment.Repository,
+	clients client.Repository,
+	organizations organization.Repository,
+	issuer Issuer,
+	signer TokenSigner,
+	lifetime time.Duration,
+) *Service {
+	return &Service{
+		repo:          repo,
+		invoices:      invoices,
+		payments:      payments,
+		clients:       clients,
+		organizations: organizations,
+		issuer:        issuer,
+		signer:        signer,
+		lifetime:      lifetime,
+	}
+}
+
+// CreateShare creates a link to the invoice valid for lifetime, or the
+// default lifetime when zero, and returns it with its token
+func (s *Service) CreateShare(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, lifetime time.Duration) (*Share, string, error) {
+	inv, err := s.ownInvoice(ctx, p, organization.ActionManageBilling, invoiceID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !shareable(inv) {
+		return nil, "", ErrNotShareable
+	}
+
+	if lifetime == 0 {
+		lifetime = s.lifetime
+	}
+	if lifetime < 0 || lifetime > MaxLifetime {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	// Tokens carry whole seconds, so the share does too
+	now := time.Now().Truncate(time.Second)
+	share := &Share{
+		ID:        uuid.New(),
+		InvoiceID: inv.ID,
+		UserID:    p.UserID,
+		ExpiresAt: now.Add(lifetime),
+		CreatedAt: now,
+	}
+
+	token, err := s.signer.Sign(share)
+	if err != nil {
+		return nil, "", fmt.Errorf("signing share token: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, share); err != nil {
+		return nil, "", fmt.Errorf("creating share: %w", err)
+	}
+
+	return share, token, nil
+}
+
+func (s *Service) ListShares(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]Share, error) {
+	if _, err := s.ownInvoice(ctx, p, organization.ActionViewBilling, invoiceID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByInvoiceID(ctx, invoiceID)
+}
+
+// Token returns the token for an existing share
+func (s *Service) Token(share *Share) (string, error) {
+	return s.signer.Sign(share)
+}
+
+func (s *Service) RevokeShare(ctx context.Context, p organization.Principal, invoiceID, shareID uuid.UUID) (*Share, error) {
+	if _, err := s.ownInvoice(ctx, p, organization.ActionManageBilling, invoiceID); err != nil {
+		return nil, err
+	}
+
+	share, err := s.repo.GetByID(ctx, shareID)
+	if err != nil || share.InvoiceID != invoiceID {
+		return nil, ErrShareNotFound
+	}
+
+	if share.RevokedAt != nil {
+		return share, nil
+	}
+
+	now := time.Now()
+	if err := s.repo.Revoke(ctx, share.ID, now); err != nil {
+		return nil, fmt.Errorf("revoking share: %w", err)
+	}
+	share.RevokedAt = &now
+
+	return share, nil
+}
+
+func (s *Service) ListViews(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) ([]View, error) {
+	if _, err := s.ownInvoice(ctx, p, organization.ActionViewBilling, invoiceID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetViews(ctx, invoiceID)
+}
+
+// Open resolves a share token to the invoice behind it and records the visit
+func (s *Service) Open(ctx context.Context, token string, resource Resource, visitor Visitor) (*Document, error) {
+	shareID, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := s.repo.GetByID(ctx, shareID)
+	if err != nil {
+		return nil, ErrShareNotFound
+	}
+
+	now := time.Now()
+	if share.RevokedAt != nil {
+		return nil, ErrShareRevoked
+	}
+	if !share.IsActive(now) {
+		return nil, ErrShareExpired
+	}
+
+	inv, err := s.invoices.GetByID(ctx, share.InvoiceID)
+	if err != nil {
+		return nil, ErrShareNotFound
+	}
+	if !shareable(inv) {
+		return nil, ErrNotShareable
+	}
+
+	view := &View{
+		ID:        uuid.New(),
+		ShareID:   share.ID,
+		InvoiceID: inv.ID,
+		Resource:  resource,
+		IPAddress: visitor.IPAddress,
+		UserAgent: visitor.UserAgent,
+		CreatedAt: now,
+	}
+	if err := s.repo.RecordView(ctx, view); err != nil {
+		slog.Error("failed to record invoice view", "share_id", share.ID, "error", err)
+	}
+
+	if inv.ViewedAt == nil {
+		if err := s.issuer.MarkViewed(ctx, inv, now); err != nil {
+			slog.Error("failed to mark invoice viewed", "invoice_id", inv.ID, "error", err)
+		}
+	}
+
+	doc := &Document{Share: share, Invoice: inv}
+	if doc.Client, err = s.clients.GetByID(ctx, inv.ClientID); err != nil {
+		return nil, fmt.Errorf("getting client: %w", err)
+	}
+
+	issuer, err := s.organizations.GetByID(ctx, inv.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting issuer: %w", err)
+	}
+	doc.IssuerName = issuer.Name
+
+	return doc, nil
+}
+
+// PDF returns the shared invoice's PDF
+func (s *Service) PDF(ctx context.Context, token string, visitor Visitor) (*Document, []byte, error) {
+	doc, err := s.Open(ctx, token, ResourcePDF, visitor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuer := organization.System(doc.Invoice.OrganizationID, doc.Invoice.UserID)
+	pdfBytes, err := s.issuer.GeneratePDF(ctx, issuer, doc.Invoice.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating PDF: %w", err)
+	}
+
+	return doc, pdfBytes, nil
+}
+
+// Payments returns the payment history of the shared invoice
+func (s *Service) Payments(ctx context.Context, token string, visitor Visitor) (*Document, []payment.Payment, error) {
+	doc, err := s.Open(ctx, token, ResourcePayments, visitor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payments, err := s.payments.GetByInvoiceID(ctx, doc.Invoice.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting payments: %w", err)
+	}
+
+	return doc, payments, nil
+}
+
+// ownInvoice returns the invoice if the principal may take the action on it
+func (s *Service) ownInvoice(ctx context.Context, p organization.Principal, action organization.Action, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	if err := p.Require(action); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, invoice.ErrInvoiceNotFound
+	}
+
+	if !p.Owns(inv.OrganizationID) {
+		return nil, invoice.ErrUnauthorized
+	}
+
+	return inv, nil
+}
+
+// shareable reports whether clients may see the invoice, so links stop
+// working once it is cancelled
+func shareable(inv *invoice.Invoice) bool {
+	return inv.Status != invoice.StatusDraft && inv.Status != invoice.StatusCancelled
+}
@@ -0,0 +1,54 @@
+// internal/domain/project/entity.go
+package project
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project groups a client's work. Time entries on its Jira project's issues
+// are assigned to it when they are imported.
+type Project struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	ClientID       uuid.UUID `db:"client_id"`
+	Name           string    `db:"name"`
+	HourlyRate     *float64  `db:"hourly_rate"`      // Default rate for the project's time
+	BudgetHours    *float64  `db:"budget_hours"`     // nil means no budget
+	BudgetAmount   *float64  `db:"budget_amount"`    // nil means no budget
+	JiraProjectKey *string   `db:"jira_project_key"` // Such as ABC for issue ABC-123
+	Archived       bool      `db:"archived"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Task is an optional breakdown of a project's work, with its own rate
+type Task struct {
+	ID         uuid.UUID `db:"id"`
+	ProjectID  uuid.UUID `db:"project_id"`
+	Name       string    `db:"name"`
+	HourlyRate *float64  `db:"hourly_rate"` // Overrides the project's rate
+	Archived   bool      `db:"archived"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// Usage is the time tracked against a project
+type Usage struct {
+	Hours         float64 `db:"hours"`
+	BillableHours float64 `db:"billable_hours"`
+	InvoicedHours float64 `db:"invoiced_hours"`
+}
+
+// JiraProjectKey returns the project key of a Jira issue key, or "" when the
+// issue key has none
+func JiraProjectKey(issueKey string) string {
+	i := strings.LastIndex(issueKey, "-")
+	if i <= 0 {
+		return ""
+	}
+	return strings.ToUpper(issueKey[:i])
+}
@@ -0,0 +1,30 @@
+// internal/domain/project/repository.go
+package project
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for project and task persistence
+type Repository interface {
+	Create(ctx context.Context, project *Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Project, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters ListFilters) ([]Project, error)
+	GetByJiraKey(ctx context.Context, organizationID uuid.UUID, key string) (*Project, error)
+	Update(ctx context.Context, project *Project) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetUsage(ctx context.Context, id uuid.UUID) (*Usage, error)
+
+	CreateTask(ctx context.Context, task *Task) error
+	GetTask(ctx context.Context, id uuid.UUID) (*Task, error)
+	GetTasks(ctx context.Context, projectID uuid.UUID) ([]Task, error)
+	UpdateTask(ctx context.Context, task *Task) error
+	DeleteTask(ctx context.Context, id uuid.UUID) error
+}
+
+type ListFilters struct {
+	ClientID        *uuid.UUID
+	IncludeArchived bool
+}
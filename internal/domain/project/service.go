@@ -0,0 +1,250 @@
+// internal/domain/project/service.go
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/organization"
+)
+
+var (
+	ErrProjectNotFound = fmt.Errorf("project not found")
+	ErrTaskNotFound    = fmt.Errorf("task not found")
+	ErrUnauthorized    = fmt.Errorf("unauthorized access")
+	ErrInvalidProject  = fmt.Errorf("invalid project")
+	ErrJiraKeyTaken    = fmt.Errorf("another project already uses this Jira project key")
+	ErrClientNotFound  = fmt.Errorf("client not found")
+)
+
+type Service struct {
+	repo    Repository
+	clients client.Repository
+}
+
+func NewService(repo Repository, clients client.Repository) *Service {
+	return &Service{
+		repo:    repo,
+		clients: clients,
+	}
+}
+
+func (s *Service) CreateProject(ctx context.Context, p organization.Principal, req ProjectRequest) (*Project, error) {
+	if err := p.Require(organization.ActionManageProjects); err != nil {
+		return nil, err
+	}
+
+	project := &Project{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := s.apply(ctx, project, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, project); err != nil {
+		return nil, fmt.Errorf("creating project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (s *Service) GetProject(ctx context.Context, p organization.Principal, projectID uuid.UUID) (*Project, error) {
+	if err := p.Require(organization.ActionViewProjects); err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	if !p.Owns(project.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return project, nil
+}
+
+func (s *Service) ListProjects(ctx context.Context, p organization.Principal, filters ListFilters) ([]Project, error) {
+	if err := p.Require(organization.ActionViewProjects); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrganizationID(ctx, p.OrganizationID, filters)
+}
+
+func (s *Service) UpdateProject(ctx context.Context, p organization.Principal, projectID uuid.UUID, req ProjectRequest) (*Project, error) {
+	project, err := s.manageProject(ctx, p, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, project, req); err != nil {
+		return nil, err
+	}
+
+	project.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("updating project: %w", err)
+	}
+
+	return project, nil
+}
+
+// DeleteProject removes a project and its tasks; its time entries are kept
+// without a project
+func (s *Service) DeleteProject(ctx context.Context, p organization.Principal, projectID uuid.UUID) error {
+	if _, err := s.manageProject(ctx, p, projectID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, projectID)
+}
+
+// Usage returns the time tracked against the project
+func (s *Service) Usage(ctx context.Context, p organization.Principal, projectID uuid.UUID) (*Usage, error) {
+	if _, err := s.GetProject(ctx, p, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetUsage(ctx, projectID)
+}
+
+func (s *Service) CreateTask(ctx context.Context, p organization.Principal, projectID uuid.UUID, req TaskRequest) (*Task, error) {
+	if _, err := s.manageProject(ctx, p, projectID); err != nil {
+		return nil, err
+	}
+
+	task := &Task{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := applyTask(task, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("creating task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (s *Service) ListTasks(ctx context.Context, p organization.Principal, projectID uuid.UUID) ([]Task, error) {
+	if _, err := s.GetProject(ctx, p, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTasks(ctx, projectID)
+}
+
+func (s *Service) UpdateTask(ctx context.Context, p organization.Principal, projectID, taskID uuid.UUID, req TaskRequest) (*Task, error) {
+	task, err := s.ownTask(ctx, p, projectID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTask(task, req); err != nil {
+		return nil, err
+	}
+
+	task.UpdatedAt = time.Now()
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("updating task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (s *Service) DeleteTask(ctx context.Context, p organization.Principal, projectID, taskID uuid.UUID) error {
+	if _, err := s.ownTask(ctx, p, projectID, taskID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteTask(ctx, taskID)
+}
+
+// manageProject returns the project if the principal may change it
+func (s *Service) manageProject(ctx context.Context, p organization.Principal, projectID uuid.UUID) (*Project, error) {
+	if err := p.Require(organization.ActionManageProjects); err != nil {
+		return nil, err
+	}
+	return s.GetProject(ctx, p, projectID)
+}
+
+func (s *Service) ownTask(ctx context.Context, p organization.Principal, projectID, taskID uuid.UUID) (*Task, error) {
+	if _, err := s.manageProject(ctx, p, projectID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil || task.ProjectID != projectID {
+		return nil, ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+// apply validates the request and copies it onto the project
+func (s *Service) apply(ctx context.Context, project *Project, req ProjectRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("%w: a name is required", ErrInvalidProject)
+	}
+	for _, v := range []*float64{req.HourlyRate, req.BudgetHours, req.BudgetAmount} {
+		if v != nil && *v < 0 {
+			return fmt.Errorf("%w: rates and budgets can't be negative", ErrInvalidProject)
+		}
+	}
+
+	c, err := s.clients.GetByID(ctx, req.ClientID)
+	if err != nil || c.OrganizationID != project.OrganizationID {
+		return ErrClientNotFound
+	}
+
+	var jiraKey *string
+	if req.JiraProjectKey != nil && strings.TrimSpace(*req.JiraProjectKey) != "" {
+		key := strings.ToUpper(strings.TrimSpace(*req.JiraProjectKey))
+		if strings.Contains(key, "-") {
+			return fmt.Errorf("%w: a Jira project key is the part before the issue number", ErrInvalidProject)
+		}
+		if other, err := s.repo.GetByJiraKey(ctx, project.OrganizationID, key); err == nil && other.ID != project.ID {
+			return ErrJiraKeyTaken
+		}
+		jiraKey = &key
+	}
+
+	project.ClientID = req.ClientID
+	project.Name = strings.TrimSpace(req.Name)
+	project.HourlyRate = req.HourlyRate
+	project.BudgetHours = req.BudgetHours
+	project.BudgetAmount = req.BudgetAmount
+	project.JiraProjectKey = jiraKey
+	project.Archived = req.Archived
+	return nil
+}
+
+func applyTask(task *Task, req TaskRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("%w: a task name is required", ErrInvalidProject)
+	}
+	if req.HourlyRate != nil && *req.HourlyRate < 0 {
+		return fmt.Errorf("%w: rates can't be negative", ErrInvalidProject)
+	}
+
+	task.Name = strings.TrimSpace(req.Name)
+	task.HourlyRate = req.HourlyRate
+	task.Archived = req.Archived
+	return nil
+}
@@ -0,0 +1,20 @@
+// internal/domain/project/types.go
+package project
+
+import "github.com/google/uuid"
+
+type ProjectRequest struct {
+	ClientID       uuid.UUID
+	Name           string
+	HourlyRate     *float64
+	BudgetHours    *float64
+	BudgetAmount   *float64
+	JiraProjectKey *string
+	Archived       bool
+}
+
+type TaskRequest struct {
+	Name       string
+	HourlyRate *float64
+	Archived   bool
+}
@@ -0,0 +1,182 @@
+// internal/domain/quote/entity.go
+package quote
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type Status string
+
+const (
+	StatusDraft    Status = "draft"
+	StatusSent     Status = "sent"
+	StatusAccepted Status = "accepted"
+	StatusDeclined Status = "declined"
+	StatusExpired  Status = "expired"
+)
+
+// Quote is an estimate sent to a client before any work is invoiced. Its
+// lines are priced exactly as an invoice's would be.
+type Quote struct {
+	ID               uuid.UUID `db:"id"`
+	OrganizationID   uuid.UUID `db:"organization_id"`
+	UserID           uuid.UUID `db:"user_id"`
+	ClientID         uuid.UUID `db:"client_id"`
+	QuoteNumber      string    `db:"quote_number"`
+	Status           Status    `db:"status"`
+	IssueDate        time.Time `db:"issue_date"`
+	ExpiryDate       time.Time `db:"expiry_date"` // Last day the quote can be accepted
+	Currency         string    `db:"currency"`
+	TaxRate          float64   `db:"tax_rate"`
+	PricesIncludeTax bool      `db:"prices_include_tax"`
+	Notes            string    `db:"notes"`
+
+	DiscountType   invoice.DiscountType `db:"discount_type"`
+	DiscountValue  float64              `db:"discount_value"`
+	DiscountAmount float64              `db:"discount_amount"`
+	Subtotal       float64              `db:"subtotal"`
+	TaxAmount      float64              `db:"tax_amount"`
+	Total          float64              `db:"total"`
+
+	// Days between conversion and the due date of the invoice
+	PaymentTermsDays int `db:"payment_terms_days"`
+
+	Items Lines `db:"items"`
+
+	// Set once an accepted quote has been converted
+	InvoiceID *uuid.UUID `db:"invoice_id"`
+
+	SentAt     *time.Time `db:"sent_at"`
+	AcceptedAt *time.Time `db:"accepted_at"`
+	DeclinedAt *time.Time `db:"declined_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// Lines are the quote's priced invoice line items
+type Lines []invoice.InvoiceItem
+
+// Value stores the lines as JSONB
+func (l Lines) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+
+// Scan loads the lines from JSONB
+func (l *Lines) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("scanning quote lines: unexpected type %T", src)
+	}
+}
+
+// Price copies the lines and totals of a priced invoice onto the quote
+func (q *Quote) Price(inv *invoice.Invoice) {
+	q.Items = inv.Items
+	q.DiscountType = inv.DiscountType
+	q.DiscountValue = inv.DiscountValue
+	q.DiscountAmount = inv.DiscountAmount
+	q.Subtotal = inv.Subtotal
+	q.TaxAmount = inv.TaxAmount
+	q.Total = inv.Total
+}
+
+// TaxBreakdown totals the quote's taxes the way its invoice would
+func (q *Quote) TaxBreakdown() []invoice.TaxSummary {
+	preview := invoice.Invoice{Currency: q.Currency, Items: q.Items}
+	return preview.TaxBreakdown()
+}
+
+// IsExpired reports whether the quote can no longer be accepted at the given time
+func (q *Quote) IsExpired(at time.Time) bool {
+	return truncateDay(at).After(truncateDay(q.ExpiryDate))
+}
+
+func (q *Quote) MarkAsSent() error {
+	if q.Status != StatusDraft {
+		return ErrInvalidStatusTransition
+	}
+	now := time.Now()
+	q.Status = StatusSent
+	q.SentAt = &now
+	q.UpdatedAt = now
+	return nil
+}
+
+func (q *Quote) Accept(at time.Time) error {
+	if q.Status != StatusSent {
+		return ErrInvalidStatusTransition
+	}
+	if q.IsExpired(at) {
+		return ErrQuoteExpired
+	}
+	q.Status = StatusAccepted
+	q.AcceptedAt = &at
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+func (q *Quote) Decline(at time.Time) error {
+	if q.Status != StatusSent {
+		return ErrInvalidStatusTransition
+	}
+	q.Status = StatusDeclined
+	q.DeclinedAt = &at
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+// InvoiceRequest builds the draft invoice an accepted quote converts into.
+// Lines keep the taxes they were quoted with, at the quoted rates, whatever
+// has since happened to their tax codes.
+func (q *Quote) InvoiceRequest(issueDate time.Time) invoice.CreateInvoiceRequest {
+	quoteID := q.ID
+	req := invoice.CreateInvoiceRequest{
+		ClientID:         q.ClientID,
+		IssueDate:        issueDate,
+		DueDate:          issueDate.AddDate(0, 0, q.PaymentTermsDays),
+		TaxRate:          q.TaxRate,
+		PricesIncludeTax: q.PricesIncludeTax,
+		Currency:         q.Currency,
+		Notes:            q.Notes,
+		Items:            make([]invoice.CreateInvoiceItemRequest, len(q.Items)),
+		QuoteID:          &quoteID,
+		DiscountType:     q.DiscountType,
+		DiscountValue:    q.DiscountValue,
+	}
+
+	for i, item := range q.Items {
+		// Not nil even when the line was quoted without tax, so none is added
+		taxes := make([]invoice.ItemTax, len(item.Taxes))
+		copy(taxes, item.Taxes)
+
+		req.Items[i] = invoice.CreateInvoiceItemRequest{
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			Taxes:         taxes,
+			TaxExempt:     item.TaxExempt,
+			DiscountType:  item.DiscountType,
+			DiscountValue: item.DiscountValue,
+		}
+	}
+
+	return req
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
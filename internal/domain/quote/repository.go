@@ -0,0 +1,32 @@
+// internal/domain/quote/repository.go
+package quote
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for quote persistence
+type Repository interface {
+	Create(ctx context.Context, quote *Quote) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Quote, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters ListFilters) ([]Quote, error)
+	Update(ctx context.Context, quote *Quote) error
+	// Transition saves the quote's status and its accepted and declined
+	// times, only if its status is still from. It reports whether it did.
+	Transition(ctx context.Context, quote *Quote, from Status) (bool, error)
+	// LinkInvoice records the invoice the quote was converted into
+	LinkInvoice(ctx context.Context, quoteID, invoiceID uuid.UUID, at time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetNextQuoteNumber(ctx context.Context, organizationID uuid.UUID) (string, error)
+	// ExpireSent marks sent quotes whose expiry date is before asOf as
+	// expired and returns how many changed
+	ExpireSent(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type ListFilters struct {
+	Status   *Status
+	ClientID *uuid.UUID
+}
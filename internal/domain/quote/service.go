@@ -0,0 +1,494 @@
+// internal/domain/quote/service.go
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/pkg/currency"
+	"github.com/invoice-app-be/internal/pkg/mail"
+)
+
+var (
+	ErrQuoteNotFound           = fmt.Errorf("quote not found")
+	ErrUnauthorized            = fmt.Errorf("unauthorized access")
+	ErrInvalidStatusTransition = fmt.Errorf("invalid status transition")
+	ErrInvalidQuote            = fmt.Errorf("invalid quote")
+	ErrQuoteExpired            = fmt.Errorf("quote has expired")
+	ErrAlreadyConverted        = fmt.Errorf("quote has already been converted")
+	ErrInvalidToken            = fmt.Errorf("invalid quote link")
+)
+
+// InvoiceIssuer prices quote lines and creates the invoice an accepted quote
+// converts into
+type InvoiceIssuer interface {
+	PriceInvoice(ctx context.Context, p organization.Principal, req invoice.CreateInvoiceRequest) (*invoice.Invoice, error)
+	CreateInvoice(ctx context.Context, p organization.Principal, req invoice.CreateInvoiceRequest) (*invoice.Invoice, error)
+}
+
+// TokenSigner turns a quote into the token in its portal link and back
+type TokenSigner interface {
+	SignQuote(quote *Quote) (string, error)
+	// VerifyQuote returns the quote ID, or ErrQuoteExpired or ErrInvalidToken
+	VerifyQuote(token string) (uuid.UUID, error)
+}
+
+type Service struct {
+	repo          Repository
+	clients       client.Repository
+	organizations organization.Repository
+	users         user.Repository
+	issuer        InvoiceIssuer
+	signer        TokenSigner
+	mailer        invoice.EmailSender // nil when email is not configured
+	portalURL     string
+}
+
+func NewService(
+	repo Repository,
+	clients client.Repository,
+	organizations organization.Repository,
+	users user.Repository,
+	issuer InvoiceIssuer,
+	signer TokenSigner,
+	mailer invoice.EmailSender,
+	portalURL string,
+) *Service {
+	return &Service{
+		repo:          repo,
+		clients:       clients,
+		organizations: organizations,
+		users:         users,
+		issuer:        issuer,
+		signer:        signer,
+		mailer:        mailer,
+		portalURL:     portalURL,
+	}
+}
+
+func (s *Service) CreateQuote(ctx context.Context, p organization.Principal, req QuoteRequest) (*Quote, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	quote := &Quote{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		Status:         StatusDraft,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := s.apply(ctx, p, quote, req); err != nil {
+		return nil, err
+	}
+
+	number, err := s.repo.GetNextQuoteNumber(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("generating quote number: %w", err)
+	}
+	quote.QuoteNumber = number
+
+	if err := s.repo.Create(ctx, quote); err != nil {
+		return nil, fmt.Errorf("creating quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+func (s *Service) GetQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) (*Quote, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.repo.GetByID(ctx, quoteID)
+	if err != nil {
+		return nil, ErrQuoteNotFound
+	}
+
+	if !p.Owns(quote.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return quote, nil
+}
+
+func (s *Service) ListQuotes(ctx context.Context, p organization.Principal, filters ListFilters) ([]Quote, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrganizationID(ctx, p.OrganizationID, filters)
+}
+
+// manageQuote returns the quote if the principal may change it
+func (s *Service) manageQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) (*Quote, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	return s.GetQuote(ctx, p, quoteID)
+}
+
+// UpdateQuote replaces the quote's details; only drafts can change
+func (s *Service) UpdateQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID, req QuoteRequest) (*Quote, error) {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Status != StatusDraft {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	if err := s.apply(ctx, p, quote, req); err != nil {
+		return nil, err
+	}
+
+	quote.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, quote); err != nil {
+		return nil, fmt.Errorf("updating quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+// DeleteQuote removes a quote that hasn't been converted into an invoice
+func (s *Service) DeleteQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) error {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return err
+	}
+
+	if quote.InvoiceID != nil {
+		return ErrAlreadyConverted
+	}
+
+	return s.repo.Delete(ctx, quoteID)
+}
+
+// SendQuote marks a draft as sent and, with email configured, emails the
+// client a link to accept or decline it. Sent quotes can be sent again.
+func (s *Service) SendQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID, opts invoice.SendOptions) (*Quote, error) {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Status == StatusDraft {
+		if err := quote.MarkAsSent(); err != nil {
+			return nil, err
+		}
+	} else if quote.Status != StatusSent {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	if s.mailer != nil {
+		if err := s.email(ctx, quote, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, quote); err != nil {
+		return nil, fmt.Errorf("updating quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+// Link returns the portal link for a quote that has been sent
+func (s *Service) Link(quote *Quote) (string, error) {
+	token, err := s.signer.SignQuote(quote)
+	if err != nil {
+		return "", fmt.Errorf("signing quote link: %w", err)
+	}
+	return s.portalURL + "/quotes/" + token, nil
+}
+
+// AcceptQuote records the client's acceptance on their behalf and converts
+// the quote into a draft invoice. The invoice is nil when conversion failed.
+func (s *Service) AcceptQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) (*Quote, *invoice.Invoice, error) {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.accept(ctx, quote)
+}
+
+func (s *Service) DeclineQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) (*Quote, error) {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decline(ctx, quote)
+}
+
+// ConvertQuote creates the draft invoice for an accepted quote, for when
+// conversion failed at acceptance
+func (s *Service) ConvertQuote(ctx context.Context, p organization.Principal, quoteID uuid.UUID) (*Quote, *invoice.Invoice, error) {
+	quote, err := s.manageQuote(ctx, p, quoteID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if quote.Status != StatusAccepted {
+		return nil, nil, ErrInvalidStatusTransition
+	}
+
+	inv, err := s.convert(ctx, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return quote, inv, nil
+}
+
+// Open resolves a portal link to the quote behind it
+func (s *Service) Open(ctx context.Context, token string) (*Document, error) {
+	quote, err := s.byToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Quote: quote}
+	if doc.Client, err = s.clients.GetByID(ctx, quote.ClientID); err != nil {
+		return nil, fmt.Errorf("getting client: %w", err)
+	}
+
+	if doc.IssuerName, err = s.issuerName(ctx, quote.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// AcceptByToken records acceptance through the portal link
+func (s *Service) AcceptByToken(ctx context.Context, token string) (*Quote, error) {
+	quote, err := s.byToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, _, err = s.accept(ctx, quote)
+	return quote, err
+}
+
+// DeclineByToken records a decline through the portal link
+func (s *Service) DeclineByToken(ctx context.Context, token string) (*Quote, error) {
+	quote, err := s.byToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decline(ctx, quote)
+}
+
+// ExpireDue marks sent quotes past their expiry date as expired
+func (s *Service) ExpireDue(ctx context.Context, asOf time.Time) (int, error) {
+	return s.repo.ExpireSent(ctx, asOf)
+}
+
+func (s *Service) accept(ctx context.Context, quote *Quote) (*Quote, *invoice.Invoice, error) {
+	if err := quote.Accept(time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.transition(ctx, quote, StatusSent); err != nil {
+		return nil, nil, err
+	}
+
+	// The client has accepted even if the invoice can't be created yet; the
+	// issuer can convert the quote later
+	inv, err := s.convert(ctx, quote)
+	if err != nil {
+		slog.Error("failed to convert accepted quote", "quote_id", quote.ID, "error", err)
+		return quote, nil, nil
+	}
+
+	return quote, inv, nil
+}
+
+func (s *Service) decline(ctx context.Context, quote *Quote) (*Quote, error) {
+	if err := quote.Decline(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.transition(ctx, quote, StatusSent); err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// transition saves the quote's new status unless it moved on from the one
+// it was loaded with, so only the first of a double-clicked accept, or of an
+// accept and a decline at once, wins
+func (s *Service) transition(ctx context.Context, quote *Quote, from Status) error {
+	changed, err := s.repo.Transition(ctx, quote, from)
+	if err != nil {
+		return fmt.Errorf("updating quote: %w", err)
+	}
+	if !changed {
+		return ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+func (s *Service) convert(ctx context.Context, quote *Quote) (*invoice.Invoice, error) {
+	if quote.InvoiceID != nil {
+		return nil, ErrAlreadyConverted
+	}
+
+	// Accepted quotes may be converted on the client's behalf, so the invoice
+	// is issued for whoever created the quote
+	issuer := organization.System(quote.OrganizationID, quote.UserID)
+	inv, err := s.issuer.CreateInvoice(ctx, issuer, quote.InvoiceRequest(truncateDay(time.Now())))
+	if errors.Is(err, invoice.ErrQuoteInvoiced) {
+		return nil, ErrAlreadyConverted
+	}
+	if err != nil {
+		return nil, fmt.Errorf("converting quote: %w", err)
+	}
+
+	quote.InvoiceID = &inv.ID
+	quote.UpdatedAt = time.Now()
+	if err := s.repo.LinkInvoice(ctx, quote.ID, inv.ID, quote.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("updating quote: %w", err)
+	}
+
+	return inv, nil
+}
+
+func (s *Service) byToken(ctx context.Context, token string) (*Quote, error) {
+	quoteID, err := s.signer.VerifyQuote(token)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.repo.GetByID(ctx, quoteID)
+	if err != nil {
+		return nil, ErrQuoteNotFound
+	}
+
+	// Drafts are never linked; a draft here means the token was forged
+	if quote.Status == StatusDraft {
+		return nil, ErrInvalidToken
+	}
+
+	return quote, nil
+}
+
+func (s *Service) email(ctx context.Context, quote *Quote, opts invoice.SendOptions) error {
+	recipient, err := s.clients.GetByID(ctx, quote.ClientID)
+	if err != nil {
+		return fmt.Errorf("getting client: %w", err)
+	}
+	if recipient.Email == "" {
+		return invoice.ErrClientHasNoEmail
+	}
+
+	senderName, err := s.issuerName(ctx, quote.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	// Replies go to whoever created the quote unless the sender says otherwise
+	replyTo := opts.ReplyTo
+	if replyTo == "" {
+		creator, err := s.users.GetByID(ctx, quote.UserID)
+		if err != nil {
+			return fmt.Errorf("getting sender: %w", err)
+		}
+		replyTo = creator.Email
+	}
+
+	link, err := s.Link(quote)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.mailer.Send(ctx, mail.Message{
+		To:       []string{recipient.Email},
+		CC:       opts.CC,
+		BCC:      opts.BCC,
+		ReplyTo:  replyTo,
+		FromName: senderName,
+		Template: "quote",
+		Data: QuoteEmail{
+			Quote:      quote,
+			Client:     recipient,
+			SenderName: senderName,
+			Total:      currency.Format(quote.Total, quote.Currency),
+			ExpiryDate: quote.ExpiryDate.Format("January 2, 2006"),
+			URL:        link,
+			Message:    opts.Message,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", invoice.ErrDeliveryFailed, err)
+	}
+	return nil
+}
+
+func (s *Service) issuerName(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	issuer, err := s.organizations.GetByID(ctx, organizationID)
+	if err != nil {
+		return "", fmt.Errorf("getting issuer: %w", err)
+	}
+	return issuer.Name, nil
+}
+
+// apply validates the request, prices its lines and copies it onto the quote
+func (s *Service) apply(ctx context.Context, p organization.Principal, quote *Quote, req QuoteRequest) error {
+	if len(req.Items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidQuote)
+	}
+	if req.ExpiryDate.Before(req.IssueDate) {
+		return fmt.Errorf("%w: expiry date is before the issue date", ErrInvalidQuote)
+	}
+	if req.PaymentTermsDays < 0 {
+		return fmt.Errorf("%w: payment terms can't be negative", ErrInvalidQuote)
+	}
+
+	c, err := s.clients.GetByID(ctx, req.ClientID)
+	if err != nil || !p.Owns(c.OrganizationID) {
+		return fmt.Errorf("%w: client not found", ErrInvalidQuote)
+	}
+
+	priced, err := s.issuer.PriceInvoice(ctx, p, invoice.CreateInvoiceRequest{
+		ClientID:         req.ClientID,
+		IssueDate:        req.IssueDate,
+		DueDate:          req.IssueDate.AddDate(0, 0, req.PaymentTermsDays),
+		TaxRate:          req.TaxRate,
+		PricesIncludeTax: req.PricesIncludeTax,
+		Currency:         req.Currency,
+		Notes:            req.Notes,
+		Items:            req.Items,
+		DiscountType:     req.DiscountType,
+		DiscountValue:    req.DiscountValue,
+	})
+	if err != nil {
+		return err
+	}
+
+	quote.ClientID = req.ClientID
+	quote.IssueDate = truncateDay(req.IssueDate)
+	quote.ExpiryDate = truncateDay(req.ExpiryDate)
+	quote.PaymentTermsDays = req.PaymentTermsDays
+	quote.TaxRate = req.TaxRate
+	quote.PricesIncludeTax = req.PricesIncludeTax
+	quote.Currency = req.Currency
+	quote.Notes = req.Notes
+	quote.Price(priced)
+	return nil
+}
@@ -0,0 +1,44 @@
+// internal/domain/quote/types.go
+package quote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type QuoteRequest struct {
+	ClientID         uuid.UUID
+	IssueDate        time.Time
+	ExpiryDate       time.Time
+	PaymentTermsDays int
+	TaxRate          float64
+	PricesIncludeTax bool
+	Currency         string
+	Notes            string
+	Items            []invoice.CreateInvoiceItemRequest
+
+	DiscountType  invoice.DiscountType
+	DiscountValue float64
+}
+
+// QuoteEmail is the data the quote email template is rendered with
+type QuoteEmail struct {
+	Quote      *Quote
+	Client     *client.Client
+	SenderName string
+	Total      string
+	ExpiryDate string
+	URL        string // Portal link where the client can accept or decline
+	Message    string
+}
+
+// Document is what the portal shows for a quote
+type Document struct {
+	Quote      *Quote
+	Client     *client.Client
+	IssuerName string
+}
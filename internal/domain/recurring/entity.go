@@ -0,0 +1,158 @@
+// internal/domain/recurring/entity.go
+package recurring
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type Mode string
+
+const (
+	ModeDraft    Mode = "draft"     // Generated invoices wait for review
+	ModeAutoSend Mode = "auto_send" // Generated invoices are sent straight away
+)
+
+// Schedule generates a copy of its template invoice on every occurrence of
+// its rule between StartDate and EndDate
+type Schedule struct {
+	ID             uuid.UUID  `db:"id"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	ClientID       uuid.UUID  `db:"client_id"`
+	Name           string     `db:"name"`
+	Rule           string     `db:"rule"`
+	StartDate      time.Time  `db:"start_date"`
+	EndDate        *time.Time `db:"end_date"`
+	Mode           Mode       `db:"mode"`
+	Active         bool       `db:"active"`
+
+	// Days between the issue date and the due date of generated invoices
+	PaymentTermsDays int `db:"payment_terms_days"`
+
+	// NextRunDate is the issue date of the next invoice; nil once the schedule has ended
+	NextRunDate   *time.Time `db:"next_run_date"`
+	LastIssueDate *time.Time `db:"last_issue_date"`
+
+	Template  Template  `db:"template"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Template holds the invoice fields copied onto every generated invoice
+type Template struct {
+	Currency         string         `json:"currency"`
+	TaxRate          float64        `json:"tax_rate"`
+	PricesIncludeTax bool           `json:"prices_include_tax"`
+	Notes            string         `json:"notes"`
+	Items            []TemplateItem `json:"items"`
+
+	DiscountType                invoice.DiscountType `json:"discount_type"`
+	DiscountValue               float64              `json:"discount_value"`
+	EarlyPaymentDiscountPercent float64              `json:"early_payment_discount_percent"`
+	EarlyPaymentDiscountDays    int                  `json:"early_payment_discount_days"`
+}
+
+type TemplateItem struct {
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity"`
+	UnitPrice   float64     `json:"unit_price"`
+	TaxCodeIDs  []uuid.UUID `json:"tax_code_ids"`
+	TaxExempt   bool        `json:"tax_exempt"`
+
+	DiscountType  invoice.DiscountType `json:"discount_type"`
+	DiscountValue float64              `json:"discount_value"`
+}
+
+// Value stores the template as JSONB
+func (t Template) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+// Scan loads the template from JSONB
+func (t *Template) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	case nil:
+		*t = Template{}
+		return nil
+	default:
+		return fmt.Errorf("scanning template: unexpected type %T", src)
+	}
+}
+
+func (m Mode) IsValid() bool {
+	return m == ModeDraft || m == ModeAutoSend
+}
+
+// IsDue reports whether an invoice should be generated on or before asOf
+func (s *Schedule) IsDue(asOf time.Time) bool {
+	return s.Active && s.NextRunDate != nil && !s.NextRunDate.After(truncateDay(asOf))
+}
+
+// ScheduleFrom sets NextRunDate to the first occurrence on or after from,
+// clearing it when that falls after the end date
+func (s *Schedule) ScheduleFrom(rule Rule, from time.Time) {
+	next := rule.From(s.StartDate, from)
+	if next.IsZero() || (s.EndDate != nil && next.After(truncateDay(*s.EndDate))) {
+		s.NextRunDate = nil
+		return
+	}
+	s.NextRunDate = &next
+}
+
+// Advance records that the invoice for NextRunDate was generated and moves on
+// to the following occurrence
+func (s *Schedule) Advance(rule Rule) {
+	if s.NextRunDate == nil {
+		return
+	}
+	issued := *s.NextRunDate
+	s.LastIssueDate = &issued
+	s.ScheduleFrom(rule, issued.AddDate(0, 0, 1))
+	s.UpdatedAt = time.Now()
+}
+
+// InvoiceRequest builds the invoice for an occurrence from the template
+func (s *Schedule) InvoiceRequest(issueDate time.Time) invoice.CreateInvoiceRequest {
+	scheduleID := s.ID
+	req := invoice.CreateInvoiceRequest{
+		ClientID:            s.ClientID,
+		IssueDate:           issueDate,
+		DueDate:             issueDate.AddDate(0, 0, s.PaymentTermsDays),
+		TaxRate:             s.Template.TaxRate,
+		PricesIncludeTax:    s.Template.PricesIncludeTax,
+		Currency:            s.Template.Currency,
+		Notes:               s.Template.Notes,
+		Items:               make([]invoice.CreateInvoiceItemRequest, len(s.Template.Items)),
+		RecurringScheduleID: &scheduleID,
+
+		DiscountType:                s.Template.DiscountType,
+		DiscountValue:               s.Template.DiscountValue,
+		EarlyPaymentDiscountPercent: s.Template.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    s.Template.EarlyPaymentDiscountDays,
+	}
+
+	for i, item := range s.Template.Items {
+		req.Items[i] = invoice.CreateInvoiceItemRequest{
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			TaxCodeIDs:    item.TaxCodeIDs,
+			TaxExempt:     item.TaxExempt,
+			DiscountType:  item.DiscountType,
+			DiscountValue: item.DiscountValue,
+		}
+	}
+
+	return req
+}
@@ -0,0 +1,20 @@
+// internal/domain/recurring/repository.go
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for recurring schedule persistence
+type Repository interface {
+	Create(ctx context.Context, schedule *Schedule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Schedule, error)
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]Schedule, error)
+	// GetDue returns active schedules whose next run date is on or before asOf
+	GetDue(ctx context.Context, asOf time.Time) ([]Schedule, error)
+	Update(ctx context.Context, schedule *Schedule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
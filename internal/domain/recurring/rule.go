@@ -0,0 +1,153 @@
+// internal/domain/recurring/rule.go
+package recurring
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxOccurrences bounds the search for the next occurrence
+const maxOccurrences = 100000
+
+// Rule is the subset of an iCalendar RRULE we support: FREQ, INTERVAL and
+// BYMONTHDAY. Occurrences are anchored on the schedule's start date, so
+// "FREQ=WEEKLY;INTERVAL=2" repeats every other week on the start weekday and
+// "FREQ=MONTHLY;INTERVAL=3" is quarterly.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByMonthDay int // 1..31, or -1..-31 counting back from month end; 0 uses the start day
+}
+
+// ParseRule parses strings such as "FREQ=MONTHLY;BYMONTHDAY=1". An "RRULE:"
+// prefix is accepted.
+func ParseRule(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(strings.TrimSpace(value)))
+		case "INTERVAL":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			rule.Interval = n
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return Rule{}, fmt.Errorf("%w: BYMONTHDAY must be 1..31 or -1..-31", ErrInvalidRule)
+			}
+			rule.ByMonthDay = n
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+	}
+
+	switch rule.Freq {
+	case FrequencyDaily, FrequencyWeekly:
+		if rule.ByMonthDay != 0 {
+			return Rule{}, fmt.Errorf("%w: BYMONTHDAY needs FREQ=MONTHLY or FREQ=YEARLY", ErrInvalidRule)
+		}
+	case FrequencyMonthly, FrequencyYearly:
+	case "":
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return Rule{}, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, rule.Freq)
+	}
+
+	return rule, nil
+}
+
+func (r Rule) String() string {
+	s := "FREQ=" + string(r.Freq)
+	if r.Interval > 1 {
+		s += ";INTERVAL=" + strconv.Itoa(r.Interval)
+	}
+	if r.ByMonthDay != 0 {
+		s += ";BYMONTHDAY=" + strconv.Itoa(r.ByMonthDay)
+	}
+	return s
+}
+
+// Occurrence returns the nth occurrence (from 0) of a rule anchored on start
+func (r Rule) Occurrence(start time.Time, n int) time.Time {
+	start = truncateDay(start)
+	step := n * r.Interval
+
+	switch r.Freq {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, step)
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FrequencyYearly:
+		return r.onDay(start.Year()+step, start.Month(), start.Day())
+	default:
+		month := int(start.Month()) - 1 + step
+		return r.onDay(start.Year()+month/12, time.Month(month%12+1), start.Day())
+	}
+}
+
+// From returns the first occurrence on or after from. Occurrences before the
+// start date are never returned.
+func (r Rule) From(start, from time.Time) time.Time {
+	start = truncateDay(start)
+	from = truncateDay(from)
+	if from.Before(start) {
+		from = start
+	}
+
+	for n := 0; n < maxOccurrences; n++ {
+		occurrence := r.Occurrence(start, n)
+		if !occurrence.Before(from) {
+			return occurrence
+		}
+	}
+	return time.Time{}
+}
+
+// onDay builds a date in the given month, using ByMonthDay when set and
+// clamping to the month's length so the 31st falls on the 30th in April.
+func (r Rule) onDay(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if r.ByMonthDay > 0 {
+		day = r.ByMonthDay
+	} else if r.ByMonthDay < 0 {
+		day = lastDay + r.ByMonthDay + 1
+	}
+
+	if day > lastDay {
+		day = lastDay
+	}
+	if day < 1 {
+		day = 1
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
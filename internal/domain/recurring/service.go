@@ -0,0 +1,277 @@
+// internal/domain/recurring/service.go
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrScheduleNotFound = fmt.Errorf("recurring schedule not found")
+	ErrUnauthorized     = fmt.Errorf("unauthorized access")
+	ErrInvalidRule      = fmt.Errorf("invalid recurrence rule")
+	ErrInvalidSchedule  = fmt.Errorf("invalid recurring schedule")
+)
+
+// InvoiceIssuer creates and sends the generated invoices
+type InvoiceIssuer interface {
+	CreateInvoice(ctx context.Context, p organization.Principal, req invoice.CreateInvoiceRequest) (*invoice.Invoice, error)
+	SendInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, opts invoice.SendOptions) (*invoice.Invoice, *invoice.Delivery, error)
+}
+
+type Service struct {
+	repo     Repository
+	issuer   InvoiceIssuer
+	invoices invoice.Repository
+}
+
+func NewService(repo Repository, issuer InvoiceIssuer, invoices invoice.Repository) *Service {
+	return &Service{
+		repo:     repo,
+		issuer:   issuer,
+		invoices: invoices,
+	}
+}
+
+func (s *Service) CreateSchedule(ctx context.Context, p organization.Principal, req ScheduleRequest) (*Schedule, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+
+	rule, err := validateSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{
+		ID:               uuid.New(),
+		OrganizationID:   p.OrganizationID,
+		UserID:           p.UserID,
+		ClientID:         req.ClientID,
+		Name:             req.Name,
+		Rule:             rule.String(),
+		StartDate:        truncateDay(req.StartDate),
+		EndDate:          req.EndDate,
+		Mode:             req.Mode,
+		Active:           true,
+		PaymentTermsDays: req.PaymentTermsDays,
+		Template:         req.Template,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	schedule.ScheduleFrom(rule, schedule.StartDate)
+
+	if err := s.repo.Create(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("creating recurring schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (s *Service) GetSchedule(ctx context.Context, p organization.Principal, scheduleID uuid.UUID) (*Schedule, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, ErrScheduleNotFound
+	}
+
+	if !p.Owns(schedule.OrganizationID) {
+		return nil, ErrUnauthorized
+	}
+
+	return schedule, nil
+}
+
+func (s *Service) ListSchedules(ctx context.Context, p organization.Principal) ([]Schedule, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOrganizationID(ctx, p.OrganizationID)
+}
+
+// manageSchedule returns the schedule if the principal may change it
+func (s *Service) manageSchedule(ctx context.Context, p organization.Principal, scheduleID uuid.UUID) (*Schedule, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, err
+	}
+	return s.GetSchedule(ctx, p, scheduleID)
+}
+
+// UpdateSchedule replaces a schedule's rule and template. Invoices already
+// generated are left alone; the next run is recalculated from the day after
+// the last one.
+func (s *Service) UpdateSchedule(ctx context.Context, p organization.Principal, scheduleID uuid.UUID, req ScheduleRequest) (*Schedule, error) {
+	schedule, err := s.manageSchedule(ctx, p, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := validateSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.ClientID = req.ClientID
+	schedule.Name = req.Name
+	schedule.Rule = rule.String()
+	schedule.StartDate = truncateDay(req.StartDate)
+	schedule.EndDate = req.EndDate
+	schedule.Mode = req.Mode
+	schedule.PaymentTermsDays = req.PaymentTermsDays
+	schedule.Template = req.Template
+	schedule.UpdatedAt = time.Now()
+	schedule.ScheduleFrom(rule, resumeFrom(schedule, schedule.StartDate))
+
+	if err := s.repo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("updating recurring schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// SetActive pauses or resumes a schedule. Occurrences missed while paused are
+// skipped rather than generated on resume.
+func (s *Service) SetActive(ctx context.Context, p organization.Principal, scheduleID uuid.UUID, active bool) (*Schedule, error) {
+	schedule, err := s.manageSchedule(ctx, p, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if active && !schedule.Active {
+		rule, err := ParseRule(schedule.Rule)
+		if err != nil {
+			return nil, err
+		}
+		schedule.ScheduleFrom(rule, resumeFrom(schedule, time.Now()))
+	}
+
+	schedule.Active = active
+	schedule.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("updating recurring schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (s *Service) DeleteSchedule(ctx context.Context, p organization.Principal, scheduleID uuid.UUID) error {
+	if _, err := s.manageSchedule(ctx, p, scheduleID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, scheduleID)
+}
+
+// ListInvoices returns the invoices generated by a schedule
+func (s *Service) ListInvoices(ctx context.Context, p organization.Principal, scheduleID uuid.UUID) ([]invoice.Invoice, error) {
+	if _, err := s.GetSchedule(ctx, p, scheduleID); err != nil {
+		return nil, err
+	}
+
+	return s.invoices.GetByOrganizationID(ctx, p.OrganizationID, invoice.ListFilters{RecurringScheduleID: &scheduleID})
+}
+
+// GenerateDue creates the invoices for every schedule due on or before asOf,
+// catching up on occurrences missed while the worker was down. It returns the
+// number of invoices generated.
+func (s *Service) GenerateDue(ctx context.Context, asOf time.Time) (int, error) {
+	schedules, err := s.repo.GetDue(ctx, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("getting due schedules: %w", err)
+	}
+
+	generated := 0
+	for i := range schedules {
+		n, err := s.generate(ctx, &schedules[i], asOf)
+		generated += n
+		if err != nil {
+			slog.Error("failed to generate recurring invoice",
+				"schedule_id", schedules[i].ID, "error", err)
+		}
+	}
+
+	return generated, nil
+}
+
+func (s *Service) generate(ctx context.Context, schedule *Schedule, asOf time.Time) (int, error) {
+	rule, err := ParseRule(schedule.Rule)
+	if err != nil {
+		return 0, err
+	}
+
+	// Invoices are issued on behalf of whoever set up the schedule
+	p := organization.System(schedule.OrganizationID, schedule.UserID)
+
+	generated := 0
+	for schedule.IsDue(asOf) {
+		inv, err := s.issuer.CreateInvoice(ctx, p, schedule.InvoiceRequest(*schedule.NextRunDate))
+		if err != nil {
+			return generated, fmt.Errorf("creating invoice for %s: %w",
+				schedule.NextRunDate.Format("2006-01-02"), err)
+		}
+		generated++
+
+		// Advance before sending so a send failure doesn't produce a duplicate
+		schedule.Advance(rule)
+		if err := s.repo.Update(ctx, schedule); err != nil {
+			return generated, fmt.Errorf("updating recurring schedule: %w", err)
+		}
+
+		if schedule.Mode == ModeAutoSend {
+			if _, _, err := s.issuer.SendInvoice(ctx, p, inv.ID, invoice.SendOptions{}); err != nil {
+				slog.Error("failed to send recurring invoice",
+					"schedule_id", schedule.ID, "invoice_id", inv.ID, "error", err)
+			}
+		}
+	}
+
+	return generated, nil
+}
+
+// resumeFrom is the earliest date the next occurrence may fall on
+func resumeFrom(schedule *Schedule, from time.Time) time.Time {
+	if schedule.LastIssueDate != nil {
+		after := schedule.LastIssueDate.AddDate(0, 0, 1)
+		if after.After(from) {
+			return after
+		}
+	}
+	return from
+}
+
+func validateSchedule(req ScheduleRequest) (Rule, error) {
+	rule, err := ParseRule(req.Rule)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if !req.Mode.IsValid() {
+		return Rule{}, fmt.Errorf("%w: mode must be draft or auto_send", ErrInvalidSchedule)
+	}
+	if req.EndDate != nil && req.EndDate.Before(req.StartDate) {
+		return Rule{}, fmt.Errorf("%w: end date is before start date", ErrInvalidSchedule)
+	}
+	if req.PaymentTermsDays < 0 {
+		return Rule{}, fmt.Errorf("%w: payment terms must not be negative", ErrInvalidSchedule)
+	}
+	if len(req.Template.Items) == 0 {
+		return Rule{}, fmt.Errorf("%w: template needs at least one item", ErrInvalidSchedule)
+	}
+	if !currency.IsValid(req.Template.Currency) {
+		return Rule{}, fmt.Errorf("%w: invalid currency", ErrInvalidSchedule)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,19 @@
+// internal/domain/recurring/types.go
+package recurring
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleRequest struct {
+	ClientID         uuid.UUID
+	Name             string
+	Rule             string
+	StartDate        time.Time
+	EndDate          *time.Time
+	Mode             Mode
+	PaymentTermsDays int
+	Template         Template
+}
@@ -0,0 +1,25 @@
+// internal/domain/report/entity.go
+package report
+
+import "time"
+
+// RevenueReport totals invoiced and collected revenue in the organization's base currency
+type RevenueReport struct {
+	BaseCurrency       string
+	From               time.Time
+	To                 time.Time
+	Invoiced           float64
+	Collected          float64
+	RealizedFXGainLoss float64
+	ByCurrency         []CurrencyRevenue
+}
+
+// CurrencyRevenue is one invoice currency's share of a revenue report
+type CurrencyRevenue struct {
+	Currency      string  `db:"currency"`
+	Invoiced      float64 `db:"invoiced"`
+	InvoicedBase  float64 `db:"invoiced_base"`
+	Collected     float64 `db:"collected"`
+	CollectedBase float64 `db:"collected_base"`
+	FXGainLoss    float64 `db:"fx_gain_loss"`
+}
@@ -0,0 +1,19 @@
+// internal/domain/report/repository.go
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// RevenueByCurrency aggregates issued invoices by issue date and payments
+	// by payment date, for invoices reported in the given base currency
+	RevenueByCurrency(ctx context.Context, organizationID uuid.UUID, baseCurrency string, from, to time.Time) ([]CurrencyRevenue, error)
+
+	// TimeByGroup sums the organization's time entries for each group, and
+	// each day or week of the interval, ordered by group name then period
+	TimeByGroup(ctx context.Context, organizationID uuid.UUID, filters TimeFilters) ([]TimeRow, error)
+}
@@ -0,0 +1,59 @@
+// internal/domain/report/service.go
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+type Service struct {
+	repo          Repository
+	organizations organization.Repository
+}
+
+func NewService(repo Repository, organizations organization.Repository) *Service {
+	return &Service{
+		repo:          repo,
+		organizations: organizations,
+	}
+}
+
+// Revenue reports revenue for the period in the organization's current base
+// currency. Invoices issued under an earlier base currency are left out,
+// since their stored rates convert to a different currency.
+func (s *Service) Revenue(ctx context.Context, p organization.Principal, from, to time.Time) (*RevenueReport, error) {
+	if err := p.Require(organization.ActionViewBilling); err != nil {
+		return nil, err
+	}
+
+	org, err := s.organizations.GetByID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting organization: %w", err)
+	}
+
+	rows, err := s.repo.RevenueByCurrency(ctx, p.OrganizationID, org.BaseCurrency, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("getting revenue: %w", err)
+	}
+
+	report := &RevenueReport{
+		BaseCurrency: org.BaseCurrency,
+		From:         from,
+		To:           to,
+		ByCurrency:   rows,
+	}
+	for _, row := range rows {
+		report.Invoiced += row.InvoicedBase
+		report.Collected += row.CollectedBase
+		report.RealizedFXGainLoss += row.FXGainLoss
+	}
+	report.Invoiced = currency.Round(report.Invoiced, org.BaseCurrency)
+	report.Collected = currency.Round(report.Collected, org.BaseCurrency)
+	report.RealizedFXGainLoss = currency.Round(report.RealizedFXGainLoss, org.BaseCurrency)
+
+	return report, nil
+}
@@ -0,0 +1,163 @@
+// internal/domain/report/time.go
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+var (
+	ErrInvalidGrouping = fmt.Errorf("group_by must be member, project or client")
+	ErrInvalidInterval = fmt.Errorf("interval must be day or week")
+)
+
+// Grouping is what a time report totals hours for
+type Grouping string
+
+const (
+	GroupByMember  Grouping = "member"
+	GroupByProject Grouping = "project"
+	GroupByClient  Grouping = "client"
+)
+
+func (g Grouping) IsValid() bool {
+	return g == GroupByMember || g == GroupByProject || g == GroupByClient
+}
+
+// Interval breaks a time report down over its period. Without one each
+// group has a single row for the whole period.
+type Interval string
+
+const (
+	IntervalNone Interval = ""
+	IntervalDay  Interval = "day"
+	IntervalWeek Interval = "week" // Monday to Sunday
+)
+
+func (i Interval) IsValid() bool {
+	return i == IntervalNone || i == IntervalDay || i == IntervalWeek
+}
+
+// TimeFilters selects the time a report covers and how it is broken down
+type TimeFilters struct {
+	From      time.Time
+	To        time.Time
+	GroupBy   Grouping
+	Interval  Interval
+	UserID    *uuid.UUID
+	ProjectID *uuid.UUID
+	ClientID  *uuid.UUID
+}
+
+// TimeRow is one group's time, for one day or week when the report has an
+// interval. BillableAmount prices billable time at each entry's resolved
+// rate; time without a rate counts as zero.
+type TimeRow struct {
+	Period         *time.Time `db:"period"`   // First day of the day or week; nil without an interval
+	GroupID        *uuid.UUID `db:"group_id"` // Nil for time without a project or client
+	GroupName      string     `db:"group_name"`
+	Hours          float64    `db:"hours"`
+	BillableHours  float64    `db:"billable_hours"`
+	BillableAmount float64    `db:"billable_amount"`
+	Utilization    float64    // Billable hours as a fraction of all hours
+}
+
+// TimeReport totals tracked time by member, project or client in the
+// organization's base currency
+type TimeReport struct {
+	BaseCurrency string
+	From         time.Time
+	To           time.Time
+	GroupBy      Grouping
+	Interval     Interval
+	Rows         []TimeRow // By group, then period
+	Groups       []TimeRow // Each group over the whole period
+	Totals       TimeRow
+}
+
+// TeamTime reports the organization's tracked time. Members who can't view
+// the team's time only get a report of their own.
+func (s *Service) TeamTime(ctx context.Context, p organization.Principal, filters TimeFilters) (*TimeReport, error) {
+	if !p.Can(organization.ActionViewTeamTime) {
+		if err := p.Require(organization.ActionTrackTime); err != nil {
+			return nil, err
+		}
+		if filters.UserID != nil && *filters.UserID != p.UserID {
+			return nil, organization.ErrForbidden
+		}
+		filters.UserID = &p.UserID
+	}
+	if !filters.GroupBy.IsValid() {
+		return nil, ErrInvalidGrouping
+	}
+	if !filters.Interval.IsValid() {
+		return nil, ErrInvalidInterval
+	}
+
+	org, err := s.organizations.GetByID(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting organization: %w", err)
+	}
+
+	rows, err := s.repo.TimeByGroup(ctx, p.OrganizationID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("getting time: %w", err)
+	}
+
+	report := &TimeReport{
+		BaseCurrency: org.BaseCurrency,
+		From:         filters.From,
+		To:           filters.To,
+		GroupBy:      filters.GroupBy,
+		Interval:     filters.Interval,
+		Rows:         rows,
+	}
+
+	// Rows come ordered by group, so each group's rows are consecutive
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if n := len(report.Groups); n == 0 || !sameGroup(report.Groups[n-1], *row) {
+			report.Groups = append(report.Groups, TimeRow{GroupID: row.GroupID, GroupName: row.GroupName})
+		}
+		group := &report.Groups[len(report.Groups)-1]
+		group.add(*row)
+		report.Totals.add(*row)
+		row.finish(org.BaseCurrency)
+	}
+	for i := range report.Groups {
+		report.Groups[i].finish(org.BaseCurrency)
+	}
+	report.Totals.GroupName = "Total"
+	report.Totals.finish(org.BaseCurrency)
+
+	return report, nil
+}
+
+func sameGroup(a, b TimeRow) bool {
+	if a.GroupID == nil || b.GroupID == nil {
+		return a.GroupID == nil && b.GroupID == nil
+	}
+	return *a.GroupID == *b.GroupID
+}
+
+func (r *TimeRow) add(other TimeRow) {
+	r.Hours += other.Hours
+	r.BillableHours += other.BillableHours
+	r.BillableAmount += other.BillableAmount
+}
+
+// finish rounds the sums and works out utilization
+func (r *TimeRow) finish(baseCurrency string) {
+	if r.Hours > 0 {
+		r.Utilization = currency.RoundTo(r.BillableHours/r.Hours, 4)
+	}
+	r.Hours = currency.RoundTo(r.Hours, 2)
+	r.BillableHours = currency.RoundTo(r.BillableHours, 2)
+	r.BillableAmount = currency.Round(r.BillableAmount, baseCurrency)
+}
@@ -0,0 +1,55 @@
+// internal/domain/session/entity.go
+package session
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login on one device. Access tokens carry its ID, and it is
+// kept alive by refreshing until it expires, reaches its maximum lifetime or
+// is revoked.
+type Session struct {
+	ID             uuid.UUID  `db:"id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	OrganizationID uuid.UUID  `db:"organization_id"` // Refreshed access tokens are issued for it
+	UserAgent      string     `db:"user_agent"`
+	IPAddress      string     `db:"ip_address"`
+	CreatedAt      time.Time  `db:"created_at"`
+	LastUsedAt     time.Time  `db:"last_used_at"`
+	ExpiresAt      time.Time  `db:"expires_at"`     // Pushed back on every refresh, up to MaxExpiresAt
+	MaxExpiresAt   time.Time  `db:"max_expires_at"` // When the session ends however often it is refreshed
+	RevokedAt      *time.Time `db:"revoked_at"`
+	MFAVerified    bool       `db:"mfa_verified"` // Started, or since confirmed, with a second factor
+}
+
+// IsActive reports whether the session can still be used at the time
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// expiry is when the session ends if it goes unused for lifetime from now,
+// never past its maximum lifetime
+func (s *Session) expiry(now time.Time, lifetime time.Duration) time.Time {
+	if expiresAt := now.Add(lifetime); expiresAt.Before(s.MaxExpiresAt) {
+		return expiresAt
+	}
+	return s.MaxExpiresAt
+}
+
+// RefreshToken is one of a session's refresh tokens. Each is used once to
+// get the next; only its hash is stored.
+type RefreshToken struct {
+	ID        uuid.UUID  `db:"id"`
+	SessionID uuid.UUID  `db:"session_id"`
+	TokenHash string     `db:"token_hash"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// Client describes the device a session was started or refreshed from
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
@@ -0,0 +1,28 @@
+// internal/domain/session/repository.go
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Create saves the session with its first refresh token
+	Create(ctx context.Context, s *Session, token *RefreshToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Rotate marks the used token as used and saves the next one and the
+	// session in one transaction. It returns ErrRefreshTokenReused when the
+	// token had already been used.
+	Rotate(ctx context.Context, s *Session, usedID uuid.UUID, next *RefreshToken) error
+	Update(ctx context.Context, s *Session) error
+	// GetActive returns the user's sessions that are neither expired nor
+	// revoked, most recently used first
+	GetActive(ctx context.Context, userID uuid.UUID, now time.Time) ([]Session, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// RevokeAll revokes the user's sessions other than except, which may
+	// be uuid.Nil to revoke them all
+	RevokeAll(ctx context.Context, userID, except uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,216 @@
+// internal/domain/session/service.go
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = fmt.Errorf("invalid refresh token")
+	ErrRefreshTokenReused  = fmt.Errorf("refresh token was already used; the session has been revoked")
+	ErrSessionEnded        = fmt.Errorf("session has expired or been revoked")
+	ErrSessionNotFound     = fmt.Errorf("session not found")
+)
+
+type Service struct {
+	repo        Repository
+	lifetime    time.Duration
+	maxLifetime time.Duration
+}
+
+// NewService creates a session service. Sessions end when they go unused
+// for lifetime, and maxLifetime after they started however often they are
+// refreshed.
+func NewService(repo Repository, lifetime, maxLifetime time.Duration) *Service {
+	return &Service{
+		repo:        repo,
+		lifetime:    lifetime,
+		maxLifetime: maxLifetime,
+	}
+}
+
+// Start opens a session for the user working in the organization, returning
+// its first refresh token. mfaVerified records that the user logged in with
+// a second factor.
+func (s *Service) Start(ctx context.Context, userID, organizationID uuid.UUID, client Client, mfaVerified bool) (*Session, string, error) {
+	now := time.Now()
+	sess := &Session{
+		ID:             uuid.New(),
+		UserID:         userID,
+		OrganizationID: organizationID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		CreatedAt:      now,
+		LastUsedAt:     now,
+		MaxExpiresAt:   now.Add(s.maxLifetime),
+		MFAVerified:    mfaVerified,
+	}
+	sess.ExpiresAt = sess.expiry(now, s.lifetime)
+
+	token, next, err := newRefreshToken(sess.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.Create(ctx, sess, next); err != nil {
+		return nil, "", fmt.Errorf("creating session: %w", err)
+	}
+
+	return sess, token, nil
+}
+
+// Refresh uses up the refresh token and returns its session with the next
+// one. A token that was already used has leaked, so the session is revoked
+// for whoever holds it.
+func (s *Service) Refresh(ctx context.Context, token string, client Client) (*Session, string, error) {
+	used, err := s.repo.GetRefreshToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	sess, err := s.repo.GetByID(ctx, used.SessionID)
+	if err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if used.UsedAt != nil {
+		return nil, "", s.revokeReused(ctx, sess, now)
+	}
+	if !sess.IsActive(now) {
+		return nil, "", ErrSessionEnded
+	}
+
+	token, next, err := newRefreshToken(sess.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	sess.UserAgent = client.UserAgent
+	sess.IPAddress = client.IPAddress
+	sess.LastUsedAt = now
+	sess.ExpiresAt = sess.expiry(now, s.lifetime)
+
+	err = s.repo.Rotate(ctx, sess, used.ID, next)
+	if errors.Is(err, ErrRefreshTokenReused) {
+		return nil, "", s.revokeReused(ctx, sess, now)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("rotating refresh token: %w", err)
+	}
+
+	return sess, token, nil
+}
+
+func (s *Service) revokeReused(ctx context.Context, sess *Session, now time.Time) error {
+	if sess.RevokedAt == nil {
+		if err := s.repo.Revoke(ctx, sess.ID, now); err != nil {
+			return fmt.Errorf("revoking session: %w", err)
+		}
+	}
+	return ErrRefreshTokenReused
+}
+
+// Check returns the session, or ErrSessionEnded unless it is still active
+func (s *Service) Check(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
+	sess, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil || !sess.IsActive(time.Now()) {
+		return nil, ErrSessionEnded
+	}
+	return sess, nil
+}
+
+// SwitchOrganization makes refreshes of the session issue access tokens for
+// the organization
+func (s *Service) SwitchOrganization(ctx context.Context, sessionID, organizationID uuid.UUID) error {
+	sess, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	sess.OrganizationID = organizationID
+	if err := s.repo.Update(ctx, sess); err != nil {
+		return fmt.Errorf("updating session: %w", err)
+	}
+	return nil
+}
+
+// MarkMFAVerified records that the user gave a second factor in the
+// session, as when they set one up from it
+func (s *Service) MarkMFAVerified(ctx context.Context, sessionID uuid.UUID) error {
+	sess, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	sess.MFAVerified = true
+	if err := s.repo.Update(ctx, sess); err != nil {
+		return fmt.Errorf("updating session: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's active sessions
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	return s.repo.GetActive(ctx, userID, time.Now())
+}
+
+// Revoke ends one of the user's sessions
+func (s *Service) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	sess, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil || sess.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if sess.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.repo.Revoke(ctx, sessionID, time.Now()); err != nil {
+		return fmt.Errorf("revoking session: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll ends every session of the user's, logging them out everywhere
+func (s *Service) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.RevokeAll(ctx, userID, uuid.Nil, time.Now()); err != nil {
+		return fmt.Errorf("revoking sessions: %w", err)
+	}
+	return nil
+}
+
+// RevokeOthers ends every session of the user's except the one given
+func (s *Service) RevokeOthers(ctx context.Context, userID, keep uuid.UUID) error {
+	if err := s.repo.RevokeAll(ctx, userID, keep, time.Now()); err != nil {
+		return fmt.Errorf("revoking sessions: %w", err)
+	}
+	return nil
+}
+
+// newRefreshToken returns a random token for the session and its record
+func newRefreshToken(sessionID uuid.UUID, now time.Time) (string, *RefreshToken, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("generating refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, &RefreshToken{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		TokenHash: hashToken(token),
+		CreatedAt: now,
+	}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+// internal/domain/sso/entity.go
+package sso
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links a user to their account at an identity provider, which
+// names it by its subject
+type Identity struct {
+	ID          uuid.UUID `db:"id"`
+	UserID      uuid.UUID `db:"user_id"`
+	Provider    string    `db:"provider"`
+	Subject     string    `db:"subject"`
+	Email       string    `db:"email"` // As the provider last reported it
+	CreatedAt   time.Time `db:"created_at"`
+	LastLoginAt time.Time `db:"last_login_at"`
+}
+
+// Login is an authorization request sent to a provider, waiting for the
+// user to come back with its code. Only the state's hash is stored; the
+// nonce and PKCE verifier never leave the server until the code is redeemed.
+type Login struct {
+	StateHash    string    `db:"state_hash"`
+	Provider     string    `db:"provider"`
+	Nonce        string    `db:"nonce"`
+	CodeVerifier string    `db:"code_verifier"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Claims are what a provider's verified ID token says about the user
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
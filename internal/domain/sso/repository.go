@@ -0,0 +1,25 @@
+// internal/domain/sso/repository.go
+package sso
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// CreateLogin saves the login, clearing out those that expired unused
+	CreateLogin(ctx context.Context, login *Login) error
+	// TakeLogin removes and returns the login with the state hash, so each
+	// works once. It returns ErrInvalidState when there is none.
+	TakeLogin(ctx context.Context, stateHash string) (*Login, error)
+
+	// GetIdentity returns ErrIdentityNotFound when the provider's subject
+	// isn't linked to a user
+	GetIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	// TouchIdentity records a login with the identity and the email the
+	// provider reported
+	TouchIdentity(ctx context.Context, id uuid.UUID, email string, at time.Time) error
+}
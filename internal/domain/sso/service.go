@@ -0,0 +1,216 @@
+// internal/domain/sso/service.go
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/user"
+)
+
+var (
+	ErrUnknownProvider    = fmt.Errorf("unknown identity provider")
+	ErrInvalidState       = fmt.Errorf("login expired or was already completed; start again")
+	ErrIdentityNotFound   = fmt.Errorf("identity not found")
+	ErrEmailNotVerified   = fmt.Errorf("the identity provider hasn't verified your email address")
+	ErrDomainNotAllowed   = fmt.Errorf("your email domain can't log in with this provider")
+	ErrAccountNotVerified = fmt.Errorf("verify your email address before linking it to an identity provider")
+)
+
+// LoginLifetime is how long users have to log in at the provider and come back
+const LoginLifetime = 10 * time.Minute
+
+// Provider is an OpenID Connect identity provider
+type Provider interface {
+	Name() string
+	// AuthorizationURL returns where to send the user to log in, asking for
+	// a code bound to the PKCE challenge and an ID token with the nonce
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the code with its PKCE verifier and returns the
+	// claims of the ID token, once its signature, issuer, audience, expiry
+	// and nonce check out
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error)
+}
+
+type Service struct {
+	repo      Repository
+	users     *user.Service
+	providers map[string]Provider
+}
+
+func NewService(repo Repository, users *user.Service, providers ...Provider) *Service {
+	s := &Service{
+		repo:      repo,
+		users:     users,
+		providers: make(map[string]Provider, len(providers)),
+	}
+	for _, p := range providers {
+		s.providers[p.Name()] = p
+	}
+	return s
+}
+
+// Providers returns the names of the providers users can log in with
+func (s *Service) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start begins logging in with the provider, returning the URL to send the
+// user to and the state, which the caller binds to the user's browser so
+// only that browser can complete the login
+func (s *Service) Start(ctx context.Context, providerName string) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	login := &Login{
+		StateHash:    hashState(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(LoginLifetime),
+		CreatedAt:    now,
+	}
+	if err := s.repo.CreateLogin(ctx, login); err != nil {
+		return "", "", fmt.Errorf("saving login: %w", err)
+	}
+
+	authorizationURL, err := provider.AuthorizationURL(ctx, state, nonce, codeChallenge(verifier))
+	if err != nil {
+		return "", "", err
+	}
+	return authorizationURL, state, nil
+}
+
+// Complete finishes logging in with the code and state the provider sent
+// the user back with, returning the user the identity belongs to. The state
+// must match the one bound to the browser at Start, so a login started by
+// someone else can't be completed in the user's browser. Identities seen
+// for the first time are linked to the user with the same verified email
+// address, or to a new user when there is none.
+func (s *Service) Complete(ctx context.Context, providerName, code, state, boundState string) (*user.User, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	if boundState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(boundState)) != 1 {
+		return nil, ErrInvalidState
+	}
+
+	login, err := s.repo.TakeLogin(ctx, hashState(state))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if login.Provider != providerName || !now.Before(login.ExpiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	claims, err := provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.repo.GetIdentity(ctx, providerName, claims.Subject)
+	if err == nil {
+		if err := s.repo.TouchIdentity(ctx, identity.ID, claims.Email, now); err != nil {
+			return nil, fmt.Errorf("updating identity: %w", err)
+		}
+		return s.users.GetUser(ctx, identity.UserID)
+	}
+	if !errors.Is(err, ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	u, err := s.userFor(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	identity = &Identity{
+		ID:          uuid.New(),
+		UserID:      u.ID,
+		Provider:    providerName,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+	if err := s.repo.CreateIdentity(ctx, identity); err != nil {
+		return nil, fmt.Errorf("linking identity: %w", err)
+	}
+
+	return u, nil
+}
+
+// userFor returns the user a new identity belongs to. Only addresses both
+// sides have verified are matched, so neither a provider account nor a
+// registration can claim someone else's address.
+func (s *Service) userFor(ctx context.Context, claims *Claims) (*user.User, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	existing, _ := s.users.GetUserByEmail(ctx, claims.Email)
+	if existing != nil {
+		if !existing.IsVerified() {
+			return nil, ErrAccountNotVerified
+		}
+		return existing, nil
+	}
+
+	u, err := s.users.RegisterExternal(ctx, claims.Email, claims.Name, true)
+	if err != nil {
+		return nil, fmt.Errorf("creating user: %w", err)
+	}
+	return u, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating login secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge is the S256 PKCE challenge for the verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func hashState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,249 @@
+// internal/domain/timeentry/bulk.go
+package timeentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+)
+
+// MaxBulkSize caps how many entries one bulk operation can touch
+const MaxBulkSize = 1000
+
+// Selection picks the entries for a bulk operation: the listed IDs, or
+// every entry matching Filter when there are none
+type Selection struct {
+	IDs    []uuid.UUID
+	Filter *ListFilters
+}
+
+// BulkResult is the outcome for one entry; Error is nil when it succeeded
+type BulkResult struct {
+	ID    uuid.UUID
+	Error error
+}
+
+// BulkSetBillable marks the selected entries billable or non-billable
+func (s *Service) BulkSetBillable(ctx context.Context, p organization.Principal, sel Selection, billable bool) ([]BulkResult, error) {
+	return s.bulkUpdate(ctx, p, sel, func(entry *TimeEntry) error {
+		entry.IsBillable = billable
+		return nil
+	})
+}
+
+// BulkSetJiraIssue sets or, with a nil key, clears the selected entries'
+// Jira issue
+func (s *Service) BulkSetJiraIssue(ctx context.Context, p organization.Principal, sel Selection, issueKey *string) ([]BulkResult, error) {
+	return s.bulkUpdate(ctx, p, sel, func(entry *TimeEntry) error {
+		entry.JiraIssueKey = issueKey
+		return nil
+	})
+}
+
+// BulkSetProject moves the selected entries to a project and task; nil IDs
+// take them off their project
+func (s *Service) BulkSetProject(ctx context.Context, p organization.Principal, sel Selection, projectID, taskID *uuid.UUID) ([]BulkResult, error) {
+	projectID, taskID, err := s.assign(ctx, p.OrganizationID, projectID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.bulkUpdate(ctx, p, sel, func(entry *TimeEntry) error {
+		entry.ProjectID = projectID
+		entry.TaskID = taskID
+		return nil
+	})
+}
+
+// BulkDelete deletes the selected entries in one transaction
+func (s *Service) BulkDelete(ctx context.Context, p organization.Principal, sel Selection) ([]BulkResult, error) {
+	entries, results, err := s.selectEntries(ctx, p, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]int, len(results))
+	for i, result := range results {
+		byID[result.ID] = i
+	}
+
+	guard := s.weekGuard(p.OrganizationID)
+	invoices := s.invoiceGuard(p)
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		if err := guard.check(ctx, entry.UserID, entry.Date); err != nil {
+			results[byID[entry.ID]].Error = err
+			continue
+		}
+		if err := invoices.check(ctx, &entry); err != nil {
+			results[byID[entry.ID]].Error = err
+			continue
+		}
+		invoices.changed(&entry)
+		ids = append(ids, entry.ID)
+	}
+	if err := s.repo.DeleteMany(ctx, ids); err != nil {
+		return nil, fmt.Errorf("deleting time entries: %w", err)
+	}
+
+	if err := invoices.rebill(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// BulkPushToJira logs each selected entry as work on its Jira issue. Work
+// logged in Jira can't be rolled back, so unlike the other bulk operations
+// each entry is saved as soon as it has been pushed.
+func (s *Service) BulkPushToJira(ctx context.Context, p organization.Principal, sel Selection) ([]BulkResult, error) {
+	if s.jiraClient == nil {
+		return nil, ErrJiraNotConfigured
+	}
+
+	entries, results, err := s.selectEntries(ctx, p, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]int, len(results))
+	for i, result := range results {
+		byID[result.ID] = i
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		result := &results[byID[entry.ID]]
+
+		switch {
+		case entry.JiraIssueKey == nil:
+			result.Error = ErrNoJiraIssue
+		case entry.JiraWorklogID != nil:
+			result.Error = ErrAlreadyInJira
+		default:
+			if err := s.logWork(ctx, entry, *entry.JiraIssueKey); err != nil {
+				result.Error = err
+			} else if err := s.repo.Update(ctx, entry); err != nil {
+				result.Error = fmt.Errorf("updating time entry: %w", err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// bulkUpdate applies the change to each selected entry and saves them all
+// in one transaction. Entries in locked weeks, on sent invoices or that the
+// change rejects are left out; draft invoices with changed time are rebilled.
+func (s *Service) bulkUpdate(ctx context.Context, p organization.Principal, sel Selection, change func(*TimeEntry) error) ([]BulkResult, error) {
+	entries, results, err := s.selectEntries(ctx, p, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]int, len(results))
+	for i, result := range results {
+		byID[result.ID] = i
+	}
+
+	guard := s.weekGuard(p.OrganizationID)
+	invoices := s.invoiceGuard(p)
+	now := time.Now()
+	changed := make([]TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if err := guard.check(ctx, entry.UserID, entry.Date); err != nil {
+			results[byID[entry.ID]].Error = err
+			continue
+		}
+		if err := invoices.check(ctx, &entry); err != nil {
+			results[byID[entry.ID]].Error = err
+			continue
+		}
+		if err := change(&entry); err != nil {
+			results[byID[entry.ID]].Error = err
+			continue
+		}
+		entry.UpdatedAt = now
+		invoices.changed(&entry)
+		changed = append(changed, entry)
+	}
+
+	if err := s.repo.UpdateMany(ctx, changed); err != nil {
+		return nil, fmt.Errorf("updating time entries: %w", err)
+	}
+
+	if err := invoices.rebill(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// selectEntries loads the selected entries the principal may change.
+// Entries that don't exist, or that the principal can't see or change, get
+// a failed result and are left out.
+func (s *Service) selectEntries(ctx context.Context, p organization.Principal, sel Selection) ([]TimeEntry, []BulkResult, error) {
+	var entries []TimeEntry
+	var results []BulkResult
+
+	switch {
+	case len(sel.IDs) > 0:
+		if len(sel.IDs) > MaxBulkSize {
+			return nil, nil, ErrTooManyEntries
+		}
+
+		seen := make(map[uuid.UUID]bool, len(sel.IDs))
+		for _, id := range sel.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			entry, err := s.repo.GetByID(ctx, id)
+			if err != nil || !canView(p, entry) {
+				results = append(results, BulkResult{ID: id, Error: ErrTimeEntryNotFound})
+				continue
+			}
+			if err := canChange(p, entry); err != nil {
+				results = append(results, BulkResult{ID: id, Error: err})
+				continue
+			}
+			entries = append(entries, *entry)
+			results = append(results, BulkResult{ID: id})
+		}
+
+	case sel.Filter != nil:
+		filters := *sel.Filter
+		if err := scope(p, &filters); err != nil {
+			return nil, nil, err
+		}
+		filters.After = nil
+		filters.Sort = SortByDate
+		filters.Limit = MaxBulkSize + 1
+
+		listed, err := s.repo.List(ctx, p.OrganizationID, filters)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing time entries: %w", err)
+		}
+		if len(listed) > MaxBulkSize {
+			return nil, nil, ErrTooManyEntries
+		}
+		for _, entry := range listed {
+			if err := canChange(p, &entry); err != nil {
+				results = append(results, BulkResult{ID: entry.ID, Error: err})
+				continue
+			}
+			entries = append(entries, entry)
+			results = append(results, BulkResult{ID: entry.ID})
+		}
+
+	default:
+		return nil, nil, ErrEmptySelection
+	}
+
+	return entries, results, nil
+}
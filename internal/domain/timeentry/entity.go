@@ -8,18 +8,26 @@ import (
 )
 
 type TimeEntry struct {
-	ID            uuid.UUID  `db:"id"`
-	UserID        uuid.UUID  `db:"user_id"`
-	InvoiceID     *uuid.UUID `db:"invoice_id"`
-	Description   string     `db:"description"`
-	Hours         float64    `db:"hours"`
-	HourlyRate    *float64   `db:"hourly_rate"`
-	Date          time.Time  `db:"date"`
-	JiraIssueKey  *string    `db:"jira_issue_key"`
-	JiraWorklogID *string    `db:"jira_worklog_id"`
-	JiraSyncedAt  *time.Time `db:"jira_synced_at"`
-	IsBillable    bool       `db:"is_billable"`
-	IsInvoiced    bool       `db:"is_invoiced"`
-	CreatedAt     time.Time  `db:"created_at"`
-	UpdatedAt     time.Time  `db:"updated_at"`
+	ID             uuid.UUID  `db:"id"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	InvoiceID      *uuid.UUID `db:"invoice_id"`
+	ProjectID      *uuid.UUID `db:"project_id"`
+	TaskID         *uuid.UUID `db:"task_id"`
+	ExternalID     *string    `db:"external_id"`  // Where an imported entry came from, such as toggl:<row hash>
+	TimesheetID    *uuid.UUID `db:"timesheet_id"` // The timesheet it was last submitted on
+	Description    string     `db:"description"`
+	Hours          float64    `db:"hours"`
+	HourlyRate     *float64   `db:"hourly_rate"` // Overrides the resolved rate; once invoiced, the rate billed
+	RateSource     *string    `db:"rate_source"` // Where the billed rate came from, set when invoiced
+	Date           time.Time  `db:"date"`
+	StartedAt      *time.Time `db:"started_at"` // Set on entries recorded with a timer
+	EndedAt        *time.Time `db:"ended_at"`
+	JiraIssueKey   *string    `db:"jira_issue_key"`
+	JiraWorklogID  *string    `db:"jira_worklog_id"`
+	JiraSyncedAt   *time.Time `db:"jira_synced_at"`
+	IsBillable     bool       `db:"is_billable"`
+	IsInvoiced     bool       `db:"is_invoiced"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
 }
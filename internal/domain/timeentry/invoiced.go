@@ -0,0 +1,225 @@
+// internal/domain/timeentry/invoiced.go
+package timeentry
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+// InvoicedError is returned for changes to time billed on an invoice that
+// has been sent. Reverting the invoice to draft unlocks it.
+type InvoicedError struct {
+	EntryID       uuid.UUID
+	InvoiceID     uuid.UUID
+	InvoiceNumber string
+	Status        invoice.Status
+}
+
+func (e *InvoicedError) Error() string {
+	return fmt.Sprintf("time entry is billed on %s invoice %s; revert the invoice to draft to change it",
+		e.Status, e.InvoiceNumber)
+}
+
+// Is makes an InvoicedError match ErrEntryInvoiced
+func (e *InvoicedError) Is(target error) bool {
+	return target == ErrEntryInvoiced
+}
+
+// invoiceGuard checks that entries aren't billed on sent invoices and keeps
+// track of the drafts whose time changed, so their lines can be rebuilt.
+// Members who can't manage billing may still change their time on drafts,
+// so invoices are read on the organization's behalf.
+type invoiceGuard struct {
+	invoicer Invoicer
+	issuer   organization.Principal
+	invoices map[uuid.UUID]*invoice.Invoice
+	drafts   []uuid.UUID
+}
+
+func (s *Service) invoiceGuard(p organization.Principal) *invoiceGuard {
+	return &invoiceGuard{
+		invoicer: s.invoicer,
+		issuer:   organization.System(p.OrganizationID, p.UserID),
+		invoices: make(map[uuid.UUID]*invoice.Invoice),
+	}
+}
+
+// check returns an *InvoicedError if the entry is billed on an invoice that
+// isn't a draft
+func (g *invoiceGuard) check(ctx context.Context, entry *TimeEntry) error {
+	if entry.InvoiceID == nil {
+		return nil
+	}
+
+	inv, ok := g.invoices[*entry.InvoiceID]
+	if !ok {
+		var err error
+		if inv, err = g.invoicer.GetInvoice(ctx, g.issuer, *entry.InvoiceID); err != nil {
+			return fmt.Errorf("getting invoice: %w", err)
+		}
+		g.invoices[inv.ID] = inv
+	}
+
+	if inv.Status != invoice.StatusDraft {
+		return &InvoicedError{EntryID: entry.ID, InvoiceID: inv.ID, InvoiceNumber: inv.InvoiceNumber, Status: inv.Status}
+	}
+	return nil
+}
+
+// changed notes that the entry was changed, so the draft it's billed on, if
+// any, needs rebilling
+func (g *invoiceGuard) changed(entry *TimeEntry) {
+	if entry.InvoiceID == nil {
+		return
+	}
+	for _, id := range g.drafts {
+		if id == *entry.InvoiceID {
+			return
+		}
+	}
+	g.drafts = append(g.drafts, *entry.InvoiceID)
+}
+
+// rebill rebuilds the lines of the drafts whose time changed
+func (g *invoiceGuard) rebill(ctx context.Context, s *Service) error {
+	for _, id := range g.drafts {
+		if err := s.rebill(ctx, g.invoices[id]); err != nil {
+			return fmt.Errorf("rebilling invoice %s: %w", g.invoices[id].InvoiceNumber, err)
+		}
+	}
+	return nil
+}
+
+// rebill rebuilds a draft invoice's lines from the time billed on it.
+// Entries that no longer belong on it, because they aren't billable or have
+// moved off the client's projects, are released to be billed elsewhere.
+func (s *Service) rebill(ctx context.Context, inv *invoice.Invoice) error {
+	entries, err := s.repo.GetByInvoiceID(ctx, inv.ID)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*project.Project)
+	billed := make([]TimeEntry, 0, len(entries))
+	var released []uuid.UUID
+	for _, entry := range entries {
+		if entry.IsBillable && entry.ProjectID != nil {
+			p, ok := byID[*entry.ProjectID]
+			if !ok {
+				if p, err = s.projects.GetByID(ctx, *entry.ProjectID); err != nil {
+					return fmt.Errorf("getting project: %w", err)
+				}
+				byID[p.ID] = p
+			}
+			if p.ClientID == inv.ClientID {
+				billed = append(billed, entry)
+				continue
+			}
+		}
+		released = append(released, entry.ID)
+	}
+
+	items, err := s.billLines(ctx, inv.OrganizationID, billed, byID)
+	if err != nil {
+		return err
+	}
+
+	issuer := organization.System(inv.OrganizationID, inv.UserID)
+	if _, err := s.invoicer.ReplaceItems(ctx, issuer, inv.ID, items); err != nil {
+		return err
+	}
+
+	if len(released) > 0 {
+		if err := s.repo.ReleaseInvoiced(ctx, released); err != nil {
+			return fmt.Errorf("releasing time: %w", err)
+		}
+	}
+	if err := s.repo.MarkInvoiced(ctx, billed, inv.ID); err != nil {
+		return fmt.Errorf("marking time invoiced: %w", err)
+	}
+
+	return nil
+}
+
+// billLines resolves the rate of each entry, storing it on the entry, and
+// totals the entries into a line for each project, task and rate. byID
+// holds the entries' projects.
+func (s *Service) billLines(ctx context.Context, organizationID uuid.UUID, entries []TimeEntry, byID map[uuid.UUID]*project.Project) ([]invoice.CreateInvoiceItemRequest, error) {
+	type lineKey struct {
+		projectID uuid.UUID
+		taskID    uuid.UUID
+		rate      float64
+	}
+	var keys []lineKey
+	lines := make(map[lineKey]*invoice.CreateInvoiceItemRequest)
+	tasks := make(map[uuid.UUID]*project.Task)
+
+	for i, entry := range entries {
+		p := byID[*entry.ProjectID]
+
+		var task *project.Task
+		if entry.TaskID != nil {
+			if task = tasks[*entry.TaskID]; task == nil {
+				var err error
+				if task, err = s.projects.GetTask(ctx, *entry.TaskID); err != nil {
+					return nil, fmt.Errorf("getting task: %w", err)
+				}
+				tasks[task.ID] = task
+			}
+		}
+
+		resolved, err := s.rates.Resolve(ctx, organizationID, entry.Date, ownRate(&entry), rateLevels(entry.UserID, p, task))
+		if err != nil {
+			return nil, fmt.Errorf("rate for %s on %s: %w", p.Name, entry.Date.Format("2006-01-02"), err)
+		}
+		source := string(resolved.Source)
+		entries[i].HourlyRate = &resolved.HourlyRate
+		entries[i].RateSource = &source
+
+		key := lineKey{projectID: p.ID, rate: resolved.HourlyRate}
+		description := p.Name
+		if task != nil {
+			key.taskID = task.ID
+			description += ": " + task.Name
+		}
+
+		line, ok := lines[key]
+		if !ok {
+			line = &invoice.CreateInvoiceItemRequest{Description: description, UnitPrice: resolved.HourlyRate}
+			lines[key] = line
+			keys = append(keys, key)
+		}
+		line.Quantity += entry.Hours
+	}
+
+	items := make([]invoice.CreateInvoiceItemRequest, len(keys))
+	for i, key := range keys {
+		items[i] = *lines[key]
+		items[i].Quantity = math.Round(items[i].Quantity*100) / 100
+	}
+	return items, nil
+}
+
+// ownRate is the rate set on the entry itself, as opposed to one it was
+// billed at from further up the rate hierarchy
+func ownRate(entry *TimeEntry) *float64 {
+	if entry.RateSource != nil && *entry.RateSource != string(hourlyrate.SourceEntry) {
+		return nil
+	}
+	return entry.HourlyRate
+}
+
+func sameRate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,104 @@
+// internal/domain/timeentry/list.go
+package timeentry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// SortField is what a list of time entries is ordered by
+type SortField string
+
+const (
+	SortByDate      SortField = "date"
+	SortByHours     SortField = "hours"
+	SortByCreatedAt SortField = "created_at"
+)
+
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByDate, SortByHours, SortByCreatedAt:
+		return true
+	}
+	return false
+}
+
+type ListFilters struct {
+	UserID       *uuid.UUID // One member's entries; everyone's when nil
+	DateFrom     *time.Time
+	DateTo       *time.Time
+	IsBillable   *bool
+	IsInvoiced   *bool
+	JiraIssueKey *string
+	JiraSynced   *bool // Whether the entry has been logged as a Jira worklog
+	ProjectID    *uuid.UUID
+	ClientID     *uuid.UUID // Entries on any of the client's projects
+	Search       string     // Matched against the description, ignoring case
+	Sort         SortField  // Defaults to date
+	Ascending    bool
+	After        *Cursor // Continue after this entry
+	Limit        int     // No limit when zero
+}
+
+// Cursor marks the last entry of a page. It holds every sortable field so
+// the next page can be found whichever field the list is sorted by.
+type Cursor struct {
+	Date      time.Time `json:"d"`
+	Hours     float64   `json:"h"`
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func CursorAfter(entry *TimeEntry) Cursor {
+	return Cursor{
+		Date:      entry.Date,
+		Hours:     entry.Hours,
+		CreatedAt: entry.CreatedAt,
+		ID:        entry.ID,
+	}
+}
+
+// Encode returns the cursor as an opaque token for clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Totals sums every entry matching the filters, not just the page.
+// BillableAmount prices billable time at each entry's resolved rate;
+// time without a rate counts as zero.
+type Totals struct {
+	Count          int     `db:"count"`
+	Hours          float64 `db:"hours"`
+	BillableHours  float64 `db:"billable_hours"`
+	BillableAmount float64 `db:"billable_amount"`
+}
+
+// Page is one page of a filtered list of time entries. NextCursor is empty
+// on the last page.
+type Page struct {
+	Entries    []TimeEntry
+	NextCursor string
+	Totals     Totals
+}
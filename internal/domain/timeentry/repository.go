@@ -3,15 +3,55 @@ package timeentry
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
 	Create(ctx context.Context, entry *TimeEntry) error
+	// CreateMany saves the entries in one transaction
+	CreateMany(ctx context.Context, entries []TimeEntry) error
+	// GetExternalIDs returns which of the external IDs the user has already
+	// imported into the organization
+	GetExternalIDs(ctx context.Context, organizationID, userID uuid.UUID, externalIDs []string) ([]string, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*TimeEntry, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, startDate string, endDate string) ([]TimeEntry, error)
+	// List returns up to filters.Limit entries (all of them when zero)
+	// matching the filters, in the requested order and after the cursor if one
+	// is given
+	List(ctx context.Context, organizationID uuid.UUID, filters ListFilters) ([]TimeEntry, error)
+	// Totals sums the entries matching the filters, ignoring the cursor and
+	// limit
+	Totals(ctx context.Context, organizationID uuid.UUID, filters ListFilters) (*Totals, error)
 	GetByJiraWorklogID(ctx context.Context, worklogID string) (*TimeEntry, error)
 	Update(ctx context.Context, entry *TimeEntry) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// UpdateMany saves the entries in one transaction
+	UpdateMany(ctx context.Context, entries []TimeEntry) error
+	// DeleteMany deletes the entries in one transaction
+	DeleteMany(ctx context.Context, ids []uuid.UUID) error
+	// GetUninvoiced returns the billable entries on the given projects that
+	// were submitted on an approved timesheet and haven't been invoiced,
+	// oldest first. Nil dates leave the range open.
+	GetUninvoiced(ctx context.Context, organizationID uuid.UUID, projectIDs []uuid.UUID, from, to *time.Time) ([]TimeEntry, error)
+	// MarkInvoiced links the entries to the invoice they were billed on and
+	// stores the rate each was billed at, in one transaction. It returns
+	// ErrAlreadyInvoiced, changing nothing, when any is on another invoice.
+	MarkInvoiced(ctx context.Context, entries []TimeEntry, invoiceID uuid.UUID) error
+	// GetByInvoiceID returns the entries billed on the invoice, oldest first
+	GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]TimeEntry, error)
+	// ReleaseInvoiced unlinks the entries from their invoice, dropping the
+	// rate they were billed at unless it was their own
+	ReleaseInvoiced(ctx context.Context, ids []uuid.UUID) error
+}
+
+type TimerRepository interface {
+	Create(ctx context.Context, timer *Timer) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*Timer, error)
+	Update(ctx context.Context, timer *Timer) error
+	// Stop deletes the timer and saves its time entry in one go, returning
+	// ErrNoTimer if the timer was already stopped
+	Stop(ctx context.Context, timerID uuid.UUID, entry *TimeEntry) error
+	// GetStale returns timers that have been running for longer than limit
+	GetStale(ctx context.Context, asOf time.Time, limit time.Duration) ([]Timer, error)
 }
@@ -3,54 +3,161 @@ package timeentry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+var (
+	ErrTimerRunning      = fmt.Errorf("a timer is already running")
+	ErrNoTimer           = fmt.Errorf("no timer is running")
+	ErrTimerPaused       = fmt.Errorf("timer is already paused")
+	ErrTimerNotPaused    = fmt.Errorf("timer is not paused")
+	ErrNoJiraIssue       = fmt.Errorf("timer has no Jira issue to log work to")
+	ErrJiraNotConfigured = fmt.Errorf("Jira integration not configured")
+	ErrJiraSyncFailed    = fmt.Errorf("logging work to Jira failed")
+	ErrNothingToInvoice  = fmt.Errorf("no approved, uninvoiced billable time")
+	ErrAlreadyInvoiced   = fmt.Errorf("some of the time was invoiced on another invoice meanwhile")
+	ErrInvalidCursor     = fmt.Errorf("invalid cursor")
+	ErrInvalidSort       = fmt.Errorf("invalid sort field")
+	ErrTimeEntryNotFound = fmt.Errorf("time entry not found")
+	ErrAlreadyInJira     = fmt.Errorf("time entry is already logged in Jira")
+	ErrEmptySelection    = fmt.Errorf("no time entries selected")
+	ErrTooManyEntries    = fmt.Errorf("too many time entries selected")
+	ErrInvalidImport     = fmt.Errorf("invalid import")
+	ErrWeekLocked        = fmt.Errorf("the week has been submitted for approval; reopen it to change its time")
+	ErrEntryInvoiced     = fmt.Errorf("time entry has been invoiced")
 )
 
 type JiraClient interface {
 	LogWork(ctx context.Context, issueKey string, timeSpentSeconds int, started time.Time, comment string) (string, error)
 }
 
+// Invoicer creates the invoices time is billed on and rebuilds the lines of
+// drafts whose time has changed
+type Invoicer interface {
+	CreateInvoice(ctx context.Context, p organization.Principal, req invoice.CreateInvoiceRequest) (*invoice.Invoice, error)
+	GetInvoice(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) (*invoice.Invoice, error)
+	ReplaceItems(ctx context.Context, p organization.Principal, invoiceID uuid.UUID, items []invoice.CreateInvoiceItemRequest) (*invoice.Invoice, error)
+	DeleteDraft(ctx context.Context, p organization.Principal, invoiceID uuid.UUID) error
+}
+
+// RateResolver works out the rate time is billed at from the rate hierarchy
+type RateResolver interface {
+	Resolve(ctx context.Context, organizationID uuid.UUID, on time.Time, override *float64, levels []hourlyrate.Level) (*hourlyrate.Resolved, error)
+}
+
 type Service struct {
 	repo       Repository
+	timers     TimerRepository
+	weeks      WeekLocks
+	projects   project.Repository
+	rates      RateResolver
+	invoicer   Invoicer
 	jiraClient JiraClient
 }
 
-func NewService(repo Repository, jiraClient JiraClient) *Service {
+func NewService(
+	repo Repository,
+	timers TimerRepository,
+	weeks WeekLocks,
+	projects project.Repository,
+	rates RateResolver,
+	invoicer Invoicer,
+	jiraClient JiraClient,
+) *Service {
 	return &Service{
 		repo:       repo,
+		timers:     timers,
+		weeks:      weeks,
+		projects:   projects,
+		rates:      rates,
+		invoicer:   invoicer,
 		jiraClient: jiraClient,
 	}
 }
 
 type CreateTimeEntryRequest struct {
+	ProjectID   *uuid.UUID
+	TaskID      *uuid.UUID // The project is taken from the task when not set
 	Description string
 	Hours       float64
+	HourlyRate  *float64 // Overrides the task, project, client and default rates
 	Date        time.Time
 	IsBillable  bool
 }
 
+type StartTimerRequest struct {
+	ProjectID    *uuid.UUID
+	TaskID       *uuid.UUID
+	Description  string
+	IsBillable   bool
+	JiraIssueKey *string
+}
+
+type StopTimerRequest struct {
+	PushToJira bool // Log the entry as work on the timer's Jira issue
+}
+
+// InvoiceTimeRequest selects the time to bill on a new invoice
+type InvoiceTimeRequest struct {
+	ClientID   uuid.UUID
+	ProjectIDs []uuid.UUID // Empty for all of the client's projects
+	From       *time.Time
+	To         *time.Time
+	IssueDate  time.Time
+	DueDate    time.Time
+	Currency   string
+	Notes      string
+}
+
 type UpdateTimeEntryRequest struct {
+	ProjectID    *uuid.UUID
+	TaskID       *uuid.UUID
 	Description  string
 	Hours        float64
+	HourlyRate   *float64
 	Date         time.Time
 	IsBillable   bool
 	JiraIssueKey *string
 }
 
-func (s *Service) CreateTimeEntry(ctx context.Context, userID uuid.UUID, req CreateTimeEntryRequest) (*TimeEntry, error) {
+func (s *Service) CreateTimeEntry(ctx context.Context, p organization.Principal, req CreateTimeEntryRequest) (*TimeEntry, error) {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return nil, err
+	}
+
+	if err := s.weekGuard(p.OrganizationID).check(ctx, p.UserID, req.Date); err != nil {
+		return nil, err
+	}
+
+	projectID, taskID, err := s.assign(ctx, p.OrganizationID, req.ProjectID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
 	entry := &TimeEntry{
-		ID:          uuid.New(),
-		UserID:      userID,
-		Description: req.Description,
-		Hours:       req.Hours,
-		Date:        req.Date,
-		IsBillable:  req.IsBillable,
-		IsInvoiced:  false,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		ProjectID:      projectID,
+		TaskID:         taskID,
+		Description:    req.Description,
+		Hours:          req.Hours,
+		HourlyRate:     req.HourlyRate,
+		Date:           req.Date,
+		IsBillable:     req.IsBillable,
+		IsInvoiced:     false,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	if err := s.repo.Create(ctx, entry); err != nil {
@@ -60,39 +167,88 @@ func (s *Service) CreateTimeEntry(ctx context.Context, userID uuid.UUID, req Cre
 	return entry, nil
 }
 
-func (s *Service) ListTimeEntries(ctx context.Context, userID uuid.UUID, startDate string, endDate string) ([]TimeEntry, error) {
-	entries, err := s.repo.GetByUserID(ctx, userID, startDate, endDate)
+// ListTimeEntries returns a page of the entries matching the filters, along
+// with totals for all of them. Without ActionViewTeamTime only the
+// principal's own entries are listed.
+func (s *Service) ListTimeEntries(ctx context.Context, p organization.Principal, filters ListFilters) (*Page, error) {
+	if err := scope(p, &filters); err != nil {
+		return nil, err
+	}
+	if filters.Sort == "" {
+		filters.Sort = SortByDate
+	}
+	if !filters.Sort.IsValid() {
+		return nil, ErrInvalidSort
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = DefaultPageSize
+	}
+	filters.Limit = min(filters.Limit, MaxPageSize)
+
+	// One extra entry tells whether there's another page
+	pageSize := filters.Limit
+	filters.Limit++
+	entries, err := s.repo.List(ctx, p.OrganizationID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("listing time entries: %w", err)
 	}
-	return entries, nil
+
+	page := &Page{Entries: entries}
+	if len(entries) > pageSize {
+		page.Entries = entries[:pageSize]
+		page.NextCursor = CursorAfter(&page.Entries[pageSize-1]).Encode()
+	}
+
+	totals, err := s.repo.Totals(ctx, p.OrganizationID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("totalling time entries: %w", err)
+	}
+	page.Totals = *totals
+
+	return page, nil
 }
 
-func (s *Service) GetTimeEntry(ctx context.Context, userID, entryID uuid.UUID) (*TimeEntry, error) {
+func (s *Service) GetTimeEntry(ctx context.Context, p organization.Principal, entryID uuid.UUID) (*TimeEntry, error) {
 	entry, err := s.repo.GetByID(ctx, entryID)
 	if err != nil {
 		return nil, fmt.Errorf("getting time entry: %w", err)
 	}
 
-	if entry.UserID != userID {
+	if !canView(p, entry) {
 		return nil, fmt.Errorf("unauthorized")
 	}
 
 	return entry, nil
 }
 
-func (s *Service) UpdateTimeEntry(ctx context.Context, userID, entryID uuid.UUID, req UpdateTimeEntryRequest) (*TimeEntry, error) {
-	entry, err := s.repo.GetByID(ctx, entryID)
+func (s *Service) UpdateTimeEntry(ctx context.Context, p organization.Principal, entryID uuid.UUID, req UpdateTimeEntryRequest) (*TimeEntry, error) {
+	entry, err := s.changeEntry(ctx, p, entryID)
 	if err != nil {
-		return nil, fmt.Errorf("getting time entry: %w", err)
+		return nil, err
 	}
 
-	if entry.UserID != userID {
-		return nil, fmt.Errorf("unauthorized")
+	if err := s.weekGuard(entry.OrganizationID).check(ctx, entry.UserID, entry.Date, req.Date); err != nil {
+		return nil, err
+	}
+
+	invoices := s.invoiceGuard(p)
+	if err := invoices.check(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if entry.ProjectID, entry.TaskID, err = s.assign(ctx, entry.OrganizationID, req.ProjectID, req.TaskID); err != nil {
+		return nil, err
+	}
+
+	// Once billed the entry holds the rate it was billed at; a different
+	// rate is the entry's own again
+	if !sameRate(entry.HourlyRate, req.HourlyRate) {
+		entry.RateSource = nil
 	}
 
 	entry.Description = req.Description
 	entry.Hours = req.Hours
+	entry.HourlyRate = req.HourlyRate
 	entry.Date = req.Date
 	entry.IsBillable = req.IsBillable
 	entry.UpdatedAt = time.Now()
@@ -102,45 +258,77 @@ func (s *Service) UpdateTimeEntry(ctx context.Context, userID, entryID uuid.UUID
 		return nil, fmt.Errorf("updating time entry: %w", err)
 	}
 
-	return entry, nil
+	invoices.changed(entry)
+	if err := invoices.rebill(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, entryID)
 }
 
-func (s *Service) DeleteTimeEntry(ctx context.Context, userID, entryID uuid.UUID) error {
-	entry, err := s.repo.GetByID(ctx, entryID)
+func (s *Service) DeleteTimeEntry(ctx context.Context, p organization.Principal, entryID uuid.UUID) error {
+	entry, err := s.changeEntry(ctx, p, entryID)
 	if err != nil {
-		return fmt.Errorf("getting time entry: %w", err)
+		return err
 	}
 
-	if entry.UserID != userID {
-		return fmt.Errorf("unauthorized")
+	if err := s.weekGuard(entry.OrganizationID).check(ctx, entry.UserID, entry.Date); err != nil {
+		return err
+	}
+
+	invoices := s.invoiceGuard(p)
+	if err := invoices.check(ctx, entry); err != nil {
+		return err
 	}
 
 	if err := s.repo.Delete(ctx, entryID); err != nil {
 		return fmt.Errorf("deleting time entry: %w", err)
 	}
 
-	return nil
+	invoices.changed(entry)
+	return invoices.rebill(ctx, s)
 }
 
-func (s *Service) SyncToJira(ctx context.Context, userID, entryID uuid.UUID, issueKey string) error {
-	entry, err := s.repo.GetByID(ctx, entryID)
+func (s *Service) SyncToJira(ctx context.Context, p organization.Principal, entryID uuid.UUID, issueKey string) error {
+	entry, err := s.changeEntry(ctx, p, entryID)
 	if err != nil {
-		return fmt.Errorf("getting time entry: %w", err)
+		return err
 	}
 
-	if entry.UserID != userID {
-		return fmt.Errorf("unauthorized")
-	}
+	return s.syncToJira(ctx, entry, issueKey)
+}
 
+// syncToJira logs the entry as work on the Jira issue and saves it
+func (s *Service) syncToJira(ctx context.Context, entry *TimeEntry, issueKey string) error {
 	if s.jiraClient == nil {
-		return fmt.Errorf("Jira integration not configured")
+		return ErrJiraNotConfigured
+	}
+
+	if err := s.logWork(ctx, entry, issueKey); err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return fmt.Errorf("updating time entry: %w", err)
 	}
 
+	return nil
+}
+
+// logWork logs the entry as work on the Jira issue and records the worklog
+// on the entry, leaving the caller to save it
+func (s *Service) logWork(ctx context.Context, entry *TimeEntry, issueKey string) error {
 	// Convert hours to seconds
 	timeSpentSeconds := int(entry.Hours * 3600)
 
+	// Entries recorded with a timer log work from when the timer started
+	started := entry.Date
+	if entry.StartedAt != nil {
+		started = *entry.StartedAt
+	}
+
 	// Log work to Jira
-	worklogID, err := s.jiraClient.LogWork(ctx, issueKey, timeSpentSeconds, entry.Date, entry.Description)
+	worklogID, err := s.jiraClient.LogWork(ctx, issueKey, timeSpentSeconds, started, entry.Description)
 	if err != nil {
 		return fmt.Errorf("logging work to Jira: %w", err)
 	}
@@ -152,9 +340,345 @@ func (s *Service) SyncToJira(ctx context.Context, userID, entryID uuid.UUID, iss
 	entry.JiraSyncedAt = &now
 	entry.UpdatedAt = now
 
-	if err := s.repo.Update(ctx, entry); err != nil {
-		return fmt.Errorf("updating time entry: %w", err)
+	return nil
+}
+
+// StartTimer starts the user's timer in the principal's organization; only
+// one can run at a time, whichever organization it's in
+func (s *Service) StartTimer(ctx context.Context, p organization.Principal, req StartTimerRequest) (*Timer, error) {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.timers.GetByUserID(ctx, p.UserID); err == nil {
+		return nil, ErrTimerRunning
+	}
+
+	projectID, taskID, err := s.assign(ctx, p.OrganizationID, req.ProjectID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	timer := &Timer{
+		ID:             uuid.New(),
+		OrganizationID: p.OrganizationID,
+		UserID:         p.UserID,
+		ProjectID:      projectID,
+		TaskID:         taskID,
+		Description:    req.Description,
+		IsBillable:     req.IsBillable,
+		JiraIssueKey:   req.JiraIssueKey,
+		StartedAt:      now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.timers.Create(ctx, timer); err != nil {
+		return nil, fmt.Errorf("starting timer: %w", err)
+	}
+
+	return timer, nil
+}
+
+// GetTimer returns the user's running timer, which may have been started in
+// another of their organizations
+func (s *Service) GetTimer(ctx context.Context, p organization.Principal) (*Timer, error) {
+	timer, err := s.timers.GetByUserID(ctx, p.UserID)
+	if err != nil {
+		return nil, ErrNoTimer
+	}
+	return timer, nil
+}
+
+func (s *Service) PauseTimer(ctx context.Context, p organization.Principal) (*Timer, error) {
+	timer, err := s.GetTimer(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := timer.Pause(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.timers.Update(ctx, timer); err != nil {
+		return nil, fmt.Errorf("pausing timer: %w", err)
+	}
+
+	return timer, nil
+}
+
+func (s *Service) ResumeTimer(ctx context.Context, p organization.Principal) (*Timer, error) {
+	timer, err := s.GetTimer(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := timer.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.timers.Update(ctx, timer); err != nil {
+		return nil, fmt.Errorf("resuming timer: %w", err)
+	}
+
+	return timer, nil
+}
+
+// StopTimer stops the user's timer and records it as a time entry in the
+// organization it was started in. It returns ErrWeekLocked, leaving the
+// timer running, when the entry's week is submitted or approved. When the
+// entry can't be pushed to Jira it is still saved, and returned along with
+// ErrJiraSyncFailed.
+func (s *Service) StopTimer(ctx context.Context, p organization.Principal, req StopTimerRequest) (*TimeEntry, error) {
+	timer, err := s.GetTimer(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PushToJira {
+		if timer.JiraIssueKey == nil || *timer.JiraIssueKey == "" {
+			return nil, ErrNoJiraIssue
+		}
+		if s.jiraClient == nil {
+			return nil, ErrJiraNotConfigured
+		}
+	}
+
+	entry := timer.Entry(time.Now())
+	if err := s.weekGuard(entry.OrganizationID).check(ctx, entry.UserID, entry.Date); err != nil {
+		return nil, err
+	}
+	if err := s.timers.Stop(ctx, timer.ID, entry); err != nil {
+		if errors.Is(err, ErrNoTimer) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("stopping timer: %w", err)
 	}
 
+	if req.PushToJira {
+		if err := s.syncToJira(ctx, entry, *timer.JiraIssueKey); err != nil {
+			return entry, fmt.Errorf("%w: %v", ErrJiraSyncFailed, err)
+		}
+	}
+
+	return entry, nil
+}
+
+// StopStale stops timers left running for longer than limit, recording each
+// as an entry of exactly limit, and returns how many were stopped. Timers
+// whose entry would land in a submitted or approved week are left running
+// for their user to deal with.
+func (s *Service) StopStale(ctx context.Context, asOf time.Time, limit time.Duration) (int, error) {
+	timers, err := s.timers.GetStale(ctx, asOf, limit)
+	if err != nil {
+		return 0, fmt.Errorf("getting stale timers: %w", err)
+	}
+
+	stopped := 0
+	for _, timer := range timers {
+		entry := timer.Entry(timer.StopsAt(limit))
+		if err := s.weekGuard(entry.OrganizationID).check(ctx, entry.UserID, entry.Date); err != nil {
+			slog.Warn("not stopping timer", "timer_id", timer.ID, "user_id", timer.UserID, "error", err)
+			continue
+		}
+		if err := s.timers.Stop(ctx, timer.ID, entry); err != nil {
+			if !errors.Is(err, ErrNoTimer) {
+				slog.Error("failed to stop timer", "timer_id", timer.ID, "user_id", timer.UserID, "error", err)
+			}
+			continue
+		}
+		stopped++
+	}
+
+	return stopped, nil
+}
+
+// InvoiceTime bills the client's uninvoiced billable time on a new draft
+// invoice, with a line for each project, task and rate, and links the
+// entries to it along with the rate each was billed at. When some of the
+// time was billed elsewhere meanwhile the draft is deleted again and
+// ErrAlreadyInvoiced returned.
+func (s *Service) InvoiceTime(ctx context.Context, p organization.Principal, req InvoiceTimeRequest) (*invoice.Invoice, []TimeEntry, error) {
+	if err := p.Require(organization.ActionManageBilling); err != nil {
+		return nil, nil, err
+	}
+
+	projects, err := s.projects.GetByOrganizationID(ctx, p.OrganizationID, project.ListFilters{ClientID: &req.ClientID, IncludeArchived: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting projects: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*project.Project, len(projects))
+	for i := range projects {
+		byID[projects[i].ID] = &projects[i]
+	}
+
+	projectIDs := req.ProjectIDs
+	if len(projectIDs) == 0 {
+		for _, project := range projects {
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+	for _, id := range projectIDs {
+		if byID[id] == nil {
+			return nil, nil, project.ErrProjectNotFound
+		}
+	}
+	if len(projectIDs) == 0 {
+		return nil, nil, ErrNothingToInvoice
+	}
+
+	entries, err := s.repo.GetUninvoiced(ctx, p.OrganizationID, projectIDs, req.From, req.To)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting uninvoiced time: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil, ErrNothingToInvoice
+	}
+
+	items, err := s.billLines(ctx, p.OrganizationID, entries, byID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inv, err := s.invoicer.CreateInvoice(ctx, p, invoice.CreateInvoiceRequest{
+		ClientID:  req.ClientID,
+		IssueDate: req.IssueDate,
+		DueDate:   req.DueDate,
+		Currency:  req.Currency,
+		Notes:     req.Notes,
+		Items:     items,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.MarkInvoiced(ctx, entries, inv.ID); err != nil {
+		if derr := s.invoicer.DeleteDraft(ctx, p, inv.ID); derr != nil {
+			slog.Error("failed to delete invoice left without its time", "invoice_id", inv.ID, "error", derr)
+		}
+		return nil, nil, fmt.Errorf("marking time invoiced: %w", err)
+	}
+	for i := range entries {
+		entries[i].InvoiceID = &inv.ID
+		entries[i].IsInvoiced = true
+	}
+
+	return inv, entries, nil
+}
+
+// ResolveRate returns the rate the entry is billed at: the rate stored when
+// it was invoiced, or else the one it would be billed at if invoiced now
+func (s *Service) ResolveRate(ctx context.Context, p organization.Principal, entryID uuid.UUID) (*hourlyrate.Resolved, error) {
+	entry, err := s.GetTimeEntry(ctx, p, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.IsInvoiced && entry.HourlyRate != nil && entry.RateSource != nil {
+		return &hourlyrate.Resolved{HourlyRate: *entry.HourlyRate, Source: hourlyrate.Source(*entry.RateSource)}, nil
+	}
+
+	var proj *project.Project
+	if entry.ProjectID != nil {
+		if proj, err = s.projects.GetByID(ctx, *entry.ProjectID); err != nil {
+			return nil, fmt.Errorf("getting project: %w", err)
+		}
+	}
+
+	var task *project.Task
+	if entry.TaskID != nil {
+		if task, err = s.projects.GetTask(ctx, *entry.TaskID); err != nil {
+			return nil, fmt.Errorf("getting task: %w", err)
+		}
+	}
+
+	return s.rates.Resolve(ctx, entry.OrganizationID, entry.Date, entry.HourlyRate, rateLevels(entry.UserID, proj, task))
+}
+
+// rateLevels is the rate hierarchy below an entry's own rate: its task,
+// project, the project's client and the default of the member whose time it
+// is
+func rateLevels(userID uuid.UUID, p *project.Project, task *project.Task) []hourlyrate.Level {
+	var levels []hourlyrate.Level
+	if task != nil {
+		levels = append(levels, hourlyrate.Level{Scope: hourlyrate.ScopeTask, ID: task.ID, Standing: task.HourlyRate})
+	}
+	if p != nil {
+		levels = append(levels,
+			hourlyrate.Level{Scope: hourlyrate.ScopeProject, ID: p.ID, Standing: p.HourlyRate},
+			hourlyrate.Level{Scope: hourlyrate.ScopeClient, ID: p.ClientID},
+		)
+	}
+	return append(levels, hourlyrate.Level{Scope: hourlyrate.ScopeUser, ID: userID})
+}
+
+// assign checks the project and task belong to the organization, taking the
+// project from the task when only the task is given
+func (s *Service) assign(ctx context.Context, organizationID uuid.UUID, projectID, taskID *uuid.UUID) (*uuid.UUID, *uuid.UUID, error) {
+	if taskID != nil {
+		task, err := s.projects.GetTask(ctx, *taskID)
+		if err != nil {
+			return nil, nil, project.ErrTaskNotFound
+		}
+		if projectID != nil && *projectID != task.ProjectID {
+			return nil, nil, project.ErrTaskNotFound
+		}
+		projectID = &task.ProjectID
+	}
+
+	if projectID != nil {
+		p, err := s.projects.GetByID(ctx, *projectID)
+		if err != nil || p.OrganizationID != organizationID {
+			return nil, nil, project.ErrProjectNotFound
+		}
+	}
+
+	return projectID, taskID, nil
+}
+
+// canView reports whether the principal may see the entry: their own time,
+// or anyone's in the organization with ActionViewTeamTime
+func canView(p organization.Principal, entry *TimeEntry) bool {
+	if !p.Owns(entry.OrganizationID) {
+		return false
+	}
+	return entry.UserID == p.UserID || p.Can(organization.ActionViewTeamTime)
+}
+
+// canChange returns organization.ErrForbidden unless the principal may
+// change the entry: their own time with ActionTrackTime, or anyone's with
+// ActionManageTeamTime
+func canChange(p organization.Principal, entry *TimeEntry) error {
+	if entry.UserID == p.UserID && p.Can(organization.ActionTrackTime) {
+		return nil
+	}
+	return p.Require(organization.ActionManageTeamTime)
+}
+
+// changeEntry returns the entry if the principal may change it
+func (s *Service) changeEntry(ctx context.Context, p organization.Principal, entryID uuid.UUID) (*TimeEntry, error) {
+	entry, err := s.GetTimeEntry(ctx, p, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := canChange(p, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// scope limits the filters to the entries the principal may see
+func scope(p organization.Principal, filters *ListFilters) error {
+	if p.Can(organization.ActionViewTeamTime) {
+		return nil
+	}
+	if filters.UserID != nil && *filters.UserID != p.UserID {
+		return organization.ErrForbidden
+	}
+	filters.UserID = &p.UserID
 	return nil
 }
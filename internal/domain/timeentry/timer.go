@@ -0,0 +1,101 @@
+// internal/domain/timeentry/timer.go
+package timeentry
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Timer is a user's running timer. Each user has at most one, in the
+// organization it was started in; stopping it turns it into a time entry
+// there.
+type Timer struct {
+	ID             uuid.UUID  `db:"id"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	ProjectID      *uuid.UUID `db:"project_id"`
+	TaskID         *uuid.UUID `db:"task_id"`
+	Description    string     `db:"description"`
+	IsBillable     bool       `db:"is_billable"`
+	JiraIssueKey   *string    `db:"jira_issue_key"`
+	StartedAt      time.Time  `db:"started_at"`
+	PausedAt       *time.Time `db:"paused_at"`      // Set while the timer is paused
+	PausedFor      int64      `db:"paused_seconds"` // Time spent paused before the current pause
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+func (t *Timer) IsPaused() bool {
+	return t.PausedAt != nil
+}
+
+// Elapsed is the time the timer has been running at the given time, not
+// counting pauses
+func (t *Timer) Elapsed(at time.Time) time.Duration {
+	at = t.runningUntil(at)
+	elapsed := at.Sub(t.StartedAt) - time.Duration(t.PausedFor)*time.Second
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+func (t *Timer) Pause(at time.Time) error {
+	if t.IsPaused() {
+		return ErrTimerPaused
+	}
+	t.PausedAt = &at
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *Timer) Resume(at time.Time) error {
+	if !t.IsPaused() {
+		return ErrTimerNotPaused
+	}
+	t.PausedFor += int64(at.Sub(*t.PausedAt).Seconds())
+	t.PausedAt = nil
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Entry builds the time entry for a timer stopped at the given time. A
+// paused timer ends when it was paused. Entries are at least a minute long
+// once rounded to hundredths of an hour.
+func (t *Timer) Entry(at time.Time) *TimeEntry {
+	at = t.runningUntil(at)
+	hours := math.Max(math.Round(t.Elapsed(at).Hours()*100)/100, 0.01)
+
+	startedAt, endedAt := t.StartedAt, at
+	now := time.Now()
+	return &TimeEntry{
+		ID:             uuid.New(),
+		OrganizationID: t.OrganizationID,
+		UserID:         t.UserID,
+		ProjectID:      t.ProjectID,
+		TaskID:         t.TaskID,
+		Description:    t.Description,
+		Hours:          hours,
+		Date:           time.Date(startedAt.Year(), startedAt.Month(), startedAt.Day(), 0, 0, 0, 0, startedAt.Location()),
+		StartedAt:      &startedAt,
+		EndedAt:        &endedAt,
+		JiraIssueKey:   t.JiraIssueKey,
+		IsBillable:     t.IsBillable,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// StopsAt is when the timer's running time reaches limit
+func (t *Timer) StopsAt(limit time.Duration) time.Time {
+	return t.StartedAt.Add(time.Duration(t.PausedFor)*time.Second + limit)
+}
+
+func (t *Timer) runningUntil(at time.Time) time.Time {
+	if t.PausedAt != nil && t.PausedAt.Before(at) {
+		return *t.PausedAt
+	}
+	return at
+}
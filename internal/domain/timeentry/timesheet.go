@@ -0,0 +1,477 @@
+// internal/domain/timeentry/timesheet.go
+package timeentry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+// MaxImportRows caps how many rows one import can hold
+const MaxImportRows = 10000
+
+// ColumnMapping names the CSV columns each field is read from; empty names
+// are not imported. Hours come from Hours, else Duration (h:mm:ss), else the
+// start and end times.
+type ColumnMapping struct {
+	ExternalID   string
+	Date         string
+	StartTime    string
+	EndDate      string
+	EndTime      string
+	Duration     string
+	Hours        string
+	Description  string
+	Project      string
+	Task         string
+	Billable     string
+	JiraIssueKey string
+	DateLayout   string // Go layout; YYYY-MM-DD by default
+	TimeLayout   string // Go layout; common 24 and 12 hour forms are also tried
+}
+
+// Presets map the CSV exports of other time trackers
+var Presets = map[string]ColumnMapping{
+	"toggl": {
+		Date:        "Start date",
+		StartTime:   "Start time",
+		EndDate:     "End date",
+		EndTime:     "End time",
+		Duration:    "Duration",
+		Description: "Description",
+		Project:     "Project",
+		Task:        "Task",
+		Billable:    "Billable",
+		DateLayout:  "2006-01-02",
+		TimeLayout:  "15:04:05",
+	},
+	"harvest": {
+		Date:        "Date",
+		Hours:       "Hours",
+		Description: "Notes",
+		Project:     "Project",
+		Task:        "Task",
+		Billable:    "Billable?",
+		DateLayout:  "2006-01-02",
+	},
+	"clockify": {
+		Date:        "Start Date",
+		StartTime:   "Start Time",
+		EndDate:     "End Date",
+		EndTime:     "End Time",
+		Hours:       "Duration (decimal)",
+		Description: "Description",
+		Project:     "Project",
+		Task:        "Task",
+		Billable:    "Billable",
+		DateLayout:  "01/02/2006",
+		TimeLayout:  "03:04:05 PM",
+	},
+}
+
+var fallbackTimeLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "3:04 PM"}
+
+type ImportRequest struct {
+	Source          string // Preset name or "csv"; namespaces external IDs
+	Mapping         ColumnMapping
+	Location        *time.Location // For start and end times; UTC when nil
+	DefaultBillable bool           // For rows without a billable column
+	DryRun          bool           // Validate and report without saving
+}
+
+// ImportRowError is a row that couldn't be imported. Row is the line in the
+// file, counting the header as line 1.
+type ImportRowError struct {
+	Row   int
+	Error string
+}
+
+type ImportResult struct {
+	Rows       int
+	Imported   int // Would be imported, on a dry run
+	Duplicates int // Rows already imported, or repeated in the file
+	Errors     []ImportRowError
+	DryRun     bool
+}
+
+// ExportEntry is a time entry with the names of its project and task
+type ExportEntry struct {
+	TimeEntry
+	Project string
+	Task    string
+}
+
+// Import reads the principal's time entries from CSV. Valid rows are saved
+// together and invalid ones, including those in submitted weeks, reported;
+// rows whose external ID was already imported are skipped. Rows without an
+// external ID column are identified by their contents, so importing the
+// same file twice adds nothing.
+func (s *Service) Import(ctx context.Context, p organization.Principal, data io.Reader, req ImportRequest) (*ImportResult, error) {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return nil, err
+	}
+
+	m := req.Mapping
+	if m.Date == "" || (m.Hours == "" && m.Duration == "" && (m.StartTime == "" || m.EndTime == "")) {
+		return nil, fmt.Errorf("%w: map a date and either hours, a duration or start and end times", ErrInvalidImport)
+	}
+	if m.DateLayout == "" {
+		m.DateLayout = "2006-01-02"
+	}
+	if req.Location == nil {
+		req.Location = time.UTC
+	}
+	if req.Source == "" {
+		req.Source = "csv"
+	}
+
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("%w: no rows after the header", ErrInvalidImport)
+	}
+	if len(records)-1 > MaxImportRows {
+		return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidImport, MaxImportRows)
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		// Spreadsheet exports often start with a byte order mark
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range []string{m.ExternalID, m.Date, m.StartTime, m.EndDate, m.EndTime, m.Duration, m.Hours,
+		m.Description, m.Project, m.Task, m.Billable, m.JiraIssueKey} {
+		if _, ok := columns[name]; name != "" && !ok {
+			return nil, fmt.Errorf("%w: no %q column", ErrInvalidImport, name)
+		}
+	}
+
+	projects, err := s.importProjects(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	guard := s.weekGuard(p.OrganizationID)
+	result := &ImportResult{Rows: len(records) - 1, DryRun: req.DryRun}
+	var entries []TimeEntry
+	var externalIDs []string
+	rowOf := make(map[string]int)
+
+	for i, record := range records[1:] {
+		row := i + 2
+		field := func(name string) string {
+			if col, ok := columns[name]; name != "" && ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+
+		entry, err := parseImportRow(p.OrganizationID, p.UserID, field, m, req, projects)
+		if err == nil {
+			if err = guard.check(ctx, p.UserID, entry.Date); err != nil && !errors.Is(err, ErrWeekLocked) {
+				return nil, err
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: row, Error: err.Error()})
+			continue
+		}
+
+		if _, seen := rowOf[*entry.ExternalID]; seen {
+			result.Duplicates++
+			continue
+		}
+		rowOf[*entry.ExternalID] = row
+		entries = append(entries, *entry)
+		externalIDs = append(externalIDs, *entry.ExternalID)
+	}
+
+	existing, err := s.repo.GetExternalIDs(ctx, p.OrganizationID, p.UserID, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("checking imported entries: %w", err)
+	}
+	imported := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		imported[id] = true
+	}
+
+	fresh := entries[:0]
+	for _, entry := range entries {
+		if imported[*entry.ExternalID] {
+			result.Duplicates++
+			continue
+		}
+		fresh = append(fresh, entry)
+	}
+	result.Imported = len(fresh)
+
+	if !req.DryRun && len(fresh) > 0 {
+		if err := s.repo.CreateMany(ctx, fresh); err != nil {
+			return nil, fmt.Errorf("saving imported entries: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// ExportTimeEntries returns the principal's own entries between the dates,
+// oldest first, with their project and task names
+func (s *Service) ExportTimeEntries(ctx context.Context, p organization.Principal, from, to time.Time) ([]ExportEntry, error) {
+	entries, err := s.repo.List(ctx, p.OrganizationID, ListFilters{
+		UserID:    &p.UserID,
+		DateFrom:  &from,
+		DateTo:    &to,
+		Sort:      SortByDate,
+		Ascending: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing time entries: %w", err)
+	}
+
+	projects, err := s.importProjects(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	projectNames := make(map[uuid.UUID]string)
+	taskNames := make(map[uuid.UUID]string)
+	for _, ip := range projects {
+		projectNames[ip.project.ID] = ip.project.Name
+		for _, task := range ip.tasks {
+			taskNames[task.ID] = task.Name
+		}
+	}
+
+	export := make([]ExportEntry, len(entries))
+	for i, entry := range entries {
+		export[i] = ExportEntry{TimeEntry: entry}
+		if entry.ProjectID != nil {
+			export[i].Project = projectNames[*entry.ProjectID]
+		}
+		if entry.TaskID != nil {
+			export[i].Task = taskNames[*entry.TaskID]
+		}
+	}
+
+	return export, nil
+}
+
+type importProject struct {
+	project project.Project
+	tasks   []project.Task
+}
+
+// importProjects returns the organization's projects, archived ones
+// included, keyed by lower-cased name
+func (s *Service) importProjects(ctx context.Context, organizationID uuid.UUID) (map[string]*importProject, error) {
+	projects, err := s.projects.GetByOrganizationID(ctx, organizationID, project.ListFilters{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("getting projects: %w", err)
+	}
+
+	byName := make(map[string]*importProject, len(projects))
+	for _, p := range projects {
+		tasks, err := s.projects.GetTasks(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting tasks: %w", err)
+		}
+		byName[strings.ToLower(p.Name)] = &importProject{project: p, tasks: tasks}
+	}
+	return byName, nil
+}
+
+func parseImportRow(
+	organizationID, userID uuid.UUID,
+	field func(string) string,
+	m ColumnMapping,
+	req ImportRequest,
+	projects map[string]*importProject,
+) (*TimeEntry, error) {
+	date, err := time.Parse(m.DateLayout, field(m.Date))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", field(m.Date))
+	}
+
+	var startedAt, endedAt *time.Time
+	if field(m.StartTime) != "" && field(m.EndTime) != "" {
+		start, err := parseClock(field(m.StartTime), m.TimeLayout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time %q", field(m.StartTime))
+		}
+		end, err := parseClock(field(m.EndTime), m.TimeLayout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end time %q", field(m.EndTime))
+		}
+
+		endDate := date
+		if v := field(m.EndDate); v != "" {
+			if endDate, err = time.Parse(m.DateLayout, v); err != nil {
+				return nil, fmt.Errorf("invalid end date %q", v)
+			}
+		}
+
+		s := atClock(date, start, req.Location)
+		e := atClock(endDate, end, req.Location)
+		if !e.After(s) {
+			return nil, fmt.Errorf("ends before it starts")
+		}
+		startedAt, endedAt = &s, &e
+	}
+
+	var hours float64
+	switch {
+	case field(m.Hours) != "":
+		if hours, err = strconv.ParseFloat(strings.ReplaceAll(field(m.Hours), ",", "."), 64); err != nil {
+			return nil, fmt.Errorf("invalid hours %q", field(m.Hours))
+		}
+	case field(m.Duration) != "":
+		if hours, err = parseDuration(field(m.Duration)); err != nil {
+			return nil, fmt.Errorf("invalid duration %q", field(m.Duration))
+		}
+	case startedAt != nil:
+		hours = endedAt.Sub(*startedAt).Hours()
+	default:
+		return nil, fmt.Errorf("no hours")
+	}
+	hours = math.Round(hours*100) / 100
+	if hours <= 0 || hours > 24 {
+		return nil, fmt.Errorf("hours must be more than 0 and at most 24")
+	}
+
+	var projectID, taskID *uuid.UUID
+	var projectName, taskName string
+	if name := field(m.Project); name != "" {
+		p, ok := projects[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown project %q", name)
+		}
+		projectID, projectName = &p.project.ID, p.project.Name
+
+		if name := field(m.Task); name != "" {
+			for _, task := range p.tasks {
+				if strings.EqualFold(task.Name, name) {
+					taskID, taskName = &task.ID, task.Name
+					break
+				}
+			}
+			if taskID == nil {
+				return nil, fmt.Errorf("unknown task %q on project %q", name, projectName)
+			}
+		}
+	}
+
+	// Rows without a description are described by their project and task
+	description := field(m.Description)
+	switch {
+	case description != "":
+	case taskName != "":
+		description = projectName + ": " + taskName
+	case projectName != "":
+		description = projectName
+	default:
+		return nil, fmt.Errorf("no description")
+	}
+
+	billable := req.DefaultBillable
+	if v := field(m.Billable); m.Billable != "" && v != "" {
+		if billable, err = parseYesNo(v); err != nil {
+			return nil, fmt.Errorf("invalid billable %q", v)
+		}
+	}
+
+	var jiraIssueKey *string
+	if v := field(m.JiraIssueKey); v != "" {
+		key := strings.ToUpper(v)
+		jiraIssueKey = &key
+	}
+
+	externalID := field(m.ExternalID)
+	if externalID == "" {
+		// Identify the row by what it holds
+		sum := sha256.Sum256([]byte(strings.Join([]string{field(m.Date), field(m.StartTime), field(m.EndDate),
+			field(m.EndTime), strconv.FormatFloat(hours, 'f', 2, 64), description, projectName, taskName}, "\x1f")))
+		externalID = hex.EncodeToString(sum[:16])
+	}
+	externalID = req.Source + ":" + externalID
+
+	now := time.Now()
+	return &TimeEntry{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		ProjectID:      projectID,
+		TaskID:         taskID,
+		ExternalID:     &externalID,
+		Description:    description,
+		Hours:          hours,
+		Date:           date,
+		StartedAt:      startedAt,
+		EndedAt:        endedAt,
+		JiraIssueKey:   jiraIssueKey,
+		IsBillable:     billable,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func parseClock(value, layout string) (time.Time, error) {
+	layouts := fallbackTimeLayouts
+	if layout != "" {
+		layouts = append([]string{layout}, layouts...)
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, strings.ToUpper(value)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time")
+}
+
+func atClock(date, clock time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+}
+
+// parseDuration reads h:mm or h:mm:ss
+func parseDuration(value string) (float64, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.New("invalid duration")
+	}
+
+	var hours float64
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, errors.New("invalid duration")
+		}
+		hours += float64(n) / math.Pow(60, float64(i))
+	}
+	return hours, nil
+}
+
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "true", "1":
+		return true, nil
+	case "no", "n", "false", "0":
+		return false, nil
+	}
+	return false, errors.New("invalid boolean")
+}
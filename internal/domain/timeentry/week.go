@@ -0,0 +1,59 @@
+// internal/domain/timeentry/week.go
+package timeentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeekLocks tells whether a member's week has been submitted or approved,
+// which keeps its time from being changed until the week is reopened
+type WeekLocks interface {
+	IsWeekLocked(ctx context.Context, organizationID, userID uuid.UUID, weekStart time.Time) (bool, error)
+}
+
+// WeekStart is the Monday of the week the date falls in
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// weekGuard checks that entries' weeks are open in an organization,
+// remembering each member's week's answer for operations on many entries
+type weekGuard struct {
+	weeks          WeekLocks
+	organizationID uuid.UUID
+	locked         map[memberWeek]bool
+}
+
+type memberWeek struct {
+	userID uuid.UUID
+	start  time.Time
+}
+
+func (s *Service) weekGuard(organizationID uuid.UUID) *weekGuard {
+	return &weekGuard{weeks: s.weeks, organizationID: organizationID, locked: make(map[memberWeek]bool)}
+}
+
+// check returns ErrWeekLocked if any of the dates is in a locked week of
+// the member's
+func (g *weekGuard) check(ctx context.Context, userID uuid.UUID, dates ...time.Time) error {
+	for _, date := range dates {
+		week := memberWeek{userID: userID, start: WeekStart(date)}
+		locked, ok := g.locked[week]
+		if !ok {
+			var err error
+			if locked, err = g.weeks.IsWeekLocked(ctx, g.organizationID, userID, week.start); err != nil {
+				return fmt.Errorf("checking timesheet: %w", err)
+			}
+			g.locked[week] = locked
+		}
+		if locked {
+			return ErrWeekLocked
+		}
+	}
+	return nil
+}
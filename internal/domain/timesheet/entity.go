@@ -0,0 +1,83 @@
+// internal/domain/timesheet/entity.go
+package timesheet
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/timeentry"
+)
+
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusSubmitted Status = "submitted"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+)
+
+// Timesheet is a member's week, Monday to Sunday, submitted for approval. A
+// submitted or approved week's time can't be changed until it is reopened.
+type Timesheet struct {
+	ID             uuid.UUID  `db:"id"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	UserID         uuid.UUID  `db:"user_id"`
+	WeekStart      time.Time  `db:"week_start"`
+	Status         Status     `db:"status"`
+	ApproverID     *uuid.UUID `db:"approver_id"`    // Nil when a team manager or a client reviews it
+	ReviewerEmail  *string    `db:"reviewer_email"` // The client emailed the review link, if any
+	SubmittedAt    *time.Time `db:"submitted_at"`
+	ReviewedAt     *time.Time `db:"reviewed_at"`
+	ReviewedBy     *string    `db:"reviewed_by"` // Name of whoever approved or rejected it
+	Comment        *string    `db:"comment"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// Locked reports whether the week's time is frozen for review or billing
+func (t *Timesheet) Locked() bool {
+	return t.Status == StatusSubmitted || t.Status == StatusApproved
+}
+
+// ReviewEmail is the data for the email sending a client reviewer the link
+// to a submitted week
+type ReviewEmail struct {
+	Owner      string // Name of the member whose week it is
+	SenderName string
+	WeekStart  string
+	Hours      float64
+	URL        string // Portal link where the client can approve or reject
+}
+
+// Week is a user's time for one week, totalled by day and by project
+type Week struct {
+	Start         time.Time
+	Owner         string     // Name of the user whose week it is
+	Timesheet     *Timesheet // Nil until the week is first submitted
+	Status        Status
+	Days          [7]Day
+	Projects      []ProjectWeek
+	Hours         float64
+	BillableHours float64
+	// Unsubmitted counts entries added since the week was submitted, such as
+	// by a timer or from Jira. They aren't approved with it.
+	Unsubmitted int
+	Entries     []timeentry.TimeEntry
+}
+
+type Day struct {
+	Date          time.Time
+	Hours         float64
+	BillableHours float64
+}
+
+// ProjectWeek is the time on one project, or on no project when ProjectID
+// is nil, for each day of the week
+type ProjectWeek struct {
+	ProjectID *uuid.UUID
+	Name      string
+	Hours     float64
+	Days      [7]float64
+}
@@ -0,0 +1,37 @@
+// internal/domain/timesheet/repository.go
+package timesheet
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the contract for timesheet persistence
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Timesheet, error)
+	// GetByWeek returns ErrTimesheetNotFound for a week that was never
+	// submitted
+	GetByWeek(ctx context.Context, organizationID, userID uuid.UUID, weekStart time.Time) (*Timesheet, error)
+	GetByUserID(ctx context.Context, organizationID, userID uuid.UUID, filters ListFilters) ([]Timesheet, error)
+	// GetPending returns the organization's submitted timesheets the
+	// approver is to review, along with those without an approver when
+	// unassigned is set, oldest week first
+	GetPending(ctx context.Context, organizationID, approverID uuid.UUID, unassigned bool) ([]Timesheet, error)
+	// Submit saves the timesheet and, in the same transaction, marks the
+	// week's entries as submitted on it in place of any earlier submission.
+	// It returns ErrInvalidStatusTransition when the week was locked since it
+	// was read.
+	Submit(ctx context.Context, ts *Timesheet) error
+	// Transition saves the timesheet's status and review, only if it is
+	// still in status from with the same submission time. It reports
+	// whether it did.
+	Transition(ctx context.Context, ts *Timesheet, from Status, submittedAt *time.Time) (bool, error)
+	// IsWeekLocked reports whether the member's week is submitted or approved
+	IsWeekLocked(ctx context.Context, organizationID, userID uuid.UUID, weekStart time.Time) (bool, error)
+}
+
+type ListFilters struct {
+	Status *Status
+}
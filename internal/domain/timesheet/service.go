@@ -0,0 +1,591 @@
+// internal/domain/timesheet/service.go
+package timesheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/client"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
+	"github.com/invoice-app-be/internal/domain/timeentry"
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/pkg/mail"
+)
+
+var (
+	ErrTimesheetNotFound       = fmt.Errorf("timesheet not found")
+	ErrUnauthorized            = fmt.Errorf("unauthorized access")
+	ErrInvalidStatusTransition = fmt.Errorf("invalid status transition")
+	ErrEmptyWeek               = fmt.Errorf("the week has no time to submit")
+	ErrApproverNotFound        = fmt.Errorf("approver not found")
+	ErrNotReviewer             = fmt.Errorf("only the timesheet's approver can review it")
+	ErrCommentRequired         = fmt.Errorf("a comment is required to reject a timesheet")
+	ErrInvalidToken            = fmt.Errorf("invalid review link")
+	ErrLinkExpired             = fmt.Errorf("review link has expired")
+	ErrSelfReview              = fmt.Errorf("you can't review your own timesheet")
+	ErrReviewerConflict        = fmt.Errorf("name an approver or a client reviewer, not both")
+	ErrApproverCannotReview    = fmt.Errorf("the approver's role doesn't allow managing the team's time")
+	ErrReviewerNotClient       = fmt.Errorf("the client reviewer must be the contact of a client with time in the week")
+)
+
+// ReviewLinkLifetime is how long a review link stays valid after the week
+// is submitted
+const ReviewLinkLifetime = 30 * 24 * time.Hour
+
+// TokenSigner turns a submitted timesheet into the token in its review link
+// and back
+type TokenSigner interface {
+	SignTimesheet(ts *Timesheet) (string, error)
+	// VerifyTimesheet returns the timesheet ID and when it was submitted, or
+	// ErrLinkExpired or ErrInvalidToken
+	VerifyTimesheet(token string) (uuid.UUID, time.Time, error)
+}
+
+type Service struct {
+	repo          Repository
+	entries       timeentry.Repository
+	projects      project.Repository
+	clients       client.Repository
+	organizations organization.Repository
+	users         user.Repository
+	signer        TokenSigner
+	mailer        invoice.EmailSender // nil when email is not configured
+	portalURL     string
+}
+
+func NewService(
+	repo Repository,
+	entries timeentry.Repository,
+	projects project.Repository,
+	clients client.Repository,
+	organizations organization.Repository,
+	users user.Repository,
+	signer TokenSigner,
+	mailer invoice.EmailSender,
+	portalURL string,
+) *Service {
+	return &Service{
+		repo:          repo,
+		entries:       entries,
+		projects:      projects,
+		clients:       clients,
+		organizations: organizations,
+		users:         users,
+		signer:        signer,
+		mailer:        mailer,
+		portalURL:     portalURL,
+	}
+}
+
+type SubmitRequest struct {
+	// ApproverEmail names the member who reviews the week, who has to be
+	// able to manage the team's time, and ReviewerEmail the contact of a
+	// client with time in the week, who is emailed a review link instead.
+	// Without either anyone who manages the team's time approves it.
+	ApproverEmail *string
+	ReviewerEmail *string
+}
+
+// GetWeek returns the principal's week containing the date
+func (s *Service) GetWeek(ctx context.Context, p organization.Principal, date time.Time) (*Week, error) {
+	start := timeentry.WeekStart(date)
+	ts, err := s.repo.GetByWeek(ctx, p.OrganizationID, p.UserID, start)
+	if err != nil && !errors.Is(err, ErrTimesheetNotFound) {
+		return nil, fmt.Errorf("getting timesheet: %w", err)
+	}
+
+	return s.week(ctx, p.OrganizationID, p.UserID, start, ts)
+}
+
+func (s *Service) ListTimesheets(ctx context.Context, p organization.Principal, filters ListFilters) ([]Timesheet, error) {
+	return s.repo.GetByUserID(ctx, p.OrganizationID, p.UserID, filters)
+}
+
+// Submit submits the week containing the date for approval, freezing its
+// time, and emails the review link to its client reviewer, if any
+func (s *Service) Submit(ctx context.Context, p organization.Principal, date time.Time, req SubmitRequest) (*Week, error) {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return nil, err
+	}
+
+	start := timeentry.WeekStart(date)
+	ts, err := s.repo.GetByWeek(ctx, p.OrganizationID, p.UserID, start)
+	switch {
+	case errors.Is(err, ErrTimesheetNotFound):
+		ts = &Timesheet{
+			ID:             uuid.New(),
+			OrganizationID: p.OrganizationID,
+			UserID:         p.UserID,
+			WeekStart:      start,
+			CreatedAt:      time.Now(),
+		}
+	case err != nil:
+		return nil, fmt.Errorf("getting timesheet: %w", err)
+	case ts.Locked():
+		return nil, ErrInvalidStatusTransition
+	}
+
+	approverEmail, reviewerEmail := trimmed(req.ApproverEmail), trimmed(req.ReviewerEmail)
+	if approverEmail != nil && reviewerEmail != nil {
+		return nil, ErrReviewerConflict
+	}
+
+	var approverID *uuid.UUID
+	if approverEmail != nil {
+		approver, err := s.users.GetByEmail(ctx, *approverEmail)
+		if err != nil {
+			return nil, ErrApproverNotFound
+		}
+		member, err := s.organizations.GetMember(ctx, p.OrganizationID, approver.ID)
+		if err != nil {
+			return nil, ErrApproverNotFound
+		}
+		if approver.ID == p.UserID {
+			return nil, ErrSelfReview
+		}
+		if !member.Role.Allows(organization.ActionManageTeamTime) {
+			return nil, ErrApproverCannotReview
+		}
+		approverID = &approver.ID
+	}
+
+	owner, err := s.users.GetByID(ctx, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+	if reviewerEmail != nil {
+		if strings.EqualFold(*reviewerEmail, owner.Email) {
+			return nil, ErrSelfReview
+		}
+		if s.mailer == nil {
+			return nil, invoice.ErrEmailNotConfigured
+		}
+	}
+
+	entries, err := s.weekEntries(ctx, p.OrganizationID, p.UserID, start)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmptyWeek
+	}
+	if reviewerEmail != nil {
+		if err := s.checkClientReviewer(ctx, p.OrganizationID, entries, *reviewerEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	// Links carry the submission time, so reopening invalidates old ones
+	now := time.Now().Truncate(time.Second)
+	ts.Status = StatusSubmitted
+	ts.ApproverID = approverID
+	ts.ReviewerEmail = reviewerEmail
+	ts.SubmittedAt = &now
+	ts.ReviewedAt = nil
+	ts.ReviewedBy = nil
+	ts.Comment = nil
+	ts.UpdatedAt = now
+
+	if err := s.repo.Submit(ctx, ts); err != nil {
+		return nil, fmt.Errorf("submitting timesheet: %w", err)
+	}
+
+	week, err := s.week(ctx, p.OrganizationID, p.UserID, start, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewerEmail != nil {
+		if err := s.email(ctx, week, *reviewerEmail, owner.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	return week, nil
+}
+
+// Reopen withdraws a submitted week or reopens an approved one so its time
+// can be changed. It has to be submitted and approved again to be invoiced.
+func (s *Service) Reopen(ctx context.Context, p organization.Principal, date time.Time) (*Week, error) {
+	start := timeentry.WeekStart(date)
+	ts, err := s.repo.GetByWeek(ctx, p.OrganizationID, p.UserID, start)
+	if err != nil {
+		if errors.Is(err, ErrTimesheetNotFound) {
+			return nil, ErrInvalidStatusTransition
+		}
+		return nil, fmt.Errorf("getting timesheet: %w", err)
+	}
+
+	if !ts.Locked() {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	from, submittedAt := ts.Status, ts.SubmittedAt
+	ts.Status = StatusOpen
+	ts.SubmittedAt = nil
+	ts.ReviewedAt = nil
+	ts.ReviewedBy = nil
+	ts.Comment = nil
+	ts.UpdatedAt = time.Now()
+
+	if err := s.transition(ctx, ts, from, submittedAt); err != nil {
+		return nil, err
+	}
+
+	return s.week(ctx, p.OrganizationID, p.UserID, start, ts)
+}
+
+// checkClientReviewer returns ErrReviewerNotClient unless the address is the
+// contact email of the client of a project with time in the week, so members
+// can't send the link to an address of their own
+func (s *Service) checkClientReviewer(ctx context.Context, organizationID uuid.UUID, entries []timeentry.TimeEntry, email string) error {
+	checked := make(map[uuid.UUID]bool)
+	for _, entry := range entries {
+		if entry.ProjectID == nil || checked[*entry.ProjectID] {
+			continue
+		}
+		checked[*entry.ProjectID] = true
+
+		p, err := s.projects.GetByID(ctx, *entry.ProjectID)
+		if err != nil || p.OrganizationID != organizationID {
+			continue
+		}
+		c, err := s.clients.GetByID(ctx, p.ClientID)
+		if err != nil {
+			continue
+		}
+		if c.Email != "" && strings.EqualFold(c.Email, email) {
+			return nil
+		}
+	}
+	return ErrReviewerNotClient
+}
+
+// email sends the week's client reviewer the link they review it through.
+// Only they get the link; it is never shown to the member who submitted.
+func (s *Service) email(ctx context.Context, week *Week, to, replyTo string) error {
+	token, err := s.signer.SignTimesheet(week.Timesheet)
+	if err != nil {
+		return fmt.Errorf("signing review link: %w", err)
+	}
+
+	org, err := s.organizations.GetByID(ctx, week.Timesheet.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("getting organization: %w", err)
+	}
+
+	_, err = s.mailer.Send(ctx, mail.Message{
+		To:       []string{to},
+		ReplyTo:  replyTo,
+		FromName: org.Name,
+		Template: "timesheet_review",
+		Data: ReviewEmail{
+			Owner:      week.Owner,
+			SenderName: org.Name,
+			WeekStart:  week.Start.Format("January 2, 2006"),
+			Hours:      week.Hours,
+			URL:        s.portalURL + "/timesheets/" + token,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", invoice.ErrDeliveryFailed, err)
+	}
+	return nil
+}
+
+// Pending returns the timesheets awaiting the principal's approval: those
+// naming them, and with ActionManageTeamTime those naming no one
+func (s *Service) Pending(ctx context.Context, p organization.Principal) ([]Timesheet, error) {
+	return s.repo.GetPending(ctx, p.OrganizationID, p.UserID, p.Can(organization.ActionManageTeamTime))
+}
+
+// GetTimesheet returns a timesheet's week to its owner, its approver or
+// anyone who can view the team's time
+func (s *Service) GetTimesheet(ctx context.Context, p organization.Principal, timesheetID uuid.UUID) (*Week, error) {
+	ts, err := s.repo.GetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+
+	if !canView(p, ts) {
+		return nil, ErrUnauthorized
+	}
+
+	return s.week(ctx, ts.OrganizationID, ts.UserID, ts.WeekStart, ts)
+}
+
+// Approve approves a submitted timesheet, making its time billable. Only its
+// approver may, or anyone who manages the team's time when it has none.
+func (s *Service) Approve(ctx context.Context, p organization.Principal, timesheetID uuid.UUID, comment string) (*Week, error) {
+	return s.reviewAsMember(ctx, p, timesheetID, StatusApproved, comment)
+}
+
+// Reject sends a submitted timesheet back to its owner with a comment,
+// unfreezing its time
+func (s *Service) Reject(ctx context.Context, p organization.Principal, timesheetID uuid.UUID, comment string) (*Week, error) {
+	return s.reviewAsMember(ctx, p, timesheetID, StatusRejected, comment)
+}
+
+// Open resolves a review link to the week behind it
+func (s *Service) Open(ctx context.Context, token string) (*Week, error) {
+	ts, err := s.byToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.week(ctx, ts.OrganizationID, ts.UserID, ts.WeekStart, ts)
+}
+
+// ApproveByToken records a client reviewer's approval through the review
+// link
+func (s *Service) ApproveByToken(ctx context.Context, token, reviewer, comment string) (*Week, error) {
+	return s.reviewByToken(ctx, token, StatusApproved, reviewer, comment)
+}
+
+// RejectByToken records a client reviewer's rejection through the review
+// link
+func (s *Service) RejectByToken(ctx context.Context, token, reviewer, comment string) (*Week, error) {
+	return s.reviewByToken(ctx, token, StatusRejected, reviewer, comment)
+}
+
+func (s *Service) reviewAsMember(ctx context.Context, p organization.Principal, timesheetID uuid.UUID, status Status, comment string) (*Week, error) {
+	ts, err := s.repo.GetByID(ctx, timesheetID)
+	if err != nil || !canView(p, ts) {
+		return nil, ErrTimesheetNotFound
+	}
+
+	// Nobody reviews their own week, whatever their role
+	canReview := p.Can(organization.ActionManageTeamTime) && ts.ReviewerEmail == nil
+	if ts.ApproverID != nil {
+		canReview = *ts.ApproverID == p.UserID && p.Can(organization.ActionManageTeamTime)
+	}
+	if !canReview || ts.UserID == p.UserID {
+		return nil, ErrNotReviewer
+	}
+
+	u, err := s.users.GetByID(ctx, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting reviewer: %w", err)
+	}
+
+	return s.review(ctx, ts, status, displayName(u), comment)
+}
+
+func (s *Service) reviewByToken(ctx context.Context, token string, status Status, reviewer, comment string) (*Week, error) {
+	ts, err := s.byToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The link only reaches the client reviewer, but a reviewer address that
+	// has since become the owner's own is still the owner reviewing
+	owner, err := s.users.GetByID(ctx, ts.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+	if strings.EqualFold(*ts.ReviewerEmail, owner.Email) {
+		return nil, ErrSelfReview
+	}
+
+	return s.review(ctx, ts, status, strings.TrimSpace(reviewer), comment)
+}
+
+func (s *Service) review(ctx context.Context, ts *Timesheet, status Status, reviewer, comment string) (*Week, error) {
+	if ts.Status != StatusSubmitted {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	comment = strings.TrimSpace(comment)
+	if status == StatusRejected && comment == "" {
+		return nil, ErrCommentRequired
+	}
+
+	now := time.Now()
+	ts.Status = status
+	ts.ReviewedAt = &now
+	ts.ReviewedBy = &reviewer
+	ts.Comment = nil
+	if comment != "" {
+		ts.Comment = &comment
+	}
+	ts.UpdatedAt = now
+
+	if err := s.transition(ctx, ts, StatusSubmitted, ts.SubmittedAt); err != nil {
+		return nil, err
+	}
+
+	return s.week(ctx, ts.OrganizationID, ts.UserID, ts.WeekStart, ts)
+}
+
+// transition saves the timesheet's new status, returning
+// ErrInvalidStatusTransition when it was reviewed, reopened or resubmitted
+// since it was read
+func (s *Service) transition(ctx context.Context, ts *Timesheet, from Status, submittedAt *time.Time) error {
+	changed, err := s.repo.Transition(ctx, ts, from, submittedAt)
+	if err != nil {
+		return fmt.Errorf("updating timesheet: %w", err)
+	}
+	if !changed {
+		return ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+func (s *Service) byToken(ctx context.Context, token string) (*Timesheet, error) {
+	timesheetID, submittedAt, err := s.signer.VerifyTimesheet(token)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.repo.GetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, ErrTimesheetNotFound
+	}
+
+	// A link from before the week was reopened no longer counts, and only
+	// weeks sent to a client reviewer are reviewed through one
+	if ts.SubmittedAt == nil || !ts.SubmittedAt.Equal(submittedAt) || ts.ReviewerEmail == nil {
+		return nil, ErrInvalidToken
+	}
+
+	return ts, nil
+}
+
+// week totals the member's entries for the week starting on start; ts is
+// nil for a week never submitted
+func (s *Service) week(ctx context.Context, organizationID, userID uuid.UUID, start time.Time, ts *Timesheet) (*Week, error) {
+	owner, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user: %w", err)
+	}
+
+	entries, err := s.weekEntries(ctx, organizationID, userID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	week := &Week{
+		Start:     start,
+		Owner:     displayName(owner),
+		Timesheet: ts,
+		Status:    StatusOpen,
+		Entries:   entries,
+	}
+	if ts != nil {
+		week.Status = ts.Status
+	}
+	for i := range week.Days {
+		week.Days[i].Date = start.AddDate(0, 0, i)
+	}
+
+	rows := make(map[uuid.UUID]int)
+	noProject := -1
+	for _, entry := range entries {
+		day := int(entry.Date.Sub(start).Hours() / 24)
+		if day < 0 || day > 6 {
+			continue
+		}
+
+		week.Hours += entry.Hours
+		week.Days[day].Hours += entry.Hours
+		if entry.IsBillable {
+			week.BillableHours += entry.Hours
+			week.Days[day].BillableHours += entry.Hours
+		}
+		if ts != nil && ts.Locked() && (entry.TimesheetID == nil || *entry.TimesheetID != ts.ID) {
+			week.Unsubmitted++
+		}
+
+		row := noProject
+		if entry.ProjectID != nil {
+			if i, ok := rows[*entry.ProjectID]; ok {
+				row = i
+			} else {
+				name := ""
+				if p, err := s.projects.GetByID(ctx, *entry.ProjectID); err == nil {
+					name = p.Name
+				}
+				week.Projects = append(week.Projects, ProjectWeek{ProjectID: entry.ProjectID, Name: name})
+				row = len(week.Projects) - 1
+				rows[*entry.ProjectID] = row
+			}
+		} else if row < 0 {
+			week.Projects = append(week.Projects, ProjectWeek{})
+			noProject = len(week.Projects) - 1
+			row = noProject
+		}
+
+		week.Projects[row].Hours += entry.Hours
+		week.Projects[row].Days[day] += entry.Hours
+	}
+
+	// Keep the sums of two-decimal hours at two decimals
+	week.Hours, week.BillableHours = roundHours(week.Hours), roundHours(week.BillableHours)
+	for i := range week.Days {
+		week.Days[i].Hours = roundHours(week.Days[i].Hours)
+		week.Days[i].BillableHours = roundHours(week.Days[i].BillableHours)
+	}
+	for i := range week.Projects {
+		week.Projects[i].Hours = roundHours(week.Projects[i].Hours)
+		for d := range week.Projects[i].Days {
+			week.Projects[i].Days[d] = roundHours(week.Projects[i].Days[d])
+		}
+	}
+
+	return week, nil
+}
+
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+func (s *Service) weekEntries(ctx context.Context, organizationID, userID uuid.UUID, start time.Time) ([]timeentry.TimeEntry, error) {
+	end := start.AddDate(0, 0, 6)
+	entries, err := s.entries.List(ctx, organizationID, timeentry.ListFilters{
+		UserID:    &userID,
+		DateFrom:  &start,
+		DateTo:    &end,
+		Sort:      timeentry.SortByDate,
+		Ascending: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing time entries: %w", err)
+	}
+	return entries, nil
+}
+
+// canView reports whether the principal may see the timesheet: their own,
+// one they approve, or any in the organization with ActionViewTeamTime
+func canView(p organization.Principal, ts *Timesheet) bool {
+	if !p.Owns(ts.OrganizationID) {
+		return false
+	}
+	if ts.UserID == p.UserID || (ts.ApproverID != nil && *ts.ApproverID == p.UserID) {
+		return true
+	}
+	return p.Can(organization.ActionViewTeamTime)
+}
+
+// trimmed returns the trimmed address, or nil when there's none
+func trimmed(email *string) *string {
+	if email == nil || strings.TrimSpace(*email) == "" {
+		return nil
+	}
+	t := strings.TrimSpace(*email)
+	return &t
+}
+
+func displayName(u *user.User) string {
+	if u.FullName != "" {
+		return u.FullName
+	}
+	return u.Email
+}
@@ -8,11 +8,35 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `db:"id"`
-	Email        string    `db:"email"`
-	PasswordHash string    `db:"password_hash"`
-	FullName     string    `db:"full_name"`
-	CompanyName  string    `db:"company_name"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID              uuid.UUID  `db:"id"`
+	Email           string     `db:"email"`
+	PasswordHash    string     `db:"password_hash"`
+	FullName        string     `db:"full_name"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+func (u *User) IsVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// TokenPurpose is what an emailed token lets its holder do
+type TokenPurpose string
+
+const (
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// Token is a single-use, expiring token emailed to a user. Only its hash is
+// stored.
+type Token struct {
+	ID        uuid.UUID    `db:"id"`
+	UserID    uuid.UUID    `db:"user_id"`
+	Purpose   TokenPurpose `db:"purpose"`
+	TokenHash string       `db:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	UsedAt    *time.Time   `db:"used_at"`
+	CreatedAt time.Time    `db:"created_at"`
 }
@@ -3,6 +3,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,4 +13,12 @@ type Repository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
+
+	// CreateToken saves the token, using up the user's earlier unused
+	// tokens for the same purpose so only the latest link works
+	CreateToken(ctx context.Context, token *Token) error
+	GetToken(ctx context.Context, tokenHash string) (*Token, error)
+	// UseToken marks the token used, returning ErrInvalidToken when it
+	// already was
+	UseToken(ctx context.Context, id uuid.UUID, at time.Time) error
 }
@@ -3,25 +3,56 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/invoice-app-be/internal/pkg/logger"
+	"github.com/invoice-app-be/internal/pkg/mail"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidToken       = fmt.Errorf("invalid or expired link")
+	ErrInvalidCredentials = fmt.Errorf("invalid credentials")
+	ErrWeakPassword       = fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	ErrAlreadyVerified    = fmt.Errorf("email address is already verified")
+	ErrEmailDisabled      = fmt.Errorf("email delivery is not configured")
+)
+
+const (
+	minPasswordLength = 8
+
+	verificationTokenLifetime = 48 * time.Hour
+	resetTokenLifetime        = time.Hour
+)
+
+type EmailSender interface {
+	Send(ctx context.Context, msg mail.Message) (string, error)
+}
+
 type Service struct {
 	repo      Repository
 	jwtSecret string
 	logger    *logger.Logger // ADD THIS
+	mailer    EmailSender    // nil when email is not configured
+	appURL    string         // Where the links in emails lead
 }
 
-func NewService(repo Repository, jwtSecret string, log *logger.Logger) *Service {
+func NewService(repo Repository, jwtSecret string, log *logger.Logger, mailer EmailSender, appURL string) *Service {
 	return &Service{
 		repo:      repo,
 		jwtSecret: jwtSecret,
 		logger:    log, // ADD THIS
+		mailer:    mailer,
+		appURL:    strings.TrimRight(appURL, "/"),
 	}
 }
 
@@ -51,6 +82,41 @@ func (s *Service) Register(ctx context.Context, email, password, fullName string
 		return nil, err
 	}
 
+	// The account works before the address is verified, so a failed email
+	// can be resent later
+	if s.mailer != nil {
+		if err := s.sendVerification(ctx, user); err != nil {
+			s.logger.Error("sending verification email failed", "user_id", user.ID, "error", err)
+		}
+	}
+
+	return user, nil
+}
+
+// RegisterExternal creates a user who logs in through an identity provider
+// and so has no password. They can set one by resetting it.
+func (s *Service) RegisterExternal(ctx context.Context, email, fullName string, verified bool) (*User, error) {
+	existing, _ := s.repo.GetByEmail(ctx, email)
+	if existing != nil {
+		return nil, fmt.Errorf("user already exists")
+	}
+
+	now := time.Now()
+	user := &User{
+		ID:        uuid.New(),
+		Email:     email,
+		FullName:  fullName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if verified {
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -68,3 +134,229 @@ func (s *Service) Authenticate(ctx context.Context, email, password string) (*Us
 
 	return user, nil
 }
+
+type UpdateProfileRequest struct {
+	FullName string
+}
+
+func (s *Service) GetUser(ctx context.Context, userID uuid.UUID) (*User, error) {
+	return s.repo.GetByID(ctx, userID)
+}
+
+func (s *Service) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	return s.repo.GetByEmail(ctx, email)
+}
+
+// UpdateProfile changes the user's profile. The company name and base
+// currency belong to their organizations.
+func (s *Service) UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*User, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.FullName = req.FullName
+	user.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SendVerification emails the user a new link to verify their address
+func (s *Service) SendVerification(ctx context.Context, userID uuid.UUID) error {
+	if s.mailer == nil {
+		return ErrEmailDisabled
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsVerified() {
+		return ErrAlreadyVerified
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+func (s *Service) sendVerification(ctx context.Context, user *User) error {
+	token, err := s.issueToken(ctx, user.ID, PurposeEmailVerification, verificationTokenLifetime)
+	if err != nil {
+		return err
+	}
+
+	return s.email(ctx, user, "verify_email", "/verify-email", token, verificationTokenLifetime)
+}
+
+// VerifyEmail marks the address the token was sent to as verified
+func (s *Service) VerifyEmail(ctx context.Context, token string) (*User, error) {
+	user, err := s.useToken(ctx, token, PurposeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := s.repo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// RequestPasswordReset emails a reset link to the address if it belongs to
+// a user. Unknown addresses succeed too, so the endpoint doesn't reveal who
+// has an account.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return ErrEmailDisabled
+	}
+
+	user, err := s.repo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, PurposePasswordReset, resetTokenLifetime)
+	if err != nil {
+		return err
+	}
+
+	return s.email(ctx, user, "password_reset", "/reset-password", token, resetTokenLifetime)
+}
+
+// ResetPassword sets a new password with a reset token. Receiving the link
+// proves the address, so it also counts as verifying it.
+func (s *Service) ResetPassword(ctx context.Context, token, password string) (*User, error) {
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+
+	user, err := s.useToken(ctx, token, PurposePasswordReset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.setPassword(ctx, user, password); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ChangePassword replaces the password of a logged-in user who knows the
+// current one
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, current, password string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(current)); err != nil {
+		return ErrInvalidCredentials
+	}
+	if len(password) < minPasswordLength {
+		return ErrWeakPassword
+	}
+
+	return s.setPassword(ctx, user, password)
+}
+
+func (s *Service) setPassword(ctx context.Context, user *User, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.PasswordHash = string(hash)
+	if !user.IsVerified() {
+		user.EmailVerifiedAt = &now
+	}
+	user.UpdatedAt = now
+
+	return s.repo.Update(ctx, user)
+}
+
+// issueToken saves a new token for the user, returning it unhashed
+func (s *Service) issueToken(ctx context.Context, userID uuid.UUID, purpose TokenPurpose, lifetime time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	err := s.repo.CreateToken(ctx, &Token{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(lifetime),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("saving token: %w", err)
+	}
+
+	return token, nil
+}
+
+// useToken spends a token issued for the purpose and returns its user
+func (s *Service) useToken(ctx context.Context, token string, purpose TokenPurpose) (*User, error) {
+	t, err := s.repo.GetToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if t.Purpose != purpose || t.UsedAt != nil || !now.Before(t.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	if err := s.repo.UseToken(ctx, t.ID, now); err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("using token: %w", err)
+	}
+
+	return s.repo.GetByID(ctx, t.UserID)
+}
+
+func (s *Service) email(ctx context.Context, user *User, template, path, token string, lifetime time.Duration) error {
+	name := user.FullName
+	if name == "" {
+		name = user.Email
+	}
+
+	_, err := s.mailer.Send(ctx, mail.Message{
+		To:       []string{user.Email},
+		Template: template,
+		Data: map[string]interface{}{
+			"Name":      name,
+			"URL":       s.appURL + path + "?token=" + url.QueryEscape(token),
+			"ExpiresIn": lifetimeText(lifetime),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+	return nil
+}
+
+func lifetimeText(d time.Duration) string {
+	if d == time.Hour {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", int(d.Hours()))
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
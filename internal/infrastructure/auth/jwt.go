@@ -3,60 +3,142 @@ package auth
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// JWTManager issues and verifies access tokens, signed with the key set's
+// signing key and identified by its kid
 type JWTManager struct {
-	secret        string
+	keys          *KeySet
 	tokenDuration time.Duration
 }
 
+const (
+	// challengeAudience marks tokens that only let their holder finish
+	// logging in with a second factor. They aren't access tokens.
+	challengeAudience = "mfa-challenge"
+	challengeDuration = 5 * time.Minute
+)
+
 type Claims struct {
+	UserID         uuid.UUID `json:"user_id"`
+	OrganizationID uuid.UUID `json:"org_id"` // The organization the user is working in
+	SessionID      uuid.UUID `json:"sid"`    // Revoking the session invalidates the token
+	Email          string    `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// ChallengeClaims identify a user who gave their password and has yet to
+// give a second factor
+type ChallengeClaims struct {
 	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTManager(secret string, duration time.Duration) *JWTManager {
+func NewJWTManager(keys *KeySet, duration time.Duration) *JWTManager {
 	return &JWTManager{
-		secret:        secret,
+		keys:          keys,
 		tokenDuration: duration,
 	}
 }
 
-func (m *JWTManager) Generate(userID uuid.UUID, email string) (string, error) {
+// Duration is how long access tokens are valid for
+func (m *JWTManager) Duration() time.Duration {
+	return m.tokenDuration
+}
+
+func (m *JWTManager) Generate(userID, organizationID, sessionID uuid.UUID, email string) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		SessionID:      sessionID,
+		Email:          email,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.tokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secret))
+	return m.sign(claims)
 }
 
 func (m *JWTManager) Verify(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method")
-		}
-		return []byte(m.secret), nil
-	})
-
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil {
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || slices.Contains(claims.Audience, challengeAudience) {
 		return nil, fmt.Errorf("invalid token")
 	}
 
 	return claims, nil
 }
+
+// ChallengeDuration is how long users have to give their second factor
+func (m *JWTManager) ChallengeDuration() time.Duration {
+	return challengeDuration
+}
+
+// GenerateChallenge issues the token a user who logged in with their
+// password trades, along with a second factor, for a session
+func (m *JWTManager) GenerateChallenge(userID uuid.UUID) (string, error) {
+	claims := ChallengeClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{challengeAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(challengeDuration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return m.sign(claims)
+}
+
+// VerifyChallenge returns the user a challenge token was issued to
+func (m *JWTManager) VerifyChallenge(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &ChallengeClaims{}, m.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithAudience(challengeAudience))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	claims, ok := token.Claims.(*ChallengeClaims)
+	if !ok || !token.Valid {
+		return uuid.Nil, fmt.Errorf("invalid token")
+	}
+
+	return claims.UserID, nil
+}
+
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	key := m.keys.signing
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.private)
+}
+
+func (m *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := m.keys.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key")
+	}
+	// The key decides the algorithm, never the token
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method")
+	}
+	return key.Public, nil
+}
+
+// JWKS returns the keys tokens are verified with
+func (m *JWTManager) JWKS() JWKS {
+	return m.keys.JWKS()
+}
@@ -0,0 +1,197 @@
+// internal/infrastructure/auth/keys.go
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a key access tokens are signed or verified with. Its ID is the
+// RFC 7638 thumbprint of the public key, sent as the token's kid.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod // RS256 for RSA keys, EdDSA for Ed25519
+	Public  crypto.PublicKey
+	private crypto.Signer // Nil for keys that only verify
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are
+// still accepted from. Rotating means signing with a new key while the old
+// one stays in the set until the tokens it signed have expired.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	ordered []*Key // Signing key first
+}
+
+// NewKeySet creates a set that signs with the first key and verifies with
+// all of them
+func NewKeySet(signing *Key, verification ...*Key) (*KeySet, error) {
+	if signing == nil || signing.private == nil {
+		return nil, fmt.Errorf("signing key must be a private key")
+	}
+
+	set := &KeySet{signing: signing, keys: map[string]*Key{signing.ID: signing}, ordered: []*Key{signing}}
+	for _, key := range verification {
+		if _, ok := set.keys[key.ID]; ok {
+			continue
+		}
+		set.keys[key.ID] = key
+		set.ordered = append(set.ordered, key)
+	}
+	return set, nil
+}
+
+// LoadKeySet reads the signing key and the keys of retired signing keys
+// from PEM files
+func LoadKeySet(signingFile string, verificationFiles []string) (*KeySet, error) {
+	signing, err := LoadKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := make([]*Key, len(verificationFiles))
+	for i, file := range verificationFiles {
+		if verification[i], err = LoadKey(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewKeySet(signing, verification...)
+}
+
+// LoadKey reads an RSA or Ed25519 key from a PEM file. Private keys may be
+// PKCS #8 or PKCS #1; public keys, which only verify, are PKIX.
+func LoadKey(file string) (*Key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", file)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", file, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: parsing key: %w", file, err)
+	}
+
+	key, err := newKey(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return key, nil
+}
+
+// GenerateKey creates a new Ed25519 signing key
+func GenerateKey() (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	return newKey(private)
+}
+
+func newKey(parsed interface{}) (*Key, error) {
+	key := &Key{}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Public, key.private = jwt.SigningMethodRS256, &k.PublicKey, k
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Public, key.private = jwt.SigningMethodEdDSA, k.Public(), k
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; use RSA or Ed25519", parsed)
+	}
+
+	if rsaKey, ok := key.Public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < 2048 {
+		return nil, fmt.Errorf("RSA keys must be at least 2048 bits")
+	}
+
+	thumbprint, err := json.Marshal(key.JWK().thumbprintMembers())
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(thumbprint)
+	key.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return key, nil
+}
+
+// JWK is a public key in JSON Web Key form
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS is the set of keys tokens verify with, served so other services can
+// check tokens without a shared secret
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k *Key) JWK() JWK {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Method.Alg()}
+	switch public := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// thumbprintMembers returns the key's required members, which Marshal
+// writes in the lexicographic order RFC 7638 hashes them in
+func (j JWK) thumbprintMembers() map[string]string {
+	if j.Kty == "RSA" {
+		return map[string]string{"e": j.E, "kty": j.Kty, "n": j.N}
+	}
+	return map[string]string{"crv": j.Crv, "kty": j.Kty, "x": j.X}
+}
+
+// JWKS returns the public half of every key in the set
+func (s *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, len(s.ordered))}
+	for i, key := range s.ordered {
+		jwks.Keys[i] = key.JWK()
+	}
+	return jwks
+}
@@ -0,0 +1,71 @@
+// internal/infrastructure/auth/secret_box.go
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix marks sealed values, and their format, apart from values
+// stored before they were encrypted
+const sealedPrefix = "v1:"
+
+// SecretBox encrypts secrets stored in the database, such as TOTP secrets,
+// with AES-256-GCM. A sealed value is the prefix and the base64 of the nonce
+// and ciphertext, and only opens with the associated data it was sealed with.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox creates a box with a 32-byte key
+func NewSecretBox(key []byte) (*SecretBox, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secret key must be 32 bytes, not %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+func (b *SecretBox) Seal(plaintext string, associatedData []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), associatedData)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (b *SecretBox) Open(value string, associatedData []byte) (string, error) {
+	if !b.IsSealed(value) {
+		return "", errors.New("value is not sealed")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil || len(sealed) < b.aead.NonceSize() {
+		return "", errors.New("malformed sealed value")
+	}
+
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return "", errors.New("opening sealed value: wrong key or data")
+	}
+	return string(plaintext), nil
+}
+
+// IsSealed reports whether the value was sealed by a box, rather than
+// stored before encryption
+func (b *SecretBox) IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
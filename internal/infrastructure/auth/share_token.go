@@ -0,0 +1,142 @@
+// internal/infrastructure/auth/share_token.go
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/portal"
+	"github.com/invoice-app-be/internal/domain/quote"
+	"github.com/invoice-app-be/internal/domain/timesheet"
+)
+
+const (
+	shareAudience     = "invoice-portal"
+	quoteAudience     = "quote-portal"
+	timesheetAudience = "timesheet-review"
+)
+
+// ShareTokenManager signs the portal's three kinds of link: invoice shares,
+// quotes and timesheet reviews. They are HS256 tokens under a key derived
+// from the secret, and each kind has its own audience so one never verifies
+// as another. Login tokens are signed with the RSA or Ed25519 keys instead.
+type ShareTokenManager struct {
+	key []byte
+}
+
+func NewShareTokenManager(secret string) *ShareTokenManager {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(shareAudience))
+	return &ShareTokenManager{key: mac.Sum(nil)}
+}
+
+func (m *ShareTokenManager) Sign(share *portal.Share) (string, error) {
+	claims := jwt.RegisteredClaims{
+		ID:        share.ID.String(),
+		Subject:   share.InvoiceID.String(),
+		Audience:  jwt.ClaimStrings{shareAudience},
+		IssuedAt:  jwt.NewNumericDate(share.CreatedAt),
+		ExpiresAt: jwt.NewNumericDate(share.ExpiresAt),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.key)
+}
+
+func (m *ShareTokenManager) Verify(tokenString string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return m.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(shareAudience))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return uuid.Nil, portal.ErrShareExpired
+	}
+	if err != nil {
+		return uuid.Nil, portal.ErrInvalidToken
+	}
+
+	shareID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return uuid.Nil, portal.ErrInvalidToken
+	}
+	return shareID, nil
+}
+
+// SignQuote signs a quote link that is valid until the end of the quote's
+// expiry date
+func (m *ShareTokenManager) SignQuote(q *quote.Quote) (string, error) {
+	expiry := q.ExpiryDate
+	expiresAt := time.Date(expiry.Year(), expiry.Month(), expiry.Day()+1, 0, 0, 0, 0, expiry.Location())
+
+	claims := jwt.RegisteredClaims{
+		Subject:   q.ID.String(),
+		Audience:  jwt.ClaimStrings{quoteAudience},
+		IssuedAt:  jwt.NewNumericDate(q.CreatedAt),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.key)
+}
+
+func (m *ShareTokenManager) VerifyQuote(tokenString string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return m.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(quoteAudience))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return uuid.Nil, quote.ErrQuoteExpired
+	}
+	if err != nil {
+		return uuid.Nil, quote.ErrInvalidToken
+	}
+
+	quoteID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, quote.ErrInvalidToken
+	}
+	return quoteID, nil
+}
+
+// SignTimesheet signs a review link for a submitted timesheet. It carries
+// the submission time, so resubmitting the week invalidates older links.
+func (m *ShareTokenManager) SignTimesheet(ts *timesheet.Timesheet) (string, error) {
+	if ts.SubmittedAt == nil {
+		return "", errors.New("timesheet has not been submitted")
+	}
+
+	claims := jwt.RegisteredClaims{
+		Subject:   ts.ID.String(),
+		Audience:  jwt.ClaimStrings{timesheetAudience},
+		IssuedAt:  jwt.NewNumericDate(*ts.SubmittedAt),
+		ExpiresAt: jwt.NewNumericDate(ts.SubmittedAt.Add(timesheet.ReviewLinkLifetime)),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.key)
+}
+
+func (m *ShareTokenManager) VerifyTimesheet(tokenString string) (uuid.UUID, time.Time, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return m.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(timesheetAudience),
+		jwt.WithIssuedAt())
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return uuid.Nil, time.Time{}, timesheet.ErrLinkExpired
+	}
+	if err != nil || claims.IssuedAt == nil {
+		return uuid.Nil, time.Time{}, timesheet.ErrInvalidToken
+	}
+
+	timesheetID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, time.Time{}, timesheet.ErrInvalidToken
+	}
+	return timesheetID, claims.IssuedAt.Time, nil
+}
@@ -2,7 +2,13 @@
 package postgres
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/client"
 )
 
 type ClientRepository struct {
@@ -12,3 +18,16 @@ type ClientRepository struct {
 func NewClientRepository(db *sqlx.DB) *ClientRepository {
 	return &ClientRepository{db: db}
 }
+
+func (r *ClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*client.Client, error) {
+	var c client.Client
+	query := `
+        SELECT id, organization_id, user_id, name, COALESCE(email, '') AS email, COALESCE(company_name, '') AS company_name,
+               COALESCE(address, '') AS address, COALESCE(phone, '') AS phone, created_at, updated_at
+        FROM clients WHERE id = $1
+    `
+	if err := r.db.GetContext(ctx, &c, query, id); err != nil {
+		return nil, fmt.Errorf("getting client: %w", err)
+	}
+	return &c, nil
+}
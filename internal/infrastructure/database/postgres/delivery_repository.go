@@ -0,0 +1,44 @@
+// internal/infrastructure/database/postgres/delivery_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type DeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeliveryRepository(db *sqlx.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+func (r *DeliveryRepository) Create(ctx context.Context, d *invoice.Delivery) error {
+	query := `
+        INSERT INTO invoice_deliveries (id, invoice_id, user_id, recipient, cc, bcc, reply_to, template,
+                                        status, message_id, error, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+	_, err := r.db.ExecContext(ctx, query, d.ID, d.InvoiceID, d.UserID, d.Recipient, d.CC, d.BCC, d.ReplyTo,
+		d.Template, d.Status, d.MessageID, d.Error, d.CreatedAt)
+	return err
+}
+
+func (r *DeliveryRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]invoice.Delivery, error) {
+	var deliveries []invoice.Delivery
+	query := `
+        SELECT id, invoice_id, user_id, recipient, cc, bcc, reply_to, template, status, message_id, error,
+               created_at
+        FROM invoice_deliveries WHERE invoice_id = $1 ORDER BY created_at DESC
+    `
+	if err := r.db.SelectContext(ctx, &deliveries, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting invoice deliveries: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,245 @@
+// internal/infrastructure/database/postgres/dunning_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/dunning"
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type DunningRepository struct {
+	db *sqlx.DB
+}
+
+func NewDunningRepository(db *sqlx.DB) *DunningRepository {
+	return &DunningRepository{db: db}
+}
+
+func (r *DunningRepository) Create(ctx context.Context, seq *dunning.Sequence) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if seq.IsDefault {
+		if err := clearDefaultSequence(ctx, tx, seq); err != nil {
+			return err
+		}
+	}
+
+	query := `
+        INSERT INTO dunning_sequences (id, organization_id, user_id, name, is_default, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	if _, err := tx.ExecContext(ctx, query, seq.ID, seq.OrganizationID, seq.UserID, seq.Name, seq.IsDefault,
+		seq.CreatedAt, seq.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := saveSteps(ctx, tx, seq); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *DunningRepository) GetByID(ctx context.Context, id uuid.UUID) (*dunning.Sequence, error) {
+	var seq dunning.Sequence
+	query := `SELECT id, organization_id, user_id, name, is_default, created_at, updated_at
+              FROM dunning_sequences WHERE id = $1`
+	if err := r.db.GetContext(ctx, &seq, query, id); err != nil {
+		return nil, fmt.Errorf("getting reminder sequence: %w", err)
+	}
+
+	stepQuery := `SELECT id, sequence_id, offset_days, template, message, late_fee_type, late_fee_value, sort_order
+                  FROM dunning_steps WHERE sequence_id = $1 ORDER BY sort_order`
+	if err := r.db.SelectContext(ctx, &seq.Steps, stepQuery, id); err != nil {
+		return nil, fmt.Errorf("getting reminder steps: %w", err)
+	}
+
+	return &seq, nil
+}
+
+func (r *DunningRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]dunning.Sequence, error) {
+	var sequences []dunning.Sequence
+	query := `SELECT id, organization_id, user_id, name, is_default, created_at, updated_at
+              FROM dunning_sequences WHERE organization_id = $1 ORDER BY is_default DESC, name`
+	if err := r.db.SelectContext(ctx, &sequences, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting reminder sequences: %w", err)
+	}
+
+	var steps []dunning.Step
+	stepQuery := `
+        SELECT s.id, s.sequence_id, s.offset_days, s.template, s.message, s.late_fee_type, s.late_fee_value,
+               s.sort_order
+        FROM dunning_steps s
+        JOIN dunning_sequences q ON q.id = s.sequence_id
+        WHERE q.organization_id = $1 ORDER BY s.sort_order
+    `
+	if err := r.db.SelectContext(ctx, &steps, stepQuery, organizationID); err != nil {
+		return nil, fmt.Errorf("getting reminder steps: %w", err)
+	}
+
+	index := make(map[uuid.UUID]int, len(sequences))
+	for i := range sequences {
+		index[sequences[i].ID] = i
+	}
+	for _, step := range steps {
+		if i, ok := index[step.SequenceID]; ok {
+			sequences[i].Steps = append(sequences[i].Steps, step)
+		}
+	}
+
+	return sequences, nil
+}
+
+func (r *DunningRepository) Update(ctx context.Context, seq *dunning.Sequence) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if seq.IsDefault {
+		if err := clearDefaultSequence(ctx, tx, seq); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE dunning_sequences SET name = $2, is_default = $3, updated_at = $4 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, seq.ID, seq.Name, seq.IsDefault, seq.UpdatedAt); err != nil {
+		return err
+	}
+
+	// Steps no longer in the sequence go; their reminder history stays
+	ids := make([]string, len(seq.Steps))
+	for i, step := range seq.Steps {
+		ids[i] = step.ID.String()
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dunning_steps WHERE sequence_id = $1 AND NOT (id::text = ANY($2))`,
+		seq.ID, ids); err != nil {
+		return err
+	}
+
+	if err := saveSteps(ctx, tx, seq); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *DunningRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM dunning_sequences WHERE id = $1", id)
+	return err
+}
+
+func (r *DunningRepository) GetDueSteps(ctx context.Context, asOf time.Time) ([]dunning.DueStep, error) {
+	query := `
+        SELECT i.id AS invoice_id, s.id, s.sequence_id, s.offset_days, s.template, s.message, s.late_fee_type,
+               s.late_fee_value, s.sort_order
+        FROM invoices i
+        JOIN dunning_sequences q ON q.id = COALESCE(
+                i.dunning_sequence_id,
+                (SELECT d.id FROM dunning_sequences d WHERE d.organization_id = i.organization_id AND d.is_default))
+        JOIN dunning_steps s ON s.sequence_id = q.id
+        WHERE i.status IN ('sent', 'partially_paid', 'overdue')
+          AND NOT i.reminders_paused
+          AND i.due_date + s.offset_days <= $1::date
+          AND i.due_date + s.offset_days >= i.issue_date
+          AND NOT EXISTS (SELECT 1 FROM invoice_reminders r WHERE r.invoice_id = i.id AND r.step_id = s.id)
+        ORDER BY i.id, s.offset_days
+    `
+	var due []dunning.DueStep
+	if err := r.db.SelectContext(ctx, &due, query, asOf); err != nil {
+		return nil, fmt.Errorf("getting due reminder steps: %w", err)
+	}
+	return due, nil
+}
+
+func (r *DunningRepository) RecordReminder(ctx context.Context, rem *dunning.Reminder) error {
+	query := `
+        INSERT INTO invoice_reminders (id, invoice_id, step_id, status, delivery_id, error, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err := r.db.ExecContext(ctx, query, rem.ID, rem.InvoiceID, rem.StepID, rem.Status, rem.DeliveryID,
+		rem.Error, rem.CreatedAt)
+	return err
+}
+
+func (r *DunningRepository) ClaimStep(ctx context.Context, rem *dunning.Reminder, lateFee *invoice.InvoiceItem) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO invoice_reminders (id, invoice_id, step_id, status, delivery_id, error, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (invoice_id, step_id) DO NOTHING
+    `
+	result, err := tx.ExecContext(ctx, query, rem.ID, rem.InvoiceID, rem.StepID, rem.Status, rem.DeliveryID,
+		rem.Error, rem.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return false, err
+	}
+
+	if lateFee != nil {
+		if err := addLateFee(ctx, tx, lateFee); err != nil {
+			return false, fmt.Errorf("adding late fee: %w", err)
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+func (r *DunningRepository) FinishReminder(ctx context.Context, rem *dunning.Reminder) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE invoice_reminders SET status = $2, delivery_id = $3, error = $4 WHERE id = $1`,
+		rem.ID, rem.Status, rem.DeliveryID, rem.Error)
+	return err
+}
+
+func (r *DunningRepository) GetReminders(ctx context.Context, invoiceID uuid.UUID) ([]dunning.Reminder, error) {
+	var reminders []dunning.Reminder
+	query := `SELECT id, invoice_id, step_id, status, delivery_id, error, created_at
+              FROM invoice_reminders WHERE invoice_id = $1 ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &reminders, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting invoice reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+func clearDefaultSequence(ctx context.Context, tx *sqlx.Tx, seq *dunning.Sequence) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE dunning_sequences SET is_default = FALSE WHERE organization_id = $1 AND id <> $2 AND is_default`,
+		seq.OrganizationID, seq.ID)
+	return err
+}
+
+func saveSteps(ctx context.Context, tx *sqlx.Tx, seq *dunning.Sequence) error {
+	query := `
+        INSERT INTO dunning_steps (id, sequence_id, offset_days, template, message, late_fee_type,
+                                   late_fee_value, sort_order)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (id) DO UPDATE SET offset_days = EXCLUDED.offset_days, template = EXCLUDED.template,
+                                       message = EXCLUDED.message, late_fee_type = EXCLUDED.late_fee_type,
+                                       late_fee_value = EXCLUDED.late_fee_value, sort_order = EXCLUDED.sort_order
+    `
+	for _, step := range seq.Steps {
+		if _, err := tx.ExecContext(ctx, query, step.ID, step.SequenceID, step.OffsetDays, step.Template,
+			step.Message, step.LateFeeType, step.LateFeeValue, step.SortOrder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// internal/infrastructure/database/postgres/fx_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/fx"
+)
+
+type ExchangeRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db *sqlx.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (r *ExchangeRateRepository) Create(ctx context.Context, rate *fx.Rate) error {
+	query := `
+        INSERT INTO exchange_rates (id, organization_id, user_id, base_currency, quote_currency, rate, effective_date,
+                                    source, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (organization_id, base_currency, quote_currency, effective_date)
+        DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
+    `
+	_, err := r.db.ExecContext(ctx, query, rate.ID, rate.OrganizationID, rate.UserID, rate.BaseCurrency,
+		rate.QuoteCurrency, rate.Rate, rate.EffectiveDate, rate.Source, rate.CreatedAt)
+	return err
+}
+
+func (r *ExchangeRateRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]fx.Rate, error) {
+	var rates []fx.Rate
+	query := `SELECT id, organization_id, user_id, base_currency, quote_currency, rate, effective_date, source, created_at
+              FROM exchange_rates WHERE organization_id = $1 ORDER BY effective_date DESC, base_currency, quote_currency`
+	if err := r.db.SelectContext(ctx, &rates, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) FindLatest(ctx context.Context, organizationID uuid.UUID, base, quote string, on time.Time) (*fx.Rate, error) {
+	var rate fx.Rate
+	query := `
+        SELECT id, organization_id, user_id, base_currency, quote_currency, rate, effective_date, source, created_at
+        FROM exchange_rates
+        WHERE organization_id = $1 AND base_currency = $2 AND quote_currency = $3 AND effective_date <= $4
+        ORDER BY effective_date DESC LIMIT 1
+    `
+	if err := r.db.GetContext(ctx, &rate, query, organizationID, base, quote, on); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fx.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("getting exchange rate: %w", err)
+	}
+	return &rate, nil
+}
@@ -0,0 +1,88 @@
+// internal/infrastructure/database/postgres/hourly_rate_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+)
+
+const hourlyRateColumns = `id, organization_id, user_id, scope, scope_id, hourly_rate, effective_date, created_at`
+
+type HourlyRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewHourlyRateRepository(db *sqlx.DB) *HourlyRateRepository {
+	return &HourlyRateRepository{db: db}
+}
+
+func (r *HourlyRateRepository) Create(ctx context.Context, rate *hourlyrate.Rate) error {
+	query := `
+        INSERT INTO hourly_rates (` + hourlyRateColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (organization_id, scope, scope_id, effective_date)
+        DO UPDATE SET hourly_rate = EXCLUDED.hourly_rate
+    `
+	_, err := r.db.ExecContext(ctx, query, rate.ID, rate.OrganizationID, rate.UserID, rate.Scope, rate.ScopeID,
+		rate.HourlyRate, rate.EffectiveDate, rate.CreatedAt)
+	return err
+}
+
+func (r *HourlyRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*hourlyrate.Rate, error) {
+	var rate hourlyrate.Rate
+	query := `SELECT ` + hourlyRateColumns + ` FROM hourly_rates WHERE id = $1`
+	if err := r.db.GetContext(ctx, &rate, query, id); err != nil {
+		return nil, fmt.Errorf("getting hourly rate: %w", err)
+	}
+	return &rate, nil
+}
+
+func (r *HourlyRateRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters hourlyrate.ListFilters) ([]hourlyrate.Rate, error) {
+	query := `SELECT ` + hourlyRateColumns + ` FROM hourly_rates WHERE organization_id = $1`
+	args := []interface{}{organizationID}
+	if filters.Scope != nil {
+		args = append(args, *filters.Scope)
+		query += fmt.Sprintf(" AND scope = $%d", len(args))
+	}
+	if filters.ScopeID != nil {
+		args = append(args, *filters.ScopeID)
+		query += fmt.Sprintf(" AND scope_id = $%d", len(args))
+	}
+	query += " ORDER BY scope, scope_id, effective_date DESC"
+
+	var rates []hourlyrate.Rate
+	if err := r.db.SelectContext(ctx, &rates, query, args...); err != nil {
+		return nil, fmt.Errorf("getting hourly rates: %w", err)
+	}
+	return rates, nil
+}
+
+func (r *HourlyRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM hourly_rates WHERE id = $1", id)
+	return err
+}
+
+func (r *HourlyRateRepository) FindLatest(ctx context.Context, organizationID uuid.UUID, scope hourlyrate.Scope, scopeID uuid.UUID, on time.Time) (*hourlyrate.Rate, error) {
+	var rate hourlyrate.Rate
+	query := `
+        SELECT ` + hourlyRateColumns + `
+        FROM hourly_rates
+        WHERE organization_id = $1 AND scope = $2 AND scope_id = $3 AND effective_date <= $4
+        ORDER BY effective_date DESC LIMIT 1
+    `
+	if err := r.db.GetContext(ctx, &rate, query, organizationID, scope, scopeID, on); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, hourlyrate.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("getting hourly rate: %w", err)
+	}
+	return &rate, nil
+}
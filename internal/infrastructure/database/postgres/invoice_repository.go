@@ -3,9 +3,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/invoice-app-be/internal/domain/invoice"
@@ -19,6 +22,12 @@ func NewInvoiceRepository(db *sqlx.DB) *InvoiceRepository {
 	return &InvoiceRepository{db: db}
 }
 
+const invoiceColumns = `id, organization_id, user_id, client_id, invoice_number, status, issue_date, due_date,
+               subtotal, tax_rate, tax_amount, total, amount_paid, currency, notes, prices_include_tax,
+               discount_type, discount_value, discount_amount, early_payment_discount_percent,
+               early_payment_discount_days, base_currency, exchange_rate, late_fees, reminders_paused,
+               dunning_sequence_id, viewed_at, recurring_schedule_id, quote_id, created_at, updated_at`
+
 func (r *InvoiceRepository) Create(ctx context.Context, inv *invoice.Invoice) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -28,38 +37,69 @@ func (r *InvoiceRepository) Create(ctx context.Context, inv *invoice.Invoice) er
 
 	// Insert invoice
 	query := `
-        INSERT INTO invoices (id, user_id, client_id, invoice_number, status, issue_date, due_date, 
-                            subtotal, tax_rate, tax_amount, total, currency, notes, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+        INSERT INTO invoices (id, organization_id, user_id, client_id, invoice_number, status, issue_date, due_date,
+                            subtotal, tax_rate, tax_amount, total, currency, notes, prices_include_tax,
+                            discount_type, discount_value, discount_amount, early_payment_discount_percent,
+                            early_payment_discount_days, base_currency, exchange_rate, recurring_schedule_id,
+                            quote_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+                $22, $23, $24, $25, $26)
     `
-	_, err = tx.ExecContext(ctx, query, inv.ID, inv.UserID, inv.ClientID, inv.InvoiceNumber, inv.Status,
-		inv.IssueDate, inv.DueDate, inv.Subtotal, inv.TaxRate, inv.TaxAmount, inv.Total, inv.Currency,
-		inv.Notes, inv.CreatedAt, inv.UpdatedAt)
+	_, err = tx.ExecContext(ctx, query, inv.ID, inv.OrganizationID, inv.UserID, inv.ClientID, inv.InvoiceNumber,
+		inv.Status, inv.IssueDate, inv.DueDate, inv.Subtotal, inv.TaxRate, inv.TaxAmount, inv.Total, inv.Currency,
+		inv.Notes, inv.PricesIncludeTax, inv.DiscountType, inv.DiscountValue, inv.DiscountAmount,
+		inv.EarlyPaymentDiscountPercent, inv.EarlyPaymentDiscountDays, inv.BaseCurrency, inv.ExchangeRate,
+		inv.RecurringScheduleID, inv.QuoteID, inv.CreatedAt, inv.UpdatedAt)
+	if isUniqueViolation(err, "idx_invoices_quote_id") {
+		return invoice.ErrQuoteInvoiced
+	}
 	if err != nil {
 		return err
 	}
 
-	// Insert items
-	for _, item := range inv.Items {
+	if err := insertInvoiceItems(ctx, tx, inv.Items); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertInvoiceItems adds the lines and their taxes
+func insertInvoiceItems(ctx context.Context, exec sqlx.ExecerContext, items []invoice.InvoiceItem) error {
+	for _, item := range items {
 		itemQuery := `
-            INSERT INTO invoice_items (id, invoice_id, description, quantity, unit_price, amount, sort_order, created_at)
-            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            INSERT INTO invoice_items (id, invoice_id, description, quantity, unit_price, amount, tax_exempt,
+                                     tax_amount, discount_type, discount_value, discount_amount, sort_order,
+                                     created_at, is_late_fee)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
         `
-		_, err = tx.ExecContext(ctx, itemQuery, item.ID, item.InvoiceID, item.Description, item.Quantity,
-			item.UnitPrice, item.Amount, item.SortOrder, item.CreatedAt)
+		_, err := exec.ExecContext(ctx, itemQuery, item.ID, item.InvoiceID, item.Description, item.Quantity,
+			item.UnitPrice, item.Amount, item.TaxExempt, item.TaxAmount, item.DiscountType, item.DiscountValue,
+			item.DiscountAmount, item.SortOrder, item.CreatedAt, item.IsLateFee)
 		if err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit()
+		for _, tax := range item.Taxes {
+			taxQuery := `
+                INSERT INTO invoice_item_taxes (id, invoice_item_id, tax_code_id, name, rate, is_compound,
+                                              taxable_amount, amount, sort_order)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            `
+			_, err = exec.ExecContext(ctx, taxQuery, tax.ID, tax.InvoiceItemID, tax.TaxCodeID, tax.Name, tax.Rate,
+				tax.IsCompound, tax.TaxableAmount, tax.Amount, tax.SortOrder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (r *InvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error) {
 	var inv invoice.Invoice
 	query := `
-        SELECT id, user_id, client_id, invoice_number, status, issue_date, due_date,
-               subtotal, tax_rate, tax_amount, total, currency, notes, created_at, updated_at
+        SELECT ` + invoiceColumns + `
         FROM invoices WHERE id = $1
     `
 	if err := r.db.GetContext(ctx, &inv, query, id); err != nil {
@@ -68,24 +108,74 @@ func (r *InvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*invoice
 
 	// Get items
 	var items []invoice.InvoiceItem
-	itemQuery := `SELECT id, invoice_id, description, quantity, unit_price, amount, sort_order, created_at 
-                  FROM invoice_items WHERE invoice_id = $1 ORDER BY sort_order`
+	itemQuery := `SELECT id, invoice_id, description, quantity, unit_price, amount, tax_exempt, tax_amount,
+                         discount_type, discount_value, discount_amount, sort_order, created_at, is_late_fee
+                  FROM invoice_items WHERE invoice_id = $1 ORDER BY is_late_fee, sort_order`
 	if err := r.db.SelectContext(ctx, &items, itemQuery, id); err != nil {
 		return nil, fmt.Errorf("getting invoice items: %w", err)
 	}
+
+	// Get line taxes
+	var taxes []invoice.ItemTax
+	taxQuery := `
+        SELECT t.id, t.invoice_item_id, t.tax_code_id, t.name, t.rate, t.is_compound,
+               t.taxable_amount, t.amount, t.sort_order
+        FROM invoice_item_taxes t
+        JOIN invoice_items i ON i.id = t.invoice_item_id
+        WHERE i.invoice_id = $1 ORDER BY t.sort_order
+    `
+	if err := r.db.SelectContext(ctx, &taxes, taxQuery, id); err != nil {
+		return nil, fmt.Errorf("getting invoice item taxes: %w", err)
+	}
+
+	itemIndex := make(map[uuid.UUID]int, len(items))
+	for i := range items {
+		itemIndex[items[i].ID] = i
+	}
+	for _, tax := range taxes {
+		if i, ok := itemIndex[tax.InvoiceItemID]; ok {
+			items[i].Taxes = append(items[i].Taxes, tax)
+		}
+	}
 	inv.Items = items
 
 	return &inv, nil
 }
 
-func (r *InvoiceRepository) GetByUserID(ctx context.Context, userID uuid.UUID, filters invoice.ListFilters) ([]invoice.Invoice, error) {
+func (r *InvoiceRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters invoice.ListFilters) ([]invoice.Invoice, error) {
 	query := `
-        SELECT id, user_id, client_id, invoice_number, status, issue_date, due_date,
-               subtotal, tax_rate, tax_amount, total, currency, notes, created_at, updated_at
-        FROM invoices WHERE user_id = $1 ORDER BY created_at DESC
+        SELECT ` + invoiceColumns + `
+        FROM invoices WHERE organization_id = $1
     `
+	args := []interface{}{organizationID}
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filters.ClientID != nil {
+		args = append(args, *filters.ClientID)
+		query += fmt.Sprintf(" AND client_id = $%d", len(args))
+	}
+	if filters.DateFrom != nil {
+		args = append(args, *filters.DateFrom)
+		query += fmt.Sprintf(" AND issue_date >= $%d", len(args))
+	}
+	if filters.DateTo != nil {
+		args = append(args, *filters.DateTo)
+		query += fmt.Sprintf(" AND issue_date <= $%d", len(args))
+	}
+	if filters.RecurringScheduleID != nil {
+		args = append(args, *filters.RecurringScheduleID)
+		query += fmt.Sprintf(" AND recurring_schedule_id = $%d", len(args))
+	}
+	query += " ORDER BY created_at DESC"
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit, filters.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
 	var invoices []invoice.Invoice
-	if err := r.db.SelectContext(ctx, &invoices, query, userID); err != nil {
+	if err := r.db.SelectContext(ctx, &invoices, query, args...); err != nil {
 		return nil, fmt.Errorf("getting invoices: %w", err)
 	}
 	return invoices, nil
@@ -94,24 +184,118 @@ func (r *InvoiceRepository) GetByUserID(ctx context.Context, userID uuid.UUID, f
 func (r *InvoiceRepository) Update(ctx context.Context, inv *invoice.Invoice) error {
 	query := `
         UPDATE invoices SET status = $2, subtotal = $3, tax_rate = $4, tax_amount = $5, 
-                          total = $6, notes = $7, updated_at = $8
+                          total = $6, notes = $7, updated_at = $8, amount_paid = $9, late_fees = $10,
+                          reminders_paused = $11, dunning_sequence_id = $12
         WHERE id = $1
     `
 	_, err := r.db.ExecContext(ctx, query, inv.ID, inv.Status, inv.Subtotal, inv.TaxRate,
-		inv.TaxAmount, inv.Total, inv.Notes, inv.UpdatedAt)
+		inv.TaxAmount, inv.Total, inv.Notes, inv.UpdatedAt, inv.AmountPaid, inv.LateFees,
+		inv.RemindersPaused, inv.DunningSequenceID)
 	return err
 }
 
+// addLateFee saves the late fee line and adds it to the invoice's late fees
+// and total in place, so payments recorded meanwhile aren't overwritten
+func addLateFee(ctx context.Context, exec sqlx.ExecerContext, fee *invoice.InvoiceItem) error {
+	if err := insertInvoiceItems(ctx, exec, []invoice.InvoiceItem{*fee}); err != nil {
+		return err
+	}
+	_, err := exec.ExecContext(ctx,
+		`UPDATE invoices SET late_fees = late_fees + $2, total = total + $2, updated_at = $3 WHERE id = $1`,
+		fee.InvoiceID, fee.Amount, fee.CreatedAt)
+	return err
+}
+
+// ReplaceItems deletes the invoice's lines, whose taxes go with them, and
+// inserts its current ones along with the totals they add up to. Late fee
+// lines are left alone.
+func (r *InvoiceRepository) ReplaceItems(ctx context.Context, inv *invoice.Invoice) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM invoice_items WHERE invoice_id = $1 AND NOT is_late_fee",
+		inv.ID); err != nil {
+		return err
+	}
+	var items []invoice.InvoiceItem
+	for _, item := range inv.Items {
+		if !item.IsLateFee {
+			items = append(items, item)
+		}
+	}
+	if err := insertInvoiceItems(ctx, tx, items); err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE invoices SET subtotal = $2, tax_amount = $3, discount_amount = $4, total = $5, updated_at = $6
+        WHERE id = $1
+    `
+	if _, err := tx.ExecContext(ctx, query, inv.ID, inv.Subtotal, inv.TaxAmount, inv.DiscountAmount, inv.Total,
+		inv.UpdatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *InvoiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.db.ExecContext(ctx, "DELETE FROM invoices WHERE id = $1", id)
 	return err
 }
 
-func (r *InvoiceRepository) GetNextInvoiceNumber(ctx context.Context, userID uuid.UUID) (string, error) {
+func (r *InvoiceRepository) GetNextInvoiceNumber(ctx context.Context, organizationID uuid.UUID) (string, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM invoices WHERE user_id = $1`
-	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+	query := `SELECT COUNT(*) FROM invoices WHERE organization_id = $1`
+	if err := r.db.GetContext(ctx, &count, query, organizationID); err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("INV-%05d", count+1), nil
 }
+
+func (r *InvoiceRepository) GetPastDue(ctx context.Context, asOf time.Time) ([]invoice.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + `
+              FROM invoices WHERE status = 'sent' AND due_date < $1 ORDER BY due_date`
+	var invoices []invoice.Invoice
+	if err := r.db.SelectContext(ctx, &invoices, query, asOf); err != nil {
+		return nil, fmt.Errorf("getting past due invoices: %w", err)
+	}
+	return invoices, nil
+}
+
+func (r *InvoiceRepository) MarkViewed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE invoices SET viewed_at = $2 WHERE id = $1 AND viewed_at IS NULL`, id, at)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+func (r *InvoiceRepository) AddActivity(ctx context.Context, a *invoice.Activity) error {
+	query := `
+        INSERT INTO invoice_activities (id, invoice_id, user_id, type, message, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := r.db.ExecContext(ctx, query, a.ID, a.InvoiceID, a.UserID, a.Type, a.Message, a.CreatedAt)
+	return err
+}
+
+func (r *InvoiceRepository) GetActivities(ctx context.Context, invoiceID uuid.UUID) ([]invoice.Activity, error) {
+	var activities []invoice.Activity
+	query := `SELECT id, invoice_id, user_id, type, message, created_at
+              FROM invoice_activities WHERE invoice_id = $1 ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &activities, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting invoice activities: %w", err)
+	}
+	return activities, nil
+}
+
+// isUniqueViolation reports whether err broke the named unique constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,153 @@
+// internal/infrastructure/database/postgres/late_fee_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/latefee"
+)
+
+const lateFeePolicyColumns = `id, organization_id, user_id, client_id, fee_type, amount, compounding, grace_days,
+                              mode, max_amount, max_percent, created_at, updated_at`
+
+type LateFeeRepository struct {
+	db *sqlx.DB
+}
+
+func NewLateFeeRepository(db *sqlx.DB) *LateFeeRepository {
+	return &LateFeeRepository{db: db}
+}
+
+func (r *LateFeeRepository) Create(ctx context.Context, p *latefee.Policy) error {
+	query := `
+        INSERT INTO late_fee_policies (id, organization_id, user_id, client_id, fee_type, amount, compounding,
+                                       grace_days, mode, max_amount, max_percent, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    `
+	_, err := r.db.ExecContext(ctx, query, p.ID, p.OrganizationID, p.UserID, p.ClientID, p.FeeType, p.Amount,
+		p.Compounding, p.GraceDays, p.Mode, p.MaxAmount, p.MaxPercent, p.CreatedAt, p.UpdatedAt)
+	return err
+}
+
+func (r *LateFeeRepository) GetByID(ctx context.Context, id uuid.UUID) (*latefee.Policy, error) {
+	var policy latefee.Policy
+	query := `SELECT ` + lateFeePolicyColumns + ` FROM late_fee_policies WHERE id = $1`
+	if err := r.db.GetContext(ctx, &policy, query, id); err != nil {
+		return nil, fmt.Errorf("getting late fee policy: %w", err)
+	}
+	return &policy, nil
+}
+
+func (r *LateFeeRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]latefee.Policy, error) {
+	var policies []latefee.Policy
+	query := `SELECT ` + lateFeePolicyColumns + `
+              FROM late_fee_policies WHERE organization_id = $1 ORDER BY client_id NULLS FIRST, created_at`
+	if err := r.db.SelectContext(ctx, &policies, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting late fee policies: %w", err)
+	}
+	return policies, nil
+}
+
+func (r *LateFeeRepository) Update(ctx context.Context, p *latefee.Policy) error {
+	query := `
+        UPDATE late_fee_policies
+        SET client_id = $2, fee_type = $3, amount = $4, compounding = $5, grace_days = $6, mode = $7,
+            max_amount = $8, max_percent = $9, updated_at = $10
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, p.ID, p.ClientID, p.FeeType, p.Amount, p.Compounding, p.GraceDays,
+		p.Mode, p.MaxAmount, p.MaxPercent, p.UpdatedAt)
+	return err
+}
+
+func (r *LateFeeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM late_fee_policies WHERE id = $1", id)
+	return err
+}
+
+func (r *LateFeeRepository) GetDue(ctx context.Context, asOf time.Time) ([]latefee.DueInvoice, error) {
+	query := `
+        SELECT i.id AS invoice_id, COALESCE(c.charged, 0) AS charged, c.charges, c.accrued_to,
+               p.id, p.organization_id, p.user_id, p.client_id, p.fee_type, p.amount, p.compounding, p.grace_days, p.mode,
+               p.max_amount, p.max_percent, p.created_at, p.updated_at
+        FROM invoices i
+        JOIN late_fee_policies p ON p.id = COALESCE(
+                (SELECT cp.id FROM late_fee_policies cp
+                 WHERE cp.organization_id = i.organization_id AND cp.client_id = i.client_id),
+                (SELECT dp.id FROM late_fee_policies dp
+                 WHERE dp.organization_id = i.organization_id AND dp.client_id IS NULL))
+        CROSS JOIN LATERAL (
+            SELECT SUM(f.amount) AS charged, COUNT(*) AS charges, MAX(f.period_end) AS accrued_to
+            FROM late_fee_charges f WHERE f.invoice_id = i.id) c
+        WHERE i.status IN ('sent', 'partially_paid', 'overdue')
+          AND i.due_date + p.grace_days < $1::date
+          AND NOT EXISTS (SELECT 1 FROM late_fee_charges f WHERE f.follow_up_invoice_id = i.id)
+        ORDER BY i.due_date
+    `
+	var due []latefee.DueInvoice
+	if err := r.db.SelectContext(ctx, &due, query, asOf); err != nil {
+		return nil, fmt.Errorf("getting invoices due late fees: %w", err)
+	}
+	return due, nil
+}
+
+func (r *LateFeeRepository) CreateCharge(ctx context.Context, c *latefee.Charge, lateFee *invoice.InvoiceItem) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// The line goes in first for the charge to reference it, and is rolled
+	// back along with it when the period was already charged
+	if lateFee != nil {
+		if err := addLateFee(ctx, tx, lateFee); err != nil {
+			return false, fmt.Errorf("adding late fee: %w", err)
+		}
+	}
+
+	query := `
+        INSERT INTO late_fee_charges (id, invoice_id, policy_id, fee_type, rate, basis, period_start, period_end,
+                                      amount, invoice_item_id, follow_up_invoice_id, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        ON CONFLICT DO NOTHING
+    `
+	result, err := tx.ExecContext(ctx, query, c.ID, c.InvoiceID, c.PolicyID, c.FeeType, c.Rate, c.Basis,
+		c.PeriodStart, c.PeriodEnd, c.Amount, c.InvoiceItemID, c.FollowUpInvoiceID, c.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	if created, err := result.RowsAffected(); err != nil || created == 0 {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (r *LateFeeRepository) SetFollowUpInvoice(ctx context.Context, chargeID, invoiceID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE late_fee_charges SET follow_up_invoice_id = $2 WHERE id = $1`,
+		chargeID, invoiceID)
+	return err
+}
+
+func (r *LateFeeRepository) DeleteCharge(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM late_fee_charges WHERE id = $1", id)
+	return err
+}
+
+func (r *LateFeeRepository) GetCharges(ctx context.Context, invoiceID uuid.UUID) ([]latefee.Charge, error) {
+	var charges []latefee.Charge
+	query := `SELECT id, invoice_id, policy_id, fee_type, rate, basis, period_start, period_end, amount,
+                     invoice_item_id, follow_up_invoice_id, created_at
+              FROM late_fee_charges WHERE invoice_id = $1 ORDER BY period_end, created_at`
+	if err := r.db.SelectContext(ctx, &charges, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting late fee charges: %w", err)
+	}
+	return charges, nil
+}
@@ -0,0 +1,196 @@
+// internal/infrastructure/database/postgres/mfa_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/mfa"
+)
+
+const factorColumns = `user_id, secret, enabled_at, last_used_step, failed_attempts, last_failed_at, created_at, updated_at`
+
+type MFARepository struct {
+	db *sqlx.DB
+}
+
+func NewMFARepository(db *sqlx.DB) *MFARepository {
+	return &MFARepository{db: db}
+}
+
+func (r *MFARepository) GetFactor(ctx context.Context, userID uuid.UUID) (*mfa.Factor, error) {
+	var f mfa.Factor
+	query := `SELECT ` + factorColumns + ` FROM user_mfa WHERE user_id = $1`
+	if err := r.db.GetContext(ctx, &f, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, mfa.ErrNotEnabled
+		}
+		return nil, fmt.Errorf("getting factor: %w", err)
+	}
+	return &f, nil
+}
+
+func (r *MFARepository) GetFactors(ctx context.Context) ([]mfa.Factor, error) {
+	var factors []mfa.Factor
+	query := `SELECT ` + factorColumns + ` FROM user_mfa ORDER BY user_id`
+	if err := r.db.SelectContext(ctx, &factors, query); err != nil {
+		return nil, fmt.Errorf("getting factors: %w", err)
+	}
+	return factors, nil
+}
+
+func (r *MFARepository) SaveFactor(ctx context.Context, f *mfa.Factor) error {
+	return saveFactor(ctx, r.db, f)
+}
+
+func (r *MFARepository) Enable(ctx context.Context, f *mfa.Factor, codes []mfa.RecoveryCode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveFactor(ctx, tx, f); err != nil {
+		return err
+	}
+	if err := replaceRecoveryCodes(ctx, tx, f.UserID, codes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MFARepository) CountAttempt(ctx context.Context, userID uuid.UUID, at, since time.Time, max int) (bool, error) {
+	var attempts int
+	err := r.db.GetContext(ctx, &attempts, `
+        UPDATE user_mfa
+        SET failed_attempts = CASE WHEN last_failed_at > $3 THEN failed_attempts + 1 ELSE 1 END,
+            last_failed_at = $2, updated_at = $2
+        WHERE user_id = $1 AND NOT (failed_attempts >= $4 AND COALESCE(last_failed_at > $3, false))
+        RETURNING failed_attempts
+    `, userID, at, since, max)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MFARepository) UseStep(ctx context.Context, userID uuid.UUID, step int64, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE user_mfa SET last_used_step = $2, failed_attempts = 0, last_failed_at = NULL, updated_at = $3
+        WHERE user_id = $1 AND last_used_step < $2
+    `, userID, step, at)
+	if err != nil {
+		return false, err
+	}
+	used, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return used > 0, nil
+}
+
+func (r *MFARepository) ResetAttempts(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE user_mfa SET failed_attempts = 0, last_failed_at = NULL, updated_at = $2 WHERE user_id = $1
+    `, userID, at)
+	return err
+}
+
+func (r *MFARepository) ReplaceSecret(ctx context.Context, userID uuid.UUID, old, secret string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user_mfa SET secret = $3 WHERE user_id = $1 AND secret = $2`,
+		userID, old, secret)
+	return err
+}
+
+func (r *MFARepository) DeleteFactor(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []mfa.RecoveryCode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE mfa_recovery_codes SET used_at = $3
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    `, userID, codeHash, at)
+	if err != nil {
+		return err
+	}
+	if used, err := result.RowsAffected(); err != nil {
+		return err
+	} else if used == 0 {
+		return mfa.ErrInvalidCode
+	}
+	return nil
+}
+
+func (r *MFARepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func saveFactor(ctx context.Context, db sqlx.ExecerContext, f *mfa.Factor) error {
+	query := `
+        INSERT INTO user_mfa (` + factorColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (user_id) DO UPDATE
+        SET secret = EXCLUDED.secret, enabled_at = EXCLUDED.enabled_at, last_used_step = EXCLUDED.last_used_step,
+            failed_attempts = EXCLUDED.failed_attempts, last_failed_at = EXCLUDED.last_failed_at,
+            created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+    `
+	_, err := db.ExecContext(ctx, query, f.UserID, f.Secret, f.EnabledAt, f.LastUsedStep, f.FailedAttempts,
+		f.LastFailedAt, f.CreatedAt, f.UpdatedAt)
+	return err
+}
+
+func replaceRecoveryCodes(ctx context.Context, db sqlx.ExecerContext, userID uuid.UUID, codes []mfa.RecoveryCode) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("deleting recovery codes: %w", err)
+	}
+	for _, c := range codes {
+		query := `INSERT INTO mfa_recovery_codes (id, user_id, code_hash, used_at, created_at) VALUES ($1, $2, $3, $4, $5)`
+		if _, err := db.ExecContext(ctx, query, c.ID, c.UserID, c.CodeHash, c.UsedAt, c.CreatedAt); err != nil {
+			return fmt.Errorf("inserting recovery code: %w", err)
+		}
+	}
+	return nil
+}
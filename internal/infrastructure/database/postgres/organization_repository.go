@@ -0,0 +1,136 @@
+// internal/infrastructure/database/postgres/organization_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+)
+
+const organizationColumns = `id, name, base_currency, require_mfa, created_at, updated_at`
+
+type OrganizationRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, org *organization.Organization, owner *organization.Member) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO organizations (` + organizationColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, query, org.ID, org.Name, org.BaseCurrency, org.RequireMFA, org.CreatedAt,
+		org.UpdatedAt); err != nil {
+		return err
+	}
+	if err := insertMember(ctx, tx, owner); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	var org organization.Organization
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE id = $1`
+	if err := r.db.GetContext(ctx, &org, query, id); err != nil {
+		return nil, fmt.Errorf("getting organization: %w", err)
+	}
+	return &org, nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, org *organization.Organization) error {
+	query := `UPDATE organizations SET name = $2, base_currency = $3, require_mfa = $4, updated_at = $5 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, org.ID, org.Name, org.BaseCurrency, org.RequireMFA, org.UpdatedAt)
+	return err
+}
+
+func (r *OrganizationRepository) GetMemberships(ctx context.Context, userID uuid.UUID) ([]organization.Membership, error) {
+	var memberships []organization.Membership
+	query := `
+        SELECT o.id, o.name, o.base_currency, o.require_mfa, o.created_at, o.updated_at, m.role
+        FROM organization_members m
+        JOIN organizations o ON o.id = m.organization_id
+        WHERE m.user_id = $1
+        ORDER BY m.created_at, o.name
+    `
+	if err := r.db.SelectContext(ctx, &memberships, query, userID); err != nil {
+		return nil, fmt.Errorf("getting memberships: %w", err)
+	}
+	return memberships, nil
+}
+
+const memberColumns = `m.organization_id, m.user_id, m.role, u.email, COALESCE(u.full_name, '') AS full_name,
+                       m.created_at, m.updated_at`
+
+func (r *OrganizationRepository) GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*organization.Member, error) {
+	var member organization.Member
+	query := `
+        SELECT ` + memberColumns + `
+        FROM organization_members m JOIN users u ON u.id = m.user_id
+        WHERE m.organization_id = $1 AND m.user_id = $2
+    `
+	if err := r.db.GetContext(ctx, &member, query, organizationID, userID); err != nil {
+		return nil, fmt.Errorf("getting member: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *OrganizationRepository) GetMembers(ctx context.Context, organizationID uuid.UUID) ([]organization.Member, error) {
+	var members []organization.Member
+	query := `
+        SELECT ` + memberColumns + `
+        FROM organization_members m JOIN users u ON u.id = m.user_id
+        WHERE m.organization_id = $1
+        ORDER BY u.full_name, u.email
+    `
+	if err := r.db.SelectContext(ctx, &members, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *OrganizationRepository) AddMember(ctx context.Context, member *organization.Member) error {
+	return insertMember(ctx, r.db, member)
+}
+
+func insertMember(ctx context.Context, db sqlx.ExecerContext, member *organization.Member) error {
+	query := `
+        INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := db.ExecContext(ctx, query, member.OrganizationID, member.UserID, member.Role, member.CreatedAt,
+		member.UpdatedAt)
+	return err
+}
+
+func (r *OrganizationRepository) UpdateMember(ctx context.Context, member *organization.Member) error {
+	query := `UPDATE organization_members SET role = $3, updated_at = $4 WHERE organization_id = $1 AND user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, member.OrganizationID, member.UserID, member.Role, member.UpdatedAt)
+	return err
+}
+
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2",
+		organizationID, userID)
+	return err
+}
+
+func (r *OrganizationRepository) CountOwners(ctx context.Context, organizationID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = 'owner'`
+	if err := r.db.GetContext(ctx, &count, query, organizationID); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,78 @@
+// internal/infrastructure/database/postgres/payment_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/payment"
+)
+
+type PaymentRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+func (r *PaymentRepository) Record(ctx context.Context, p *payment.Payment, apply func(inv *invoice.Invoice, totalPaid float64) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var inv invoice.Invoice
+	if err := tx.GetContext(ctx, &inv, `SELECT `+invoiceColumns+` FROM invoices WHERE id = $1 FOR UPDATE`,
+		p.InvoiceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invoice.ErrInvoiceNotFound
+		}
+		return fmt.Errorf("locking invoice: %w", err)
+	}
+
+	query := `
+        INSERT INTO payments (id, invoice_id, user_id, amount, currency, method, paid_at, reference, notes,
+                            exchange_rate, fx_gain_loss, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+	if _, err := tx.ExecContext(ctx, query, p.ID, p.InvoiceID, p.UserID, p.Amount, p.Currency, p.Method, p.PaidAt,
+		p.Reference, p.Notes, p.ExchangeRate, p.FXGainLoss, p.CreatedAt); err != nil {
+		return fmt.Errorf("creating payment: %w", err)
+	}
+
+	var totalPaid float64
+	if err := tx.GetContext(ctx, &totalPaid, `SELECT COALESCE(SUM(amount), 0) FROM payments WHERE invoice_id = $1`,
+		p.InvoiceID); err != nil {
+		return fmt.Errorf("summing payments: %w", err)
+	}
+
+	if err := apply(&inv, totalPaid); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE invoices SET status = $2, amount_paid = $3, updated_at = $4 WHERE id = $1`,
+		inv.ID, inv.Status, inv.AmountPaid, inv.UpdatedAt); err != nil {
+		return fmt.Errorf("updating invoice: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PaymentRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]payment.Payment, error) {
+	var payments []payment.Payment
+	query := `SELECT id, invoice_id, user_id, amount, currency, method, paid_at, reference, notes,
+                     exchange_rate, fx_gain_loss, created_at
+              FROM payments WHERE invoice_id = $1 ORDER BY paid_at, created_at`
+	if err := r.db.SelectContext(ctx, &payments, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting payments: %w", err)
+	}
+	return payments, nil
+}
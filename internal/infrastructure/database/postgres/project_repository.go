@@ -0,0 +1,139 @@
+// internal/infrastructure/database/postgres/project_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+const projectColumns = `id, organization_id, user_id, client_id, name, hourly_rate, budget_hours, budget_amount,
+                        jira_project_key, archived, created_at, updated_at`
+
+const taskColumns = `id, project_id, name, hourly_rate, archived, created_at, updated_at`
+
+type ProjectRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectRepository(db *sqlx.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	query := `
+        INSERT INTO projects (` + projectColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+	_, err := r.db.ExecContext(ctx, query, p.ID, p.OrganizationID, p.UserID, p.ClientID, p.Name, p.HourlyRate,
+		p.BudgetHours, p.BudgetAmount, p.JiraProjectKey, p.Archived, p.CreatedAt, p.UpdatedAt)
+	return err
+}
+
+func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	var p project.Project
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
+	if err := r.db.GetContext(ctx, &p, query, id); err != nil {
+		return nil, fmt.Errorf("getting project: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *ProjectRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters project.ListFilters) ([]project.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE organization_id = $1`
+	args := []interface{}{organizationID}
+	if filters.ClientID != nil {
+		args = append(args, *filters.ClientID)
+		query += fmt.Sprintf(" AND client_id = $%d", len(args))
+	}
+	if !filters.IncludeArchived {
+		query += " AND NOT archived"
+	}
+	query += " ORDER BY name"
+
+	var projects []project.Project
+	if err := r.db.SelectContext(ctx, &projects, query, args...); err != nil {
+		return nil, fmt.Errorf("getting projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *ProjectRepository) GetByJiraKey(ctx context.Context, organizationID uuid.UUID, key string) (*project.Project, error) {
+	var p project.Project
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE organization_id = $1 AND jira_project_key = $2`
+	if err := r.db.GetContext(ctx, &p, query, organizationID, key); err != nil {
+		return nil, fmt.Errorf("getting project by Jira key: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, p *project.Project) error {
+	query := `
+        UPDATE projects
+        SET client_id = $2, name = $3, hourly_rate = $4, budget_hours = $5, budget_amount = $6,
+            jira_project_key = $7, archived = $8, updated_at = $9
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, p.ID, p.ClientID, p.Name, p.HourlyRate, p.BudgetHours, p.BudgetAmount,
+		p.JiraProjectKey, p.Archived, p.UpdatedAt)
+	return err
+}
+
+func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM projects WHERE id = $1", id)
+	return err
+}
+
+func (r *ProjectRepository) GetUsage(ctx context.Context, id uuid.UUID) (*project.Usage, error) {
+	var usage project.Usage
+	query := `
+        SELECT COALESCE(SUM(hours), 0) AS hours,
+               COALESCE(SUM(hours) FILTER (WHERE is_billable), 0) AS billable_hours,
+               COALESCE(SUM(hours) FILTER (WHERE is_invoiced), 0) AS invoiced_hours
+        FROM time_entries WHERE project_id = $1
+    `
+	if err := r.db.GetContext(ctx, &usage, query, id); err != nil {
+		return nil, fmt.Errorf("getting project usage: %w", err)
+	}
+	return &usage, nil
+}
+
+func (r *ProjectRepository) CreateTask(ctx context.Context, t *project.Task) error {
+	query := `INSERT INTO project_tasks (` + taskColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.ExecContext(ctx, query, t.ID, t.ProjectID, t.Name, t.HourlyRate, t.Archived, t.CreatedAt,
+		t.UpdatedAt)
+	return err
+}
+
+func (r *ProjectRepository) GetTask(ctx context.Context, id uuid.UUID) (*project.Task, error) {
+	var t project.Task
+	query := `SELECT ` + taskColumns + ` FROM project_tasks WHERE id = $1`
+	if err := r.db.GetContext(ctx, &t, query, id); err != nil {
+		return nil, fmt.Errorf("getting task: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *ProjectRepository) GetTasks(ctx context.Context, projectID uuid.UUID) ([]project.Task, error) {
+	var tasks []project.Task
+	query := `SELECT ` + taskColumns + ` FROM project_tasks WHERE project_id = $1 ORDER BY name`
+	if err := r.db.SelectContext(ctx, &tasks, query, projectID); err != nil {
+		return nil, fmt.Errorf("getting tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *ProjectRepository) UpdateTask(ctx context.Context, t *project.Task) error {
+	query := `UPDATE project_tasks SET name = $2, hourly_rate = $3, archived = $4, updated_at = $5 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, t.ID, t.Name, t.HourlyRate, t.Archived, t.UpdatedAt)
+	return err
+}
+
+func (r *ProjectRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM project_tasks WHERE id = $1", id)
+	return err
+}
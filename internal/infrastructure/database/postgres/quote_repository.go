@@ -0,0 +1,136 @@
+// internal/infrastructure/database/postgres/quote_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/quote"
+)
+
+const quoteColumns = `id, organization_id, user_id, client_id, quote_number, status, issue_date, expiry_date,
+                      currency, tax_rate, prices_include_tax, notes, discount_type, discount_value, discount_amount, subtotal,
+                      tax_amount, total, payment_terms_days, items, invoice_id, sent_at, accepted_at,
+                      declined_at, created_at, updated_at`
+
+type QuoteRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuoteRepository(db *sqlx.DB) *QuoteRepository {
+	return &QuoteRepository{db: db}
+}
+
+func (r *QuoteRepository) Create(ctx context.Context, q *quote.Quote) error {
+	query := `
+        INSERT INTO quotes (` + quoteColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+                $22, $23, $24, $25, $26)
+    `
+	_, err := r.db.ExecContext(ctx, query, q.ID, q.OrganizationID, q.UserID, q.ClientID, q.QuoteNumber, q.Status,
+		q.IssueDate, q.ExpiryDate, q.Currency, q.TaxRate, q.PricesIncludeTax, q.Notes, q.DiscountType, q.DiscountValue,
+		q.DiscountAmount, q.Subtotal, q.TaxAmount, q.Total, q.PaymentTermsDays, q.Items, q.InvoiceID, q.SentAt,
+		q.AcceptedAt, q.DeclinedAt, q.CreatedAt, q.UpdatedAt)
+	return err
+}
+
+func (r *QuoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*quote.Quote, error) {
+	var q quote.Quote
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE id = $1`
+	if err := r.db.GetContext(ctx, &q, query, id); err != nil {
+		return nil, fmt.Errorf("getting quote: %w", err)
+	}
+	return &q, nil
+}
+
+func (r *QuoteRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, filters quote.ListFilters) ([]quote.Quote, error) {
+	query := `
+        SELECT ` + quoteColumns + `
+        FROM quotes WHERE organization_id = $1
+    `
+	args := []interface{}{organizationID}
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filters.ClientID != nil {
+		args = append(args, *filters.ClientID)
+		query += fmt.Sprintf(" AND client_id = $%d", len(args))
+	}
+	query += " ORDER BY created_at DESC"
+
+	var quotes []quote.Quote
+	if err := r.db.SelectContext(ctx, &quotes, query, args...); err != nil {
+		return nil, fmt.Errorf("getting quotes: %w", err)
+	}
+	return quotes, nil
+}
+
+func (r *QuoteRepository) Update(ctx context.Context, q *quote.Quote) error {
+	query := `
+        UPDATE quotes
+        SET client_id = $2, status = $3, issue_date = $4, expiry_date = $5, currency = $6, tax_rate = $7,
+            prices_include_tax = $8, notes = $9, discount_type = $10, discount_value = $11,
+            discount_amount = $12, subtotal = $13, tax_amount = $14, total = $15, payment_terms_days = $16,
+            items = $17, invoice_id = $18, sent_at = $19, accepted_at = $20, declined_at = $21, updated_at = $22
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, q.ID, q.ClientID, q.Status, q.IssueDate, q.ExpiryDate, q.Currency,
+		q.TaxRate, q.PricesIncludeTax, q.Notes, q.DiscountType, q.DiscountValue, q.DiscountAmount, q.Subtotal,
+		q.TaxAmount, q.Total, q.PaymentTermsDays, q.Items, q.InvoiceID, q.SentAt, q.AcceptedAt, q.DeclinedAt,
+		q.UpdatedAt)
+	return err
+}
+
+func (r *QuoteRepository) Transition(ctx context.Context, q *quote.Quote, from quote.Status) (bool, error) {
+	query := `
+        UPDATE quotes SET status = $2, accepted_at = $3, declined_at = $4, updated_at = $5
+        WHERE id = $1 AND status = $6
+    `
+	result, err := r.db.ExecContext(ctx, query, q.ID, q.Status, q.AcceptedAt, q.DeclinedAt, q.UpdatedAt, from)
+	if err != nil {
+		return false, err
+	}
+	changed, err := result.RowsAffected()
+	return changed > 0, err
+}
+
+func (r *QuoteRepository) LinkInvoice(ctx context.Context, quoteID, invoiceID uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE quotes SET invoice_id = $2, updated_at = $3 WHERE id = $1`,
+		quoteID, invoiceID, at)
+	return err
+}
+
+func (r *QuoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM quotes WHERE id = $1", id)
+	return err
+}
+
+func (r *QuoteRepository) GetNextQuoteNumber(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM quotes WHERE organization_id = $1`
+	if err := r.db.GetContext(ctx, &count, query, organizationID); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("QUO-%05d", count+1), nil
+}
+
+func (r *QuoteRepository) ExpireSent(ctx context.Context, asOf time.Time) (int, error) {
+	query := `
+        UPDATE quotes SET status = 'expired', updated_at = CURRENT_TIMESTAMP
+        WHERE status = 'sent' AND expiry_date < $1::date
+    `
+	result, err := r.db.ExecContext(ctx, query, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("expiring quotes: %w", err)
+	}
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(expired), nil
+}
@@ -0,0 +1,85 @@
+// internal/infrastructure/database/postgres/recurring_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/recurring"
+)
+
+type RecurringScheduleRepository struct {
+	db *sqlx.DB
+}
+
+func NewRecurringScheduleRepository(db *sqlx.DB) *RecurringScheduleRepository {
+	return &RecurringScheduleRepository{db: db}
+}
+
+const recurringScheduleColumns = `id, organization_id, user_id, client_id, name, rule, start_date, end_date, mode, active,
+               payment_terms_days, next_run_date, last_issue_date, template, created_at, updated_at`
+
+func (r *RecurringScheduleRepository) Create(ctx context.Context, s *recurring.Schedule) error {
+	query := `
+        INSERT INTO recurring_schedules (id, organization_id, user_id, client_id, name, rule, start_date, end_date,
+                                         mode, active, payment_terms_days, next_run_date, last_issue_date,
+                                         template, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+    `
+	_, err := r.db.ExecContext(ctx, query, s.ID, s.OrganizationID, s.UserID, s.ClientID, s.Name, s.Rule,
+		s.StartDate, s.EndDate, s.Mode, s.Active, s.PaymentTermsDays, s.NextRunDate, s.LastIssueDate, s.Template,
+		s.CreatedAt, s.UpdatedAt)
+	return err
+}
+
+func (r *RecurringScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*recurring.Schedule, error) {
+	var schedule recurring.Schedule
+	query := `SELECT ` + recurringScheduleColumns + ` FROM recurring_schedules WHERE id = $1`
+	if err := r.db.GetContext(ctx, &schedule, query, id); err != nil {
+		return nil, fmt.Errorf("getting recurring schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+func (r *RecurringScheduleRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]recurring.Schedule, error) {
+	var schedules []recurring.Schedule
+	query := `SELECT ` + recurringScheduleColumns + `
+              FROM recurring_schedules WHERE organization_id = $1 ORDER BY name`
+	if err := r.db.SelectContext(ctx, &schedules, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting recurring schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func (r *RecurringScheduleRepository) GetDue(ctx context.Context, asOf time.Time) ([]recurring.Schedule, error) {
+	var schedules []recurring.Schedule
+	query := `SELECT ` + recurringScheduleColumns + `
+              FROM recurring_schedules
+              WHERE active AND next_run_date IS NOT NULL AND next_run_date <= $1
+              ORDER BY next_run_date`
+	if err := r.db.SelectContext(ctx, &schedules, query, asOf); err != nil {
+		return nil, fmt.Errorf("getting due recurring schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func (r *RecurringScheduleRepository) Update(ctx context.Context, s *recurring.Schedule) error {
+	query := `
+        UPDATE recurring_schedules SET client_id = $2, name = $3, rule = $4, start_date = $5, end_date = $6,
+                                       mode = $7, active = $8, payment_terms_days = $9, next_run_date = $10,
+                                       last_issue_date = $11, template = $12, updated_at = $13
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, s.ID, s.ClientID, s.Name, s.Rule, s.StartDate, s.EndDate, s.Mode,
+		s.Active, s.PaymentTermsDays, s.NextRunDate, s.LastIssueDate, s.Template, s.UpdatedAt)
+	return err
+}
+
+func (r *RecurringScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM recurring_schedules WHERE id = $1", id)
+	return err
+}
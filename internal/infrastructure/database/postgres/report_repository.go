@@ -0,0 +1,112 @@
+// internal/infrastructure/database/postgres/report_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/report"
+)
+
+type ReportRepository struct {
+	db *sqlx.DB
+}
+
+func NewReportRepository(db *sqlx.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+func (r *ReportRepository) RevenueByCurrency(ctx context.Context, organizationID uuid.UUID, baseCurrency string, from, to time.Time) ([]report.CurrencyRevenue, error) {
+	query := `
+        WITH invoiced AS (
+            SELECT currency, SUM(total) AS invoiced, SUM(total * exchange_rate) AS invoiced_base
+            FROM invoices
+            WHERE organization_id = $1 AND base_currency = $2 AND issue_date BETWEEN $3 AND $4
+              AND status NOT IN ('draft', 'cancelled')
+            GROUP BY currency
+        ), collected AS (
+            SELECT p.currency, SUM(p.amount) AS collected, SUM(p.amount * p.exchange_rate) AS collected_base,
+                   SUM(p.fx_gain_loss) AS fx_gain_loss
+            FROM payments p
+            JOIN invoices i ON i.id = p.invoice_id
+            WHERE i.organization_id = $1 AND i.base_currency = $2 AND p.paid_at BETWEEN $3 AND $4
+            GROUP BY p.currency
+        )
+        SELECT COALESCE(i.currency, c.currency) AS currency,
+               COALESCE(i.invoiced, 0) AS invoiced, COALESCE(i.invoiced_base, 0) AS invoiced_base,
+               COALESCE(c.collected, 0) AS collected, COALESCE(c.collected_base, 0) AS collected_base,
+               COALESCE(c.fx_gain_loss, 0) AS fx_gain_loss
+        FROM invoiced i
+        FULL OUTER JOIN collected c ON c.currency = i.currency
+        ORDER BY currency
+    `
+	var rows []report.CurrencyRevenue
+	if err := r.db.SelectContext(ctx, &rows, query, organizationID, baseCurrency, from, to); err != nil {
+		return nil, fmt.Errorf("getting revenue by currency: %w", err)
+	}
+	return rows, nil
+}
+
+// timeGroups are the id and name columns each grouping totals by, over
+// time_entries as te, projects as p, clients as c and users as u
+var timeGroups = map[report.Grouping][2]string{
+	report.GroupByMember:  {"te.user_id", "COALESCE(NULLIF(u.full_name, ''), u.email)"},
+	report.GroupByProject: {"te.project_id", "COALESCE(p.name, 'No project')"},
+	report.GroupByClient:  {"p.client_id", "COALESCE(c.name, 'No client')"},
+}
+
+var timeIntervals = map[report.Interval]string{
+	report.IntervalNone: "NULL::date",
+	report.IntervalDay:  "te.date",
+	report.IntervalWeek: "date_trunc('week', te.date)::date",
+}
+
+func (r *ReportRepository) TimeByGroup(ctx context.Context, organizationID uuid.UUID, filters report.TimeFilters) ([]report.TimeRow, error) {
+	group, ok := timeGroups[filters.GroupBy]
+	if !ok {
+		return nil, report.ErrInvalidGrouping
+	}
+	period, ok := timeIntervals[filters.Interval]
+	if !ok {
+		return nil, report.ErrInvalidInterval
+	}
+
+	where := "te.organization_id = $1 AND te.date BETWEEN $2 AND $3"
+	args := []interface{}{organizationID, filters.From, filters.To}
+	if filters.UserID != nil {
+		args = append(args, *filters.UserID)
+		where += fmt.Sprintf(" AND te.user_id = $%d", len(args))
+	}
+	if filters.ProjectID != nil {
+		args = append(args, *filters.ProjectID)
+		where += fmt.Sprintf(" AND te.project_id = $%d", len(args))
+	}
+	if filters.ClientID != nil {
+		args = append(args, *filters.ClientID)
+		where += fmt.Sprintf(" AND p.client_id = $%d", len(args))
+	}
+
+	query := `
+        SELECT ` + period + ` AS period, ` + group[0] + ` AS group_id, ` + group[1] + ` AS group_name,
+               COALESCE(SUM(te.hours), 0) AS hours,
+               COALESCE(SUM(te.hours) FILTER (WHERE te.is_billable), 0) AS billable_hours,
+               COALESCE(SUM(te.hours * ` + effectiveRateSQL + `) FILTER (WHERE te.is_billable), 0) AS billable_amount
+        FROM time_entries te
+        JOIN users u ON u.id = te.user_id
+        LEFT JOIN project_tasks t ON t.id = te.task_id
+        LEFT JOIN projects p ON p.id = te.project_id
+        LEFT JOIN clients c ON c.id = p.client_id
+        WHERE ` + where + `
+        GROUP BY 1, 2, 3
+        ORDER BY group_name, group_id NULLS LAST, period
+    `
+	var rows []report.TimeRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("getting time by group: %w", err)
+	}
+	return rows, nil
+}
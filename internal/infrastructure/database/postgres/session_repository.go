@@ -0,0 +1,137 @@
+// internal/infrastructure/database/postgres/session_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/session"
+)
+
+const sessionColumns = `id, user_id, organization_id, user_agent, ip_address, created_at, last_used_at, expires_at,
+                        max_expires_at, revoked_at, mfa_verified`
+
+type SessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, s *session.Session, token *session.RefreshToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO sessions (` + sessionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	if _, err := tx.ExecContext(ctx, query, s.ID, s.UserID, s.OrganizationID, s.UserAgent, s.IPAddress,
+		s.CreatedAt, s.LastUsedAt, s.ExpiresAt, s.MaxExpiresAt, s.RevokedAt, s.MFAVerified); err != nil {
+		return err
+	}
+	if err := insertRefreshToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*session.Session, error) {
+	var s session.Session
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+	if err := r.db.GetContext(ctx, &s, query, id); err != nil {
+		return nil, fmt.Errorf("getting session: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	var token session.RefreshToken
+	query := `SELECT id, session_id, token_hash, created_at, used_at FROM refresh_tokens WHERE token_hash = $1`
+	if err := r.db.GetContext(ctx, &token, query, tokenHash); err != nil {
+		return nil, fmt.Errorf("getting refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, s *session.Session, usedID uuid.UUID, next *session.RefreshToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Only one of two refreshes racing with the same token gets to use it
+	result, err := tx.ExecContext(ctx,
+		`UPDATE refresh_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, usedID, s.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("using refresh token: %w", err)
+	}
+	if used, err := result.RowsAffected(); err != nil {
+		return err
+	} else if used == 0 {
+		return session.ErrRefreshTokenReused
+	}
+
+	if err := insertRefreshToken(ctx, tx, next); err != nil {
+		return err
+	}
+	if err := updateSession(ctx, tx, s); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SessionRepository) Update(ctx context.Context, s *session.Session) error {
+	return updateSession(ctx, r.db, s)
+}
+
+func (r *SessionRepository) GetActive(ctx context.Context, userID uuid.UUID, now time.Time) ([]session.Session, error) {
+	var sessions []session.Session
+	query := `
+        SELECT ` + sessionColumns + `
+        FROM sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+        ORDER BY last_used_at DESC
+    `
+	if err := r.db.SelectContext(ctx, &sessions, query, userID, now); err != nil {
+		return nil, fmt.Errorf("getting sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE sessions SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`, id, at)
+	return err
+}
+
+func (r *SessionRepository) RevokeAll(ctx context.Context, userID, except uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE sessions SET revoked_at = $2 WHERE user_id = $1 AND id <> $3 AND revoked_at IS NULL`, userID, at, except)
+	return err
+}
+
+func insertRefreshToken(ctx context.Context, db sqlx.ExecerContext, t *session.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (id, session_id, token_hash, created_at, used_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := db.ExecContext(ctx, query, t.ID, t.SessionID, t.TokenHash, t.CreatedAt, t.UsedAt)
+	return err
+}
+
+func updateSession(ctx context.Context, db sqlx.ExecerContext, s *session.Session) error {
+	query := `
+        UPDATE sessions
+        SET organization_id = $2, user_agent = $3, ip_address = $4, last_used_at = $5, expires_at = $6,
+            mfa_verified = $7
+        WHERE id = $1
+    `
+	_, err := db.ExecContext(ctx, query, s.ID, s.OrganizationID, s.UserAgent, s.IPAddress, s.LastUsedAt, s.ExpiresAt,
+		s.MFAVerified)
+	return err
+}
@@ -0,0 +1,78 @@
+// internal/infrastructure/database/postgres/share_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/portal"
+)
+
+const shareColumns = `s.id, s.invoice_id, s.user_id, s.expires_at, s.revoked_at, s.created_at,
+                      (SELECT COUNT(*) FROM invoice_views v WHERE v.share_id = s.id) AS view_count,
+                      (SELECT MAX(v.created_at) FROM invoice_views v WHERE v.share_id = s.id) AS last_viewed_at`
+
+type ShareRepository struct {
+	db *sqlx.DB
+}
+
+func NewShareRepository(db *sqlx.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+func (r *ShareRepository) Create(ctx context.Context, s *portal.Share) error {
+	query := `
+        INSERT INTO invoice_shares (id, invoice_id, user_id, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.ExecContext(ctx, query, s.ID, s.InvoiceID, s.UserID, s.ExpiresAt, s.CreatedAt)
+	return err
+}
+
+func (r *ShareRepository) GetByID(ctx context.Context, id uuid.UUID) (*portal.Share, error) {
+	var share portal.Share
+	query := `SELECT ` + shareColumns + ` FROM invoice_shares s WHERE s.id = $1`
+	if err := r.db.GetContext(ctx, &share, query, id); err != nil {
+		return nil, fmt.Errorf("getting share: %w", err)
+	}
+	return &share, nil
+}
+
+func (r *ShareRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]portal.Share, error) {
+	var shares []portal.Share
+	query := `SELECT ` + shareColumns + ` FROM invoice_shares s WHERE s.invoice_id = $1 ORDER BY s.created_at DESC`
+	if err := r.db.SelectContext(ctx, &shares, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting shares: %w", err)
+	}
+	return shares, nil
+}
+
+func (r *ShareRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE invoice_shares SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`,
+		id, at)
+	return err
+}
+
+func (r *ShareRepository) RecordView(ctx context.Context, v *portal.View) error {
+	query := `
+        INSERT INTO invoice_views (id, share_id, invoice_id, resource, ip_address, user_agent, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err := r.db.ExecContext(ctx, query, v.ID, v.ShareID, v.InvoiceID, v.Resource, v.IPAddress, v.UserAgent,
+		v.CreatedAt)
+	return err
+}
+
+func (r *ShareRepository) GetViews(ctx context.Context, invoiceID uuid.UUID) ([]portal.View, error) {
+	var views []portal.View
+	query := `SELECT id, share_id, invoice_id, resource, ip_address, user_agent, created_at
+              FROM invoice_views WHERE invoice_id = $1 ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &views, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting invoice views: %w", err)
+	}
+	return views, nil
+}
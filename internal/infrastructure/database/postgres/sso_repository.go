@@ -0,0 +1,88 @@
+// internal/infrastructure/database/postgres/sso_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/sso"
+)
+
+const identityColumns = `id, user_id, provider, subject, email, created_at, last_login_at`
+
+type SSORepository struct {
+	db *sqlx.DB
+}
+
+func NewSSORepository(db *sqlx.DB) *SSORepository {
+	return &SSORepository{db: db}
+}
+
+func (r *SSORepository) CreateLogin(ctx context.Context, login *sso.Login) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM oidc_logins WHERE expires_at < $1`, login.CreatedAt); err != nil {
+		return fmt.Errorf("clearing expired logins: %w", err)
+	}
+
+	query := `
+        INSERT INTO oidc_logins (state_hash, provider, nonce, code_verifier, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	if _, err := tx.ExecContext(ctx, query, login.StateHash, login.Provider, login.Nonce, login.CodeVerifier,
+		login.ExpiresAt, login.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SSORepository) TakeLogin(ctx context.Context, stateHash string) (*sso.Login, error) {
+	var login sso.Login
+	query := `
+        DELETE FROM oidc_logins WHERE state_hash = $1
+        RETURNING state_hash, provider, nonce, code_verifier, expires_at, created_at
+    `
+	if err := r.db.GetContext(ctx, &login, query, stateHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sso.ErrInvalidState
+		}
+		return nil, fmt.Errorf("taking login: %w", err)
+	}
+	return &login, nil
+}
+
+func (r *SSORepository) GetIdentity(ctx context.Context, provider, subject string) (*sso.Identity, error) {
+	var identity sso.Identity
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE provider = $1 AND subject = $2`
+	if err := r.db.GetContext(ctx, &identity, query, provider, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sso.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("getting identity: %w", err)
+	}
+	return &identity, nil
+}
+
+func (r *SSORepository) CreateIdentity(ctx context.Context, identity *sso.Identity) error {
+	query := `INSERT INTO user_identities (` + identityColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.ExecContext(ctx, query, identity.ID, identity.UserID, identity.Provider, identity.Subject,
+		identity.Email, identity.CreatedAt, identity.LastLoginAt)
+	return err
+}
+
+func (r *SSORepository) TouchIdentity(ctx context.Context, id uuid.UUID, email string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user_identities SET email = $2, last_login_at = $3 WHERE id = $1`,
+		id, email, at)
+	return err
+}
@@ -0,0 +1,64 @@
+// internal/infrastructure/database/postgres/taxcode_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type TaxCodeRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaxCodeRepository(db *sqlx.DB) *TaxCodeRepository {
+	return &TaxCodeRepository{db: db}
+}
+
+func (r *TaxCodeRepository) Create(ctx context.Context, code *invoice.TaxCode) error {
+	query := `
+        INSERT INTO tax_codes (id, organization_id, user_id, name, rate, is_compound, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := r.db.ExecContext(ctx, query, code.ID, code.OrganizationID, code.UserID, code.Name, code.Rate,
+		code.IsCompound, code.CreatedAt, code.UpdatedAt)
+	return err
+}
+
+func (r *TaxCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*invoice.TaxCode, error) {
+	var code invoice.TaxCode
+	query := `SELECT id, organization_id, user_id, name, rate, is_compound, created_at, updated_at
+              FROM tax_codes WHERE id = $1`
+	if err := r.db.GetContext(ctx, &code, query, id); err != nil {
+		return nil, fmt.Errorf("getting tax code: %w", err)
+	}
+	return &code, nil
+}
+
+func (r *TaxCodeRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]invoice.TaxCode, error) {
+	var codes []invoice.TaxCode
+	query := `SELECT id, organization_id, user_id, name, rate, is_compound, created_at, updated_at
+              FROM tax_codes WHERE organization_id = $1 ORDER BY name`
+	if err := r.db.SelectContext(ctx, &codes, query, organizationID); err != nil {
+		return nil, fmt.Errorf("getting tax codes: %w", err)
+	}
+	return codes, nil
+}
+
+func (r *TaxCodeRepository) Update(ctx context.Context, code *invoice.TaxCode) error {
+	query := `
+        UPDATE tax_codes SET name = $2, rate = $3, is_compound = $4, updated_at = $5
+        WHERE id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, code.ID, code.Name, code.Rate, code.IsCompound, code.UpdatedAt)
+	return err
+}
+
+func (r *TaxCodeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM tax_codes WHERE id = $1", id)
+	return err
+}
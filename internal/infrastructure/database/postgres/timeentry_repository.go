@@ -4,6 +4,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,18 +22,40 @@ func NewTimeEntryRepository(db *sqlx.DB) *TimeEntryRepository {
 }
 
 func (r *TimeEntryRepository) Create(ctx context.Context, entry *timeentry.TimeEntry) error {
+	return insertTimeEntry(ctx, r.db, entry)
+}
+
+func insertTimeEntry(ctx context.Context, db sqlx.ExecerContext, entry *timeentry.TimeEntry) error {
 	query := `
-        INSERT INTO time_entries (id, user_id, invoice_id, description, hours, hourly_rate, date,
-                                jira_issue_key, jira_worklog_id, jira_synced_at, is_billable, is_invoiced,
-                                created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        INSERT INTO time_entries (id, organization_id, user_id, project_id, task_id, invoice_id, description, hours,
+                                hourly_rate, date, started_at, ended_at, jira_issue_key, jira_worklog_id,
+                                jira_synced_at, is_billable, is_invoiced, external_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
     `
-	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.UserID, entry.InvoiceID, entry.Description,
-		entry.Hours, entry.HourlyRate, entry.Date, entry.JiraIssueKey, entry.JiraWorklogID,
-		entry.JiraSyncedAt, entry.IsBillable, entry.IsInvoiced, entry.CreatedAt, entry.UpdatedAt)
+	_, err := db.ExecContext(ctx, query, entry.ID, entry.OrganizationID, entry.UserID, entry.ProjectID, entry.TaskID, entry.InvoiceID,
+		entry.Description,
+		entry.Hours, entry.HourlyRate, entry.Date, entry.StartedAt, entry.EndedAt, entry.JiraIssueKey,
+		entry.JiraWorklogID, entry.JiraSyncedAt, entry.IsBillable, entry.IsInvoiced, entry.ExternalID,
+		entry.CreatedAt, entry.UpdatedAt)
 	return err
 }
 
+func (r *TimeEntryRepository) CreateMany(ctx context.Context, entries []timeentry.TimeEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range entries {
+		if err := insertTimeEntry(ctx, tx, &entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (r *TimeEntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*timeentry.TimeEntry, error) {
 	var entry timeentry.TimeEntry
 	query := `SELECT * FROM time_entries WHERE id = $1`
@@ -42,25 +65,140 @@ func (r *TimeEntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*timee
 	return &entry, nil
 }
 
-func (r *TimeEntryRepository) GetByUserID(ctx context.Context, userID uuid.UUID, startDate string, endDate string) ([]timeentry.TimeEntry, error) {
-	var entries []timeentry.TimeEntry
+// effectiveRateSQL is the rate an entry is billed at, following the same
+// hierarchy as hourlyrate.Service.Resolve: the entry's own rate, then for its
+// task, project, client and the member's default a dated rate in effect on
+// the entry's date, falling back to the task's or project's own rate. It expects
+// time_entries as te, project_tasks as t and projects as p.
+const effectiveRateSQL = `
+        COALESCE(te.hourly_rate,
+                 (SELECT hr.hourly_rate FROM hourly_rates hr
+                  WHERE hr.organization_id = te.organization_id AND hr.scope = 'task' AND hr.scope_id = te.task_id
+                    AND hr.effective_date <= te.date ORDER BY hr.effective_date DESC LIMIT 1),
+                 t.hourly_rate,
+                 (SELECT hr.hourly_rate FROM hourly_rates hr
+                  WHERE hr.organization_id = te.organization_id AND hr.scope = 'project' AND hr.scope_id = te.project_id
+                    AND hr.effective_date <= te.date ORDER BY hr.effective_date DESC LIMIT 1),
+                 p.hourly_rate,
+                 (SELECT hr.hourly_rate FROM hourly_rates hr
+                  WHERE hr.organization_id = te.organization_id AND hr.scope = 'client' AND hr.scope_id = p.client_id
+                    AND hr.effective_date <= te.date ORDER BY hr.effective_date DESC LIMIT 1),
+                 (SELECT hr.hourly_rate FROM hourly_rates hr
+                  WHERE hr.organization_id = te.organization_id AND hr.scope = 'user' AND hr.scope_id = te.user_id
+                    AND hr.effective_date <= te.date ORDER BY hr.effective_date DESC LIMIT 1))`
 
-	// If no dates provided, default to current date
-	if startDate == "" && endDate == "" {
-		now := time.Now().Format("2006-01-02")
-		startDate = now
-		endDate = now
+func (r *TimeEntryRepository) List(ctx context.Context, organizationID uuid.UUID, filters timeentry.ListFilters) ([]timeentry.TimeEntry, error) {
+	where, args := timeEntryFilters(organizationID, filters)
+
+	column := string(filters.Sort)
+	direction, comparison := "DESC", "<"
+	if filters.Ascending {
+		direction, comparison = "ASC", ">"
 	}
 
-	query := `SELECT * FROM time_entries WHERE user_id = $1 AND date BETWEEN $2 AND $3 ORDER BY date DESC`
+	if c := filters.After; c != nil {
+		var value interface{}
+		switch filters.Sort {
+		case timeentry.SortByHours:
+			value = c.Hours
+		case timeentry.SortByCreatedAt:
+			value = c.CreatedAt
+		default:
+			value = c.Date
+		}
+		args = append(args, value, c.ID)
+		where += fmt.Sprintf(" AND (te.%s, te.id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args))
+	}
 
-	if err := r.db.SelectContext(ctx, &entries, query, userID, startDate, endDate); err != nil {
-		return nil, fmt.Errorf("getting time entries: %w", err)
+	query := fmt.Sprintf(`SELECT te.* FROM time_entries te WHERE %s ORDER BY te.%s %s, te.id %s`,
+		where, column, direction, direction)
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
 	}
 
+	var entries []timeentry.TimeEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("getting time entries: %w", err)
+	}
 	return entries, nil
 }
 
+func (r *TimeEntryRepository) Totals(ctx context.Context, organizationID uuid.UUID, filters timeentry.ListFilters) (*timeentry.Totals, error) {
+	where, args := timeEntryFilters(organizationID, filters)
+	query := `
+        SELECT COUNT(*) AS count,
+               COALESCE(SUM(te.hours), 0) AS hours,
+               COALESCE(SUM(te.hours) FILTER (WHERE te.is_billable), 0) AS billable_hours,
+               COALESCE(SUM(te.hours * ` + effectiveRateSQL + `) FILTER (WHERE te.is_billable), 0) AS billable_amount
+        FROM time_entries te
+        LEFT JOIN project_tasks t ON t.id = te.task_id
+        LEFT JOIN projects p ON p.id = te.project_id
+        WHERE ` + where
+
+	var totals timeentry.Totals
+	if err := r.db.GetContext(ctx, &totals, query, args...); err != nil {
+		return nil, fmt.Errorf("totalling time entries: %w", err)
+	}
+	return &totals, nil
+}
+
+// timeEntryFilters builds the WHERE clause for the filters, on time_entries
+// as te
+func timeEntryFilters(organizationID uuid.UUID, filters timeentry.ListFilters) (string, []interface{}) {
+	where := "te.organization_id = $1"
+	args := []interface{}{organizationID}
+
+	if filters.UserID != nil {
+		args = append(args, *filters.UserID)
+		where += fmt.Sprintf(" AND te.user_id = $%d", len(args))
+	}
+	if filters.DateFrom != nil {
+		args = append(args, *filters.DateFrom)
+		where += fmt.Sprintf(" AND te.date >= $%d", len(args))
+	}
+	if filters.DateTo != nil {
+		args = append(args, *filters.DateTo)
+		where += fmt.Sprintf(" AND te.date <= $%d", len(args))
+	}
+	if filters.IsBillable != nil {
+		args = append(args, *filters.IsBillable)
+		where += fmt.Sprintf(" AND te.is_billable = $%d", len(args))
+	}
+	if filters.IsInvoiced != nil {
+		args = append(args, *filters.IsInvoiced)
+		where += fmt.Sprintf(" AND te.is_invoiced = $%d", len(args))
+	}
+	if filters.JiraIssueKey != nil {
+		args = append(args, *filters.JiraIssueKey)
+		where += fmt.Sprintf(" AND UPPER(te.jira_issue_key) = UPPER($%d)", len(args))
+	}
+	if filters.JiraSynced != nil {
+		if *filters.JiraSynced {
+			where += " AND te.jira_worklog_id IS NOT NULL"
+		} else {
+			where += " AND te.jira_worklog_id IS NULL"
+		}
+	}
+	if filters.ProjectID != nil {
+		args = append(args, *filters.ProjectID)
+		where += fmt.Sprintf(" AND te.project_id = $%d", len(args))
+	}
+	if filters.ClientID != nil {
+		args = append(args, *filters.ClientID)
+		where += fmt.Sprintf(" AND te.project_id IN (SELECT id FROM projects WHERE client_id = $%d)", len(args))
+	}
+	if filters.Search != "" {
+		args = append(args, "%"+likeEscaper.Replace(filters.Search)+"%")
+		where += fmt.Sprintf(" AND te.description ILIKE $%d", len(args))
+	}
+
+	return where, args
+}
+
+// likeEscaper makes LIKE wildcards in user input match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func (r *TimeEntryRepository) GetByJiraWorklogID(ctx context.Context, worklogID string) (*timeentry.TimeEntry, error) {
 	var entry timeentry.TimeEntry
 	query := `SELECT * FROM time_entries WHERE jira_worklog_id = $1`
@@ -70,16 +208,136 @@ func (r *TimeEntryRepository) GetByJiraWorklogID(ctx context.Context, worklogID
 	return &entry, nil
 }
 
+func (r *TimeEntryRepository) GetExternalIDs(ctx context.Context, organizationID, userID uuid.UUID, externalIDs []string) ([]string, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+
+	var existing []string
+	query := `SELECT external_id FROM time_entries
+              WHERE organization_id = $1 AND user_id = $2 AND external_id = ANY($3)`
+	if err := r.db.SelectContext(ctx, &existing, query, organizationID, userID, externalIDs); err != nil {
+		return nil, fmt.Errorf("getting external IDs: %w", err)
+	}
+	return existing, nil
+}
+
 func (r *TimeEntryRepository) Update(ctx context.Context, entry *timeentry.TimeEntry) error {
+	return updateTimeEntry(ctx, r.db, entry)
+}
+
+func updateTimeEntry(ctx context.Context, db sqlx.ExecerContext, entry *timeentry.TimeEntry) error {
 	query := `
-        UPDATE time_entries SET description = $2, hours = $3, jira_worklog_id = $4, updated_at = $5, date = $6, jira_issue_key = $7
+        UPDATE time_entries SET description = $2, hours = $3, jira_worklog_id = $4, updated_at = $5, date = $6, jira_issue_key = $7,
+                                project_id = $8, task_id = $9, is_billable = $10, hourly_rate = $11, jira_synced_at = $12
         WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.Description, entry.Hours, entry.JiraWorklogID, entry.UpdatedAt, entry.Date, entry.JiraIssueKey)
+	_, err := db.ExecContext(ctx, query, entry.ID, entry.Description, entry.Hours, entry.JiraWorklogID, entry.UpdatedAt, entry.Date, entry.JiraIssueKey,
+		entry.ProjectID, entry.TaskID, entry.IsBillable, entry.HourlyRate, entry.JiraSyncedAt)
 	return err
 }
 
+func (r *TimeEntryRepository) UpdateMany(ctx context.Context, entries []timeentry.TimeEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range entries {
+		if err := updateTimeEntry(ctx, tx, &entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TimeEntryRepository) GetUninvoiced(ctx context.Context, organizationID uuid.UUID, projectIDs []uuid.UUID, from, to *time.Time) ([]timeentry.TimeEntry, error) {
+	query := `
+        SELECT * FROM time_entries
+        WHERE organization_id = $1 AND is_billable AND NOT is_invoiced AND project_id::text = ANY($2)
+          AND timesheet_id IN (SELECT id FROM timesheets WHERE status = 'approved')
+    `
+	args := []interface{}{organizationID, uuidStrings(projectIDs)}
+	if from != nil {
+		args = append(args, *from)
+		query += fmt.Sprintf(" AND date >= $%d", len(args))
+	}
+	if to != nil {
+		args = append(args, *to)
+		query += fmt.Sprintf(" AND date <= $%d", len(args))
+	}
+	query += " ORDER BY date, created_at"
+
+	var entries []timeentry.TimeEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("getting uninvoiced time entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *TimeEntryRepository) MarkInvoiced(ctx context.Context, entries []timeentry.TimeEntry, invoiceID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE time_entries SET is_invoiced = true, invoice_id = $2, hourly_rate = $3, rate_source = $4, updated_at = NOW()
+        WHERE id = $1 AND (invoice_id IS NULL OR invoice_id = $2)
+    `
+	for _, entry := range entries {
+		result, err := tx.ExecContext(ctx, query, entry.ID, invoiceID, entry.HourlyRate, entry.RateSource)
+		if err != nil {
+			return err
+		}
+		if marked, err := result.RowsAffected(); err != nil {
+			return err
+		} else if marked == 0 {
+			return timeentry.ErrAlreadyInvoiced
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TimeEntryRepository) GetByInvoiceID(ctx context.Context, invoiceID uuid.UUID) ([]timeentry.TimeEntry, error) {
+	var entries []timeentry.TimeEntry
+	query := `SELECT * FROM time_entries WHERE invoice_id = $1 ORDER BY date, created_at`
+	if err := r.db.SelectContext(ctx, &entries, query, invoiceID); err != nil {
+		return nil, fmt.Errorf("getting invoiced time entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *TimeEntryRepository) ReleaseInvoiced(ctx context.Context, ids []uuid.UUID) error {
+	query := `
+        UPDATE time_entries
+        SET is_invoiced = false, invoice_id = NULL, rate_source = NULL, updated_at = NOW(),
+            hourly_rate = CASE WHEN rate_source = 'entry' THEN hourly_rate END
+        WHERE id::text = ANY($1)
+    `
+	_, err := r.db.ExecContext(ctx, query, uuidStrings(ids))
+	return err
+}
+
+// uuidStrings lets a list of IDs be passed as a text array
+func uuidStrings(ids []uuid.UUID) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return s
+}
+
 func (r *TimeEntryRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.db.ExecContext(ctx, "DELETE FROM time_entries WHERE id = $1", id)
 	return err
 }
+
+func (r *TimeEntryRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM time_entries WHERE id::text = ANY($1)", uuidStrings(ids))
+	return err
+}
@@ -0,0 +1,88 @@
+// internal/infrastructure/database/postgres/timer_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/timeentry"
+)
+
+const timerColumns = `id, organization_id, user_id, project_id, task_id, description, is_billable, jira_issue_key, started_at,
+                      paused_at, paused_seconds, created_at, updated_at`
+
+type TimerRepository struct {
+	db *sqlx.DB
+}
+
+func NewTimerRepository(db *sqlx.DB) *TimerRepository {
+	return &TimerRepository{db: db}
+}
+
+func (r *TimerRepository) Create(ctx context.Context, t *timeentry.Timer) error {
+	query := `
+        INSERT INTO timers (` + timerColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    `
+	_, err := r.db.ExecContext(ctx, query, t.ID, t.OrganizationID, t.UserID, t.ProjectID, t.TaskID, t.Description, t.IsBillable, t.JiraIssueKey,
+		t.StartedAt, t.PausedAt, t.PausedFor, t.CreatedAt, t.UpdatedAt)
+	return err
+}
+
+func (r *TimerRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*timeentry.Timer, error) {
+	var timer timeentry.Timer
+	query := `SELECT ` + timerColumns + ` FROM timers WHERE user_id = $1`
+	if err := r.db.GetContext(ctx, &timer, query, userID); err != nil {
+		return nil, fmt.Errorf("getting timer: %w", err)
+	}
+	return &timer, nil
+}
+
+func (r *TimerRepository) Update(ctx context.Context, t *timeentry.Timer) error {
+	query := `UPDATE timers SET paused_at = $2, paused_seconds = $3, updated_at = $4 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, t.ID, t.PausedAt, t.PausedFor, t.UpdatedAt)
+	return err
+}
+
+func (r *TimerRepository) Stop(ctx context.Context, timerID uuid.UUID, entry *timeentry.TimeEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM timers WHERE id = $1", timerID)
+	if err != nil {
+		return fmt.Errorf("deleting timer: %w", err)
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if deleted == 0 {
+		return timeentry.ErrNoTimer
+	}
+
+	if err := insertTimeEntry(ctx, tx, entry); err != nil {
+		return fmt.Errorf("creating time entry: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *TimerRepository) GetStale(ctx context.Context, asOf time.Time, limit time.Duration) ([]timeentry.Timer, error) {
+	query := `
+        SELECT ` + timerColumns + `
+        FROM timers
+        WHERE COALESCE(paused_at, $1) - started_at - make_interval(secs => paused_seconds)
+              > make_interval(secs => $2)
+        ORDER BY started_at
+    `
+	var timers []timeentry.Timer
+	if err := r.db.SelectContext(ctx, &timers, query, asOf, limit.Seconds()); err != nil {
+		return nil, fmt.Errorf("getting stale timers: %w", err)
+	}
+	return timers, nil
+}
@@ -0,0 +1,151 @@
+// internal/infrastructure/database/postgres/timesheet_repository.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/invoice-app-be/internal/domain/timesheet"
+)
+
+const timesheetColumns = `id, organization_id, user_id, week_start, status, approver_id, reviewer_email, submitted_at,
+                          reviewed_at, reviewed_by, comment, created_at, updated_at`
+
+type TimesheetRepository struct {
+	db *sqlx.DB
+}
+
+func NewTimesheetRepository(db *sqlx.DB) *TimesheetRepository {
+	return &TimesheetRepository{db: db}
+}
+
+func (r *TimesheetRepository) GetByID(ctx context.Context, id uuid.UUID) (*timesheet.Timesheet, error) {
+	var ts timesheet.Timesheet
+	query := `SELECT ` + timesheetColumns + ` FROM timesheets WHERE id = $1`
+	if err := r.db.GetContext(ctx, &ts, query, id); err != nil {
+		return nil, fmt.Errorf("getting timesheet: %w", err)
+	}
+	return &ts, nil
+}
+
+func (r *TimesheetRepository) GetByWeek(ctx context.Context, organizationID, userID uuid.UUID, weekStart time.Time) (*timesheet.Timesheet, error) {
+	var ts timesheet.Timesheet
+	query := `SELECT ` + timesheetColumns + `
+              FROM timesheets WHERE organization_id = $1 AND user_id = $2 AND week_start = $3`
+	if err := r.db.GetContext(ctx, &ts, query, organizationID, userID, weekStart); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, timesheet.ErrTimesheetNotFound
+		}
+		return nil, fmt.Errorf("getting timesheet: %w", err)
+	}
+	return &ts, nil
+}
+
+func (r *TimesheetRepository) GetByUserID(ctx context.Context, organizationID, userID uuid.UUID, filters timesheet.ListFilters) ([]timesheet.Timesheet, error) {
+	query := `SELECT ` + timesheetColumns + ` FROM timesheets WHERE organization_id = $1 AND user_id = $2`
+	args := []interface{}{organizationID, userID}
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	query += " ORDER BY week_start DESC"
+
+	var sheets []timesheet.Timesheet
+	if err := r.db.SelectContext(ctx, &sheets, query, args...); err != nil {
+		return nil, fmt.Errorf("getting timesheets: %w", err)
+	}
+	return sheets, nil
+}
+
+func (r *TimesheetRepository) GetPending(ctx context.Context, organizationID, approverID uuid.UUID, unassigned bool) ([]timesheet.Timesheet, error) {
+	query := `
+        SELECT ` + timesheetColumns + ` FROM timesheets
+        WHERE organization_id = $1 AND status = 'submitted'
+          AND (approver_id = $2 OR ($3 AND approver_id IS NULL AND reviewer_email IS NULL))
+        ORDER BY week_start, submitted_at
+    `
+	var sheets []timesheet.Timesheet
+	if err := r.db.SelectContext(ctx, &sheets, query, organizationID, approverID, unassigned); err != nil {
+		return nil, fmt.Errorf("getting pending timesheets: %w", err)
+	}
+	return sheets, nil
+}
+
+func (r *TimesheetRepository) Submit(ctx context.Context, ts *timesheet.Timesheet) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO timesheets (` + timesheetColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+        ON CONFLICT (id) DO UPDATE
+        SET status = EXCLUDED.status, approver_id = EXCLUDED.approver_id, reviewer_email = EXCLUDED.reviewer_email,
+            submitted_at = EXCLUDED.submitted_at, reviewed_at = EXCLUDED.reviewed_at,
+            reviewed_by = EXCLUDED.reviewed_by, comment = EXCLUDED.comment, updated_at = EXCLUDED.updated_at
+        WHERE timesheets.status NOT IN ('submitted', 'approved')
+    `
+	result, err := tx.ExecContext(ctx, query, ts.ID, ts.OrganizationID, ts.UserID, ts.WeekStart, ts.Status, ts.ApproverID,
+		ts.ReviewerEmail, ts.SubmittedAt, ts.ReviewedAt, ts.ReviewedBy, ts.Comment, ts.CreatedAt, ts.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if saved, err := result.RowsAffected(); err != nil {
+		return err
+	} else if saved == 0 {
+		return timesheet.ErrInvalidStatusTransition
+	}
+
+	// Entries moved out of the week since it was last submitted leave it
+	if _, err := tx.ExecContext(ctx, "UPDATE time_entries SET timesheet_id = NULL WHERE timesheet_id = $1",
+		ts.ID); err != nil {
+		return err
+	}
+
+	query = `
+        UPDATE time_entries SET timesheet_id = $1
+        WHERE organization_id = $2 AND user_id = $3 AND date >= $4 AND date < $4::date + 7
+    `
+	if _, err := tx.ExecContext(ctx, query, ts.ID, ts.OrganizationID, ts.UserID, ts.WeekStart); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TimesheetRepository) Transition(ctx context.Context, ts *timesheet.Timesheet, from timesheet.Status, submittedAt *time.Time) (bool, error) {
+	query := `
+        UPDATE timesheets
+        SET status = $2, approver_id = $3, submitted_at = $4, reviewed_at = $5, reviewed_by = $6, comment = $7,
+            updated_at = $8
+        WHERE id = $1 AND status = $9 AND submitted_at IS NOT DISTINCT FROM $10
+    `
+	result, err := r.db.ExecContext(ctx, query, ts.ID, ts.Status, ts.ApproverID, ts.SubmittedAt, ts.ReviewedAt,
+		ts.ReviewedBy, ts.Comment, ts.UpdatedAt, from, submittedAt)
+	if err != nil {
+		return false, err
+	}
+	changed, err := result.RowsAffected()
+	return changed > 0, err
+}
+
+func (r *TimesheetRepository) IsWeekLocked(ctx context.Context, organizationID, userID uuid.UUID, weekStart time.Time) (bool, error) {
+	var locked bool
+	query := `
+        SELECT EXISTS (SELECT 1 FROM timesheets
+                       WHERE organization_id = $1 AND user_id = $2 AND week_start = $3
+                         AND status IN ('submitted', 'approved'))
+    `
+	if err := r.db.GetContext(ctx, &locked, query, organizationID, userID, weekStart); err != nil {
+		return false, fmt.Errorf("checking timesheet: %w", err)
+	}
+	return locked, nil
+}
@@ -4,6 +4,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -11,6 +12,8 @@ import (
 	"github.com/invoice-app-be/internal/domain/user"
 )
 
+const userColumns = `id, email, password_hash, full_name, email_verified_at, created_at, updated_at`
+
 type UserRepository struct {
 	db *sqlx.DB
 }
@@ -21,16 +24,17 @@ func NewUserRepository(db *sqlx.DB) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
 	query := `
-        INSERT INTO users (id, email, password_hash, full_name, company_name, created_at, updated_at)
+        INSERT INTO users (` + userColumns + `)
         VALUES ($1, $2, $3, $4, $5, $6, $7)
     `
-	_, err := r.db.ExecContext(ctx, query, u.ID, u.Email, u.PasswordHash, u.FullName, u.CompanyName, u.CreatedAt, u.UpdatedAt)
+	_, err := r.db.ExecContext(ctx, query, u.ID, u.Email, u.PasswordHash, u.FullName, u.EmailVerifiedAt, u.CreatedAt,
+		u.UpdatedAt)
 	return err
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
 	var u user.User
-	query := `SELECT id, email, password_hash, full_name, company_name, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
 	if err := r.db.GetContext(ctx, &u, query, id); err != nil {
 		return nil, fmt.Errorf("getting user: %w", err)
 	}
@@ -39,7 +43,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User,
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	var u user.User
-	query := `SELECT id, email, password_hash, full_name, company_name, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 	if err := r.db.GetContext(ctx, &u, query, email); err != nil {
 		return nil, fmt.Errorf("getting user by email: %w", err)
 	}
@@ -49,9 +53,60 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
 	query := `
         UPDATE users 
-        SET email = $2, password_hash = $3, full_name = $4, company_name = $5, updated_at = $6
+        SET email = $2, password_hash = $3, full_name = $4, email_verified_at = $5, updated_at = $6
         WHERE id = $1
     `
-	_, err := r.db.ExecContext(ctx, query, u.ID, u.Email, u.PasswordHash, u.FullName, u.CompanyName, u.UpdatedAt)
+	_, err := r.db.ExecContext(ctx, query, u.ID, u.Email, u.PasswordHash, u.FullName, u.EmailVerifiedAt, u.UpdatedAt)
 	return err
 }
+
+func (r *UserRepository) CreateToken(ctx context.Context, t *user.Token) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE user_tokens SET used_at = $3
+        WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+    `, t.UserID, t.Purpose, t.CreatedAt); err != nil {
+		return fmt.Errorf("using up earlier tokens: %w", err)
+	}
+
+	query := `
+        INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, used_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	if _, err := tx.ExecContext(ctx, query, t.ID, t.UserID, t.Purpose, t.TokenHash, t.ExpiresAt, t.UsedAt,
+		t.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *UserRepository) GetToken(ctx context.Context, tokenHash string) (*user.Token, error) {
+	var t user.Token
+	query := `
+        SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+        FROM user_tokens WHERE token_hash = $1
+    `
+	if err := r.db.GetContext(ctx, &t, query, tokenHash); err != nil {
+		return nil, fmt.Errorf("getting token: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *UserRepository) UseToken(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE user_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, id, at)
+	if err != nil {
+		return err
+	}
+	if used, err := result.RowsAffected(); err != nil {
+		return err
+	} else if used == 0 {
+		return user.ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// internal/infrastructure/email/smtp.go
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/config"
+	appmail "github.com/invoice-app-be/internal/pkg/mail"
+)
+
+// DialFunc opens the connection to the SMTP server. Tests can swap it for an
+// in-process fake server.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+type SMTPSender struct {
+	host      string
+	port      int
+	username  string
+	password  string
+	from      string
+	fromName  string
+	templates *Templates
+	dial      DialFunc
+}
+
+func NewSMTPSender(cfg *config.EmailConfig, templates *Templates) *SMTPSender {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	return &SMTPSender{
+		host:      cfg.Host,
+		port:      cfg.Port,
+		username:  cfg.Username,
+		password:  cfg.Password,
+		from:      cfg.From,
+		fromName:  cfg.FromName,
+		templates: templates,
+		dial:      dialer.DialContext,
+	}
+}
+
+// WithDialer replaces how the sender connects to the server
+func (s *SMTPSender) WithDialer(dial DialFunc) *SMTPSender {
+	s.dial = dial
+	return s
+}
+
+// Send delivers the message and returns its Message-ID
+func (s *SMTPSender) Send(ctx context.Context, msg appmail.Message) (string, error) {
+	if len(msg.To) == 0 {
+		return "", fmt.Errorf("email has no recipients")
+	}
+
+	if msg.Template != "" {
+		subject, text, html, err := s.templates.Render(msg.Template, msg.Data)
+		if err != nil {
+			return "", err
+		}
+		msg.Subject, msg.TextBody, msg.HTMLBody = subject, text, html
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", uuid.New().String(), s.domain())
+
+	body, err := s.build(msg, messageID)
+	if err != nil {
+		return "", fmt.Errorf("building email: %w", err)
+	}
+
+	if err := s.deliver(ctx, msg.Recipients(), body); err != nil {
+		return "", err
+	}
+
+	return messageID, nil
+}
+
+func (s *SMTPSender) deliver(ctx context.Context, recipients []string, body []byte) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	conn, err := s.dial(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connecting to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("starting SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("starting TLS: %w", err)
+		}
+	}
+
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("authenticating: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return fmt.Errorf("setting sender: %w", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("adding recipient %s: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("starting message data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// build writes a multipart/mixed message: a multipart/alternative text and
+// HTML body followed by base64 attachments
+func (s *SMTPSender) build(msg appmail.Message, messageID string) ([]byte, error) {
+	var body bytes.Buffer
+	mixed := multipart.NewWriter(&body)
+
+	var alt bytes.Buffer
+	alternative := multipart.NewWriter(&alt)
+	if err := writePart(alternative, "text/plain; charset=utf-8", msg.TextBody); err != nil {
+		return nil, err
+	}
+	if msg.HTMLBody != "" {
+		if err := writePart(alternative, "text/html; charset=utf-8", msg.HTMLBody); err != nil {
+			return nil, err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, err
+	}
+
+	part, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alternative.Boundary()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(alt.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range msg.Attachments {
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(part, attachment.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	fromName := s.fromName
+	if msg.FromName != "" {
+		fromName = msg.FromName
+	}
+
+	// Bcc recipients only appear in the envelope, never in the headers
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", (&mail.Address{Name: fromName, Address: s.from}).String())
+	writeHeader(&buf, "To", strings.Join(msg.To, ", "))
+	if len(msg.CC) > 0 {
+		writeHeader(&buf, "Cc", strings.Join(msg.CC, ", "))
+	}
+	if msg.ReplyTo != "" {
+		writeHeader(&buf, "Reply-To", msg.ReplyTo)
+	}
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", messageID)
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeHeader(&buf, "Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name + ": " + value + "\r\n")
+}
+
+func writePart(w *multipart.Writer, contentType, content string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeBase64 encodes data in 76 character lines as RFC 2045 requires
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
+
+func (s *SMTPSender) domain() string {
+	if at := strings.LastIndex(s.from, "@"); at >= 0 {
+		return s.from[at+1:]
+	}
+	return s.host
+}
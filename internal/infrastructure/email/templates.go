@@ -0,0 +1,61 @@
+// internal/infrastructure/email/templates.go
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Templates renders the embedded email templates. Each template name has a
+// <name>.subject.tmpl and <name>.txt.tmpl, and optionally <name>.html.tmpl.
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewTemplates() (*Templates, error) {
+	text, err := texttemplate.ParseFS(templateFS, "templates/*.subject.tmpl", "templates/*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parsing text templates: %w", err)
+	}
+
+	html, err := htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parsing html templates: %w", err)
+	}
+
+	return &Templates{text: text, html: html}, nil
+}
+
+// Render returns the subject, text and HTML bodies for a template
+func (t *Templates) Render(name string, data interface{}) (subject, text, html string, err error) {
+	var buf bytes.Buffer
+
+	if err := t.text.ExecuteTemplate(&buf, name+".subject.tmpl", data); err != nil {
+		return "", "", "", fmt.Errorf("rendering %s subject: %w", name, err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := t.text.ExecuteTemplate(&buf, name+".txt.tmpl", data); err != nil {
+		return "", "", "", fmt.Errorf("rendering %s text body: %w", name, err)
+	}
+	text = buf.String()
+
+	if t.html.Lookup(name+".html.tmpl") != nil {
+		buf.Reset()
+		if err := t.html.ExecuteTemplate(&buf, name+".html.tmpl", data); err != nil {
+			return "", "", "", fmt.Errorf("rendering %s html body: %w", name, err)
+		}
+		html = buf.String()
+	}
+
+	return subject, text, html, nil
+}
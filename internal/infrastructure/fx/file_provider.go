@@ -0,0 +1,93 @@
+// internal/infrastructure/fx/file_provider.go
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	domainfx "github.com/invoice-app-be/internal/domain/fx"
+)
+
+// FileProvider serves exchange rates from a JSON file loaded at startup:
+//
+//	[{"date": "2026-01-01", "base": "EUR", "quote": "USD", "rate": 1.08}]
+type FileProvider struct {
+	rates map[pair][]datedRate
+}
+
+type pair struct {
+	base  string
+	quote string
+}
+
+type datedRate struct {
+	date time.Time
+	rate float64
+}
+
+type fileRate struct {
+	Date  string  `json:"date"`
+	Base  string  `json:"base"`
+	Quote string  `json:"quote"`
+	Rate  float64 `json:"rate"`
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rates file: %w", err)
+	}
+
+	var entries []fileRate
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing rates file: %w", err)
+	}
+
+	p := &FileProvider{rates: make(map[pair][]datedRate)}
+	for _, e := range entries {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q in rates file: %w", e.Date, err)
+		}
+		if e.Rate <= 0 {
+			return nil, fmt.Errorf("invalid rate for %s/%s on %s", e.Base, e.Quote, e.Date)
+		}
+		key := pair{e.Base, e.Quote}
+		p.rates[key] = append(p.rates[key], datedRate{date: date, rate: e.Rate})
+	}
+
+	for key := range p.rates {
+		sort.Slice(p.rates[key], func(i, j int) bool {
+			return p.rates[key][i].date.Before(p.rates[key][j].date)
+		})
+	}
+
+	return p, nil
+}
+
+// Rate returns the newest rate on or before the given date, using the
+// inverse pair when only that is in the file
+func (p *FileProvider) Rate(ctx context.Context, base, quote string, on time.Time) (float64, error) {
+	if rate, ok := p.latest(pair{base, quote}, on); ok {
+		return rate, nil
+	}
+	if rate, ok := p.latest(pair{quote, base}, on); ok {
+		return 1 / rate, nil
+	}
+	return 0, domainfx.ErrRateNotFound
+}
+
+func (p *FileProvider) latest(key pair, on time.Time) (float64, bool) {
+	rates := p.rates[key]
+	i := sort.Search(len(rates), func(i int) bool {
+		return rates[i].date.After(on)
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return rates[i-1].rate, true
+}
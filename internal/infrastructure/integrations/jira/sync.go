@@ -7,23 +7,69 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
 	"github.com/invoice-app-be/internal/domain/timeentry"
 )
 
 type SyncService struct {
 	client        *Client
 	timeEntryRepo timeentry.Repository
+	projectRepo   project.Repository
+	weeks         timeentry.WeekLocks
 }
 
-func NewSyncService(client *Client, repo timeentry.Repository) *SyncService {
+func NewSyncService(client *Client, repo timeentry.Repository, projects project.Repository, weeks timeentry.WeekLocks) *SyncService {
 	return &SyncService{
 		client:        client,
 		timeEntryRepo: repo,
+		projectRepo:   projects,
+		weeks:         weeks,
 	}
 }
 
-// PullWorklogsByDateRange pulls worklogs from Jira for a date range
-func (s *SyncService) PullWorklogsByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time, issueKeys []string) (int, error) {
+// weekLocked reports whether the worklog's week is submitted or approved,
+// so its time can't be added, caching answers in locked for the rest of the
+// pull
+func (s *SyncService) weekLocked(ctx context.Context, p organization.Principal, date time.Time, locked map[time.Time]bool) (bool, error) {
+	start := timeentry.WeekStart(date)
+	if l, ok := locked[start]; ok {
+		return l, nil
+	}
+
+	l, err := s.weeks.IsWeekLocked(ctx, p.OrganizationID, p.UserID, start)
+	if err != nil {
+		return false, fmt.Errorf("checking timesheet: %w", err)
+	}
+	locked[start] = l
+	return l, nil
+}
+
+// projectFor finds the project mapped to the issue's Jira project, caching
+// lookups in projects for the rest of the pull
+func (s *SyncService) projectFor(ctx context.Context, organizationID uuid.UUID, issueKey string, projects map[string]*uuid.UUID) *uuid.UUID {
+	key := project.JiraProjectKey(issueKey)
+	if key == "" {
+		return nil
+	}
+	if id, ok := projects[key]; ok {
+		return id
+	}
+
+	var id *uuid.UUID
+	if p, err := s.projectRepo.GetByJiraKey(ctx, organizationID, key); err == nil {
+		id = &p.ID
+	}
+	projects[key] = id
+	return id
+}
+
+// PullWorklogsByDateRange pulls worklogs from Jira for a date range as the
+// principal's time. Worklogs in submitted or approved weeks are skipped.
+func (s *SyncService) PullWorklogsByDateRange(ctx context.Context, p organization.Principal, startDate, endDate time.Time, issueKeys []string) (int, error) {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return 0, err
+	}
 	if s.client == nil {
 		return 0, fmt.Errorf("Jira client not configured")
 	}
@@ -65,24 +111,25 @@ func (s *SyncService) PullWorklogsByDateRange(ctx context.Context, userID uuid.U
 
 	// Create time entries from worklogs
 	count := 0
+	projects := make(map[string]*uuid.UUID)
+	locked := make(map[time.Time]bool)
 	for _, wl := range worklogs {
 		// Check if already synced
-		entries, _ := s.timeEntryRepo.GetByUserID(ctx, userID, "", "")
-		exists := false
-		for _, entry := range entries {
-			if entry.JiraWorklogID != nil && *entry.JiraWorklogID == wl.ID {
-				exists = true
-				break
-			}
-		}
-
-		if exists {
+		if _, err := s.timeEntryRepo.GetByJiraWorklogID(ctx, wl.ID); err == nil {
 			fmt.Printf("Skipping worklog %s (already synced)\n", wl.ID)
 			continue
 		}
 
 		// Create new time entry
-		entry := MapWorklogToTimeEntry(userID, wl)
+		entry := MapWorklogToTimeEntry(p.UserID, wl)
+		if l, err := s.weekLocked(ctx, p, entry.Date, locked); err != nil {
+			return count, err
+		} else if l {
+			fmt.Printf("Skipping worklog %s (week is submitted)\n", wl.ID)
+			continue
+		}
+		entry.OrganizationID = p.OrganizationID
+		entry.ProjectID = s.projectFor(ctx, p.OrganizationID, wl.IssueKey, projects)
 		if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
 			return count, fmt.Errorf("creating time entry: %w", err)
 		}
@@ -93,8 +140,12 @@ func (s *SyncService) PullWorklogsByDateRange(ctx context.Context, userID uuid.U
 	return count, nil
 }
 
-// SyncWorklogsForIssue syncs all worklogs for a specific issue
-func (s *SyncService) SyncWorklogsForIssue(ctx context.Context, userID uuid.UUID, issueKey string) error {
+// SyncWorklogsForIssue syncs all worklogs for a specific issue as the
+// principal's time, skipping those in submitted or approved weeks
+func (s *SyncService) SyncWorklogsForIssue(ctx context.Context, p organization.Principal, issueKey string) error {
+	if err := p.Require(organization.ActionTrackTime); err != nil {
+		return err
+	}
 	if s.client == nil {
 		return fmt.Errorf("Jira client not configured")
 	}
@@ -104,23 +155,23 @@ func (s *SyncService) SyncWorklogsForIssue(ctx context.Context, userID uuid.UUID
 		return fmt.Errorf("fetching worklogs: %w", err)
 	}
 
+	projectID := s.projectFor(ctx, p.OrganizationID, issueKey, make(map[string]*uuid.UUID))
+	locked := make(map[time.Time]bool)
 	for _, wl := range worklogs {
 		// Check if already synced
-		entries, _ := s.timeEntryRepo.GetByUserID(ctx, userID, "", "")
-		exists := false
-		for _, entry := range entries {
-			if entry.JiraWorklogID != nil && *entry.JiraWorklogID == wl.ID {
-				exists = true
-				break
-			}
-		}
-
-		if exists {
+		if _, err := s.timeEntryRepo.GetByJiraWorklogID(ctx, wl.ID); err == nil {
 			continue
 		}
 
 		// Create time entry
-		entry := MapWorklogToTimeEntry(userID, wl)
+		entry := MapWorklogToTimeEntry(p.UserID, wl)
+		if l, err := s.weekLocked(ctx, p, entry.Date, locked); err != nil {
+			return err
+		} else if l {
+			continue
+		}
+		entry.OrganizationID = p.OrganizationID
+		entry.ProjectID = projectID
 		if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
 			return fmt.Errorf("creating time entry: %w", err)
 		}
@@ -3,6 +3,7 @@ package square
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/invoice-app-be/internal/domain/invoice"
 )
@@ -10,17 +11,26 @@ import (
 type Client struct {
 	accessToken string
 	environment string
+	locationID  string
 }
 
-func NewClient(accessToken, environment string) *Client {
+func NewClient(accessToken, environment, locationID string) *Client {
 	return &Client{
 		accessToken: accessToken,
 		environment: environment,
+		locationID:  locationID,
 	}
 }
 
 func (c *Client) CreateInvoice(ctx context.Context, inv *invoice.Invoice) (string, error) {
-	// TODO: Implement Square API integration
+	order := NewOrder(inv, c.locationID)
+	slog.Debug("Prepared Square order",
+		"reference_id", order.ReferenceID,
+		"line_items", len(order.LineItems),
+		"discounts", len(order.Discounts),
+		"taxes", len(order.Taxes))
+
+	// TODO: Implement Square API integration - POST the order, then an invoice for it
 	return "square-invoice-id", nil
 }
 
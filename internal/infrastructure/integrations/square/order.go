@@ -0,0 +1,136 @@
+// internal/infrastructure/integrations/square/order.go
+package square
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/pkg/currency"
+)
+
+// Order mirrors the parts of the Square Orders API payload we send. Square
+// invoices are always backed by an order, so discounts and taxes have to be
+// expressed here for the amounts to match ours.
+type Order struct {
+	LocationID  string          `json:"location_id,omitempty"`
+	ReferenceID string          `json:"reference_id,omitempty"`
+	LineItems   []OrderLineItem `json:"line_items"`
+	Discounts   []OrderDiscount `json:"discounts,omitempty"`
+	Taxes       []OrderTax      `json:"taxes,omitempty"`
+}
+
+type OrderLineItem struct {
+	UID              string            `json:"uid"`
+	Name             string            `json:"name"`
+	Quantity         string            `json:"quantity"`
+	BasePriceMoney   Money             `json:"base_price_money"`
+	AppliedDiscounts []AppliedDiscount `json:"applied_discounts,omitempty"`
+	AppliedTaxes     []AppliedTax      `json:"applied_taxes,omitempty"`
+}
+
+type OrderDiscount struct {
+	UID         string `json:"uid"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Percentage  string `json:"percentage,omitempty"`
+	AmountMoney *Money `json:"amount_money,omitempty"`
+	Scope       string `json:"scope"`
+}
+
+type OrderTax struct {
+	UID        string `json:"uid"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Percentage string `json:"percentage"`
+	Scope      string `json:"scope"`
+}
+
+type AppliedDiscount struct {
+	DiscountUID string `json:"discount_uid"`
+}
+
+type AppliedTax struct {
+	TaxUID string `json:"tax_uid"`
+}
+
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewOrder maps an invoice to a Square order. Line discounts are scoped to
+// their line and the invoice discount to the whole order, which Square applies
+// before tax just like CalculateTotals does. Square has no compound taxes, so
+// those are sent as additive taxes and may differ from ours by the compounding.
+func NewOrder(inv *invoice.Invoice, locationID string) Order {
+	order := Order{
+		LocationID:  locationID,
+		ReferenceID: inv.InvoiceNumber,
+		LineItems:   make([]OrderLineItem, len(inv.Items)),
+	}
+
+	taxType := "ADDITIVE"
+	if inv.PricesIncludeTax {
+		taxType = "INCLUSIVE"
+	}
+
+	for i, item := range inv.Items {
+		line := OrderLineItem{
+			UID:            item.ID.String(),
+			Name:           item.Description,
+			Quantity:       strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+			BasePriceMoney: toMoney(item.UnitPrice, inv.Currency),
+		}
+
+		if item.DiscountAmount > 0 {
+			discount := newDiscount(fmt.Sprintf("line-%d-discount", i), "Line discount", item.DiscountType,
+				item.DiscountValue, inv.Currency, "LINE_ITEM")
+			order.Discounts = append(order.Discounts, discount)
+			line.AppliedDiscounts = append(line.AppliedDiscounts, AppliedDiscount{DiscountUID: discount.UID})
+		}
+
+		for j, tax := range item.Taxes {
+			uid := fmt.Sprintf("line-%d-tax-%d", i, j)
+			order.Taxes = append(order.Taxes, OrderTax{
+				UID:        uid,
+				Name:       tax.Name,
+				Type:       taxType,
+				Percentage: strconv.FormatFloat(tax.Rate, 'f', -1, 64),
+				Scope:      "LINE_ITEM",
+			})
+			line.AppliedTaxes = append(line.AppliedTaxes, AppliedTax{TaxUID: uid})
+		}
+
+		order.LineItems[i] = line
+	}
+
+	if inv.DiscountAmount > 0 {
+		order.Discounts = append(order.Discounts, newDiscount("invoice-discount", "Discount", inv.DiscountType,
+			inv.DiscountValue, inv.Currency, "ORDER"))
+	}
+
+	return order
+}
+
+func newDiscount(uid, name string, discountType invoice.DiscountType, value float64, currency, scope string) OrderDiscount {
+	discount := OrderDiscount{UID: uid, Name: name, Scope: scope}
+	if discountType == invoice.DiscountPercent {
+		discount.Type = "FIXED_PERCENTAGE"
+		discount.Percentage = strconv.FormatFloat(value, 'f', -1, 64)
+		return discount
+	}
+
+	amount := toMoney(value, currency)
+	discount.Type = "FIXED_AMOUNT"
+	discount.AmountMoney = &amount
+	return discount
+}
+
+// toMoney converts an amount to Square's smallest currency unit
+func toMoney(amount float64, code string) Money {
+	return Money{
+		Amount:   currency.ToMinor(amount, code),
+		Currency: code,
+	}
+}
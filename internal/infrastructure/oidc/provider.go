@@ -0,0 +1,282 @@
+// internal/infrastructure/oidc/provider.go
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/invoice-app-be/internal/domain/sso"
+)
+
+// Config describes a provider and this app's client registered with it
+type Config struct {
+	Name           string
+	Issuer         string
+	ClientID       string
+	ClientSecret   string // Empty for public clients
+	RedirectURL    string
+	Scopes         []string
+	AllowedDomains []string // Email domains allowed to log in; any when empty
+}
+
+// Provider logs users in with an OpenID Connect provider using the
+// authorization code flow with PKCE. Its endpoints are discovered on first
+// use, and its signing keys fetched again when a token names an unknown one.
+type Provider struct {
+	config Config
+	client *resty.Client
+
+	mu            sync.Mutex
+	discovery     *discovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+	AuthorizedParty string       `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// flexibleBool reads booleans some providers send as strings
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	*b = flexibleBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+// keysRefreshInterval limits how often tokens naming unknown keys make the
+// provider's keys be fetched again
+const keysRefreshInterval = time.Minute
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+func NewProvider(config Config) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if !slices.Contains(config.Scopes, "openid") {
+		config.Scopes = append([]string{"openid"}, config.Scopes...)
+	}
+
+	return &Provider{
+		config: config,
+		client: resty.New().SetTimeout(10 * time.Second),
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+func (p *Provider) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing authorization endpoint: %w", err)
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*sso.Claims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"redirect_uri":  p.config.RedirectURL,
+		"code_verifier": codeVerifier,
+		"client_id":     p.config.ClientID,
+	}
+	request := p.client.R().SetContext(ctx).SetFormData(form)
+	if p.config.ClientSecret != "" {
+		// client_secret_basic, with both parts form-encoded as RFC 6749
+		// section 2.3.1 asks
+		request.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var result tokenResponse
+	resp, err := request.SetResult(&result).SetError(&result).Post(d.TokenEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("redeeming code: %w", err)
+	}
+	if resp.IsError() || result.Error != "" {
+		return nil, fmt.Errorf("%s token endpoint: %s", p.config.Name,
+			strings.TrimSpace(resp.Status()+" "+result.Error+" "+result.ErrorDescription))
+	}
+	if result.IDToken == "" {
+		return nil, fmt.Errorf("%s returned no ID token", p.config.Name)
+	}
+
+	return p.verifyIDToken(ctx, result.IDToken, nonce)
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, raw, nonce string) (*sso.Claims, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute))
+	if err != nil {
+		return nil, fmt.Errorf("verifying ID token: %w", err)
+	}
+
+	// A token issued to several clients has to name this one as the party
+	// it was issued for
+	if (len(claims.Audience) > 1 || claims.AuthorizedParty != "") && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("verifying ID token: issued to another client")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("verifying ID token: nonce doesn't match")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("verifying ID token: no subject")
+	}
+	if !p.allowsEmail(claims.Email) {
+		return nil, sso.ErrDomainNotAllowed
+	}
+
+	return &sso.Claims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *Provider) allowsEmail(email string) bool {
+	if len(p.config.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	return slices.ContainsFunc(p.config.AllowedDomains, func(allowed string) bool {
+		return strings.EqualFold(allowed, domain)
+	})
+}
+
+// discover fetches the provider's configuration once it's first needed, so
+// the API starts while a provider is down
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d discovery
+	endpoint := strings.TrimRight(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	resp, err := p.client.R().SetContext(ctx).SetResult(&d).Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s discovery: %w", p.config.Name, err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("fetching %s discovery: %s", p.config.Name, resp.Status())
+	}
+	if d.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%s discovery names issuer %q, not %q", p.config.Name, d.Issuer, p.config.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("%s discovery is missing endpoints", p.config.Name)
+	}
+
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// key returns the provider's public key with the ID, fetching the keys again
+// when it's unknown, as after the provider rotated them. Tokens without an
+// ID are checked with the only key, when there's one.
+func (p *Provider) key(ctx context.Context, kid string) (interface{}, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := fetchKeys(ctx, p.client, d.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *Provider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
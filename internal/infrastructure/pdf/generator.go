@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/jung-kurt/gofpdf"
 
 	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/pkg/currency"
 )
 
 type Generator struct{}
@@ -21,6 +23,12 @@ func (g *Generator) Generate(ctx context.Context, inv *invoice.Invoice) ([]byte,
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 
+	// The core fonts are cp1252, so symbols such as € and £ need translating
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	money := func(amount float64) string {
+		return tr(currency.Format(amount, inv.Currency))
+	}
+
 	// Header
 	pdf.SetFont("Arial", "B", 24)
 	pdf.Cell(40, 10, "INVOICE")
@@ -44,17 +52,93 @@ func (g *Generator) Generate(ctx context.Context, inv *invoice.Invoice) ([]byte,
 
 	pdf.SetFont("Arial", "", 11)
 	for _, item := range inv.Items {
-		pdf.Cell(100, 8, item.Description)
+		if item.IsLateFee {
+			continue
+		}
+		description := item.Description
+		if item.TaxExempt {
+			description += " (tax exempt)"
+		}
+		pdf.Cell(100, 8, description)
 		pdf.Cell(30, 8, fmt.Sprintf("%.2f", item.Quantity))
-		pdf.Cell(40, 8, fmt.Sprintf("$%.2f", item.Amount))
+		pdf.Cell(40, 8, money(item.Amount))
 		pdf.Ln(8)
+
+		if item.DiscountAmount > 0 {
+			pdf.SetFont("Arial", "I", 10)
+			pdf.Cell(130, 6, "    "+discountLabel(item.DiscountType, item.DiscountValue))
+			pdf.Cell(40, 6, money(-item.DiscountAmount))
+			pdf.Ln(6)
+			pdf.SetFont("Arial", "", 11)
+		}
 	}
 
-	// Total
+	// Subtotal and tax breakdown
 	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(130, 8, "Subtotal:")
+	pdf.Cell(40, 8, money(inv.Subtotal))
+	pdf.Ln(8)
+
+	if inv.DiscountAmount > 0 {
+		pdf.Cell(130, 8, discountLabel(inv.DiscountType, inv.DiscountValue)+":")
+		pdf.Cell(40, 8, money(-inv.DiscountAmount))
+		pdf.Ln(8)
+	}
+
+	for _, tax := range inv.TaxBreakdown() {
+		label := fmt.Sprintf("%s (%s%%", tax.Name, formatRate(tax.Rate))
+		if tax.IsCompound {
+			label += ", compound"
+		}
+		label += fmt.Sprintf(") on %s:", money(tax.TaxableAmount))
+		pdf.Cell(130, 8, label)
+		pdf.Cell(40, 8, money(tax.Amount))
+		pdf.Ln(8)
+	}
+
+	if inv.PricesIncludeTax {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.Cell(130, 8, "Prices include tax")
+		pdf.Ln(8)
+	}
+
+	// Late fees are listed after the original amounts, which they leave as they were
+	pdf.SetFont("Arial", "", 12)
+	for _, fee := range inv.LateFeeItems() {
+		pdf.Cell(130, 8, fee.Description)
+		pdf.Cell(40, 8, money(fee.Amount))
+		pdf.Ln(8)
+	}
+
+	// Total
+	pdf.Ln(2)
 	pdf.SetFont("Arial", "B", 14)
 	pdf.Cell(130, 10, "Total:")
-	pdf.Cell(40, 10, fmt.Sprintf("$%.2f", inv.Total))
+	pdf.Cell(40, 10, money(inv.Total))
+	pdf.Ln(10)
+
+	if inv.AmountPaid != 0 {
+		pdf.SetFont("Arial", "", 12)
+		pdf.Cell(130, 8, "Amount paid:")
+		pdf.Cell(40, 8, money(-inv.AmountPaid))
+		pdf.Ln(8)
+		pdf.SetFont("Arial", "B", 14)
+		pdf.Cell(130, 10, "Balance due:")
+		pdf.Cell(40, 10, money(inv.BalanceDue()))
+		pdf.Ln(10)
+	}
+	pdf.Ln(2)
+
+	// Payment terms
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(170, 6, fmt.Sprintf("Terms: %s", inv.PaymentTerms()))
+	pdf.Ln(6)
+	if deadline, ok := inv.EarlyPaymentDeadline(); ok {
+		pdf.Cell(170, 6, fmt.Sprintf("Pay %s by %s to take the %s%% early payment discount.",
+			money(inv.AmountDueOn(deadline)), deadline.Format("2006-01-02"), formatRate(inv.EarlyPaymentDiscountPercent)))
+		pdf.Ln(6)
+	}
 
 	// Convert to bytes - FIXED
 	var buf bytes.Buffer
@@ -64,3 +148,15 @@ func (g *Generator) Generate(ctx context.Context, inv *invoice.Invoice) ([]byte,
 
 	return buf.Bytes(), nil
 }
+
+func discountLabel(discountType invoice.DiscountType, value float64) string {
+	if discountType == invoice.DiscountPercent {
+		return fmt.Sprintf("Discount (%s%%)", formatRate(value))
+	}
+	return "Discount"
+}
+
+// formatRate prints a tax rate without trailing zeros, e.g. 8.875 or 20
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
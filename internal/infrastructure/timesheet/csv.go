@@ -0,0 +1,66 @@
+// internal/infrastructure/timesheet/csv.go
+package timesheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/timeentry"
+)
+
+var csvHeader = []string{"ID", "Date", "Start time", "End time", "Hours", "Description", "Project", "Task",
+	"Billable", "Invoiced", "Hourly rate", "Jira issue"}
+
+// WriteCSV writes the entries as CSV with a header row. Start and end times
+// are UTC.
+func WriteCSV(w io.Writer, entries []timeentry.ExportEntry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			entry.ID.String(),
+			entry.Date.Format("2006-01-02"),
+			clock(entry.StartedAt),
+			clock(entry.EndedAt),
+			strconv.FormatFloat(entry.Hours, 'f', 2, 64),
+			entry.Description,
+			entry.Project,
+			entry.Task,
+			yesNo(entry.IsBillable),
+			yesNo(entry.IsInvoiced),
+			"",
+			"",
+		}
+		if entry.HourlyRate != nil {
+			record[10] = strconv.FormatFloat(*entry.HourlyRate, 'f', 2, 64)
+		}
+		if entry.JiraIssueKey != nil {
+			record[11] = *entry.JiraIssueKey
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func clock(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("15:04:05")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
@@ -0,0 +1,96 @@
+// internal/infrastructure/timesheet/ical.go
+package timesheet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/invoice-app-be/internal/domain/timeentry"
+)
+
+const (
+	icsDateTime = "20060102T150405Z"
+	icsDate     = "20060102"
+)
+
+// WriteICS writes the entries as an iCalendar (RFC 5545) calendar with an
+// event per entry. Entries with start and end times are timed events; the
+// rest are all-day events on their date.
+func WriteICS(w io.Writer, entries []timeentry.ExportEntry) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//invoice-app//Timesheet//EN")
+	line("CALSCALE", "GREGORIAN")
+
+	for _, entry := range entries {
+		line("BEGIN", "VEVENT")
+		line("UID", entry.ID.String()+"@invoice-app")
+		line("DTSTAMP", entry.UpdatedAt.UTC().Format(icsDateTime))
+		if entry.StartedAt != nil && entry.EndedAt != nil {
+			line("DTSTART", entry.StartedAt.UTC().Format(icsDateTime))
+			line("DTEND", entry.EndedAt.UTC().Format(icsDateTime))
+		} else {
+			line("DTSTART;VALUE=DATE", entry.Date.Format(icsDate))
+			line("DTEND;VALUE=DATE", entry.Date.AddDate(0, 0, 1).Format(icsDate))
+		}
+		line("SUMMARY", escapeText(entry.Description))
+		line("DESCRIPTION", escapeText(eventDescription(entry)))
+		if entry.Project != "" {
+			line("CATEGORIES", escapeText(entry.Project))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+func eventDescription(entry timeentry.ExportEntry) string {
+	parts := []string{strconv.FormatFloat(entry.Hours, 'f', 2, 64) + " hours"}
+	if entry.Project != "" {
+		project := entry.Project
+		if entry.Task != "" {
+			project += " / " + entry.Task
+		}
+		parts = append(parts, project)
+	}
+	if entry.JiraIssueKey != nil {
+		parts = append(parts, *entry.JiraIssueKey)
+	}
+	if entry.IsBillable {
+		parts = append(parts, "billable")
+	}
+	return strings.Join(parts, "\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeFolded writes a content line, folding it into lines of at most 75
+// octets without splitting a UTF-8 character
+func writeFolded(w *bufio.Writer, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		fmt.Fprintf(w, "%s\r\n ", s[:cut])
+		s = s[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	fmt.Fprintf(w, "%s\r\n", s)
+}
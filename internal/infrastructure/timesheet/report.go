@@ -0,0 +1,54 @@
+// internal/infrastructure/timesheet/report.go
+package timesheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/invoice-app-be/internal/domain/report"
+)
+
+// WriteReportCSV writes a team time report as CSV: a row per group and
+// period, then each group's total when the report has an interval, then the
+// grand total. Utilization is a percentage.
+func WriteReportCSV(w io.Writer, rep *report.TimeReport) error {
+	out := csv.NewWriter(w)
+	header := []string{"Period", groupHeader(rep.GroupBy), "Hours", "Billable hours",
+		"Billable amount (" + rep.BaseCurrency + ")", "Utilization %"}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	rows := rep.Rows
+	if rep.Interval != report.IntervalNone {
+		rows = append(append([]report.TimeRow{}, rows...), rep.Groups...)
+	}
+	rows = append(rows, rep.Totals)
+
+	for _, row := range rows {
+		period := rep.From.Format("2006-01-02") + " to " + rep.To.Format("2006-01-02")
+		if row.Period != nil {
+			period = row.Period.Format("2006-01-02")
+		}
+		record := []string{
+			period,
+			row.GroupName,
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+			strconv.FormatFloat(row.BillableHours, 'f', 2, 64),
+			strconv.FormatFloat(row.BillableAmount, 'f', 2, 64),
+			strconv.FormatFloat(row.Utilization*100, 'f', 1, 64),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func groupHeader(g report.Grouping) string {
+	return strings.ToUpper(string(g[:1])) + string(g[1:])
+}
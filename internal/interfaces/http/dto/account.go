@@ -0,0 +1,48 @@
+// internal/interfaces/http/dto/account.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/user"
+)
+
+type UpdateAccountRequest struct {
+	FullName string `json:"full_name" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AccountResponse struct {
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	FullName      string `json:"full_name"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func AccountFromDomain(u *user.User) AccountResponse {
+	return AccountResponse{
+		ID:            u.ID.String(),
+		Email:         u.Email,
+		EmailVerified: u.IsVerified(),
+		FullName:      u.FullName,
+		CreatedAt:     u.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,24 @@
+// internal/interfaces/http/dto/activity.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type ActivityResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+func ActivityFromDomain(a *invoice.Activity) ActivityResponse {
+	return ActivityResponse{
+		ID:        a.ID.String(),
+		Type:      string(a.Type),
+		Message:   a.Message,
+		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+	}
+}
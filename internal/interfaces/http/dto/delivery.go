@@ -0,0 +1,50 @@
+// internal/interfaces/http/dto/delivery.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type SendInvoiceRequest struct {
+	CC      []string `json:"cc" validate:"omitempty,dive,email"`
+	BCC     []string `json:"bcc" validate:"omitempty,dive,email"`
+	ReplyTo string   `json:"reply_to" validate:"omitempty,email"`
+	Message string   `json:"message" validate:"max=2000"`
+}
+
+type SendInvoiceResponse struct {
+	Invoice  InvoiceResponse   `json:"invoice"`
+	Delivery *DeliveryResponse `json:"delivery,omitempty"`
+}
+
+type DeliveryResponse struct {
+	ID        string `json:"id"`
+	InvoiceID string `json:"invoice_id"`
+	Recipient string `json:"recipient"`
+	CC        string `json:"cc"`
+	BCC       string `json:"bcc"`
+	ReplyTo   string `json:"reply_to"`
+	Template  string `json:"template"`
+	Status    string `json:"status"`
+	MessageID string `json:"message_id"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+func DeliveryFromDomain(d *invoice.Delivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:        d.ID.String(),
+		InvoiceID: d.InvoiceID.String(),
+		Recipient: d.Recipient,
+		CC:        d.CC,
+		BCC:       d.BCC,
+		ReplyTo:   d.ReplyTo,
+		Template:  d.Template,
+		Status:    string(d.Status),
+		MessageID: d.MessageID,
+		Error:     d.Error,
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,117 @@
+// internal/interfaces/http/dto/dunning.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/dunning"
+)
+
+type ReminderSequenceRequest struct {
+	Name      string                `json:"name" validate:"required,max=255"`
+	IsDefault bool                  `json:"is_default"`
+	Steps     []ReminderStepRequest `json:"steps" validate:"required,min=1,dive"`
+}
+
+type ReminderStepRequest struct {
+	ID           *uuid.UUID `json:"id"`
+	OffsetDays   int        `json:"offset_days"` // Days from the due date; negative is before
+	Template     string     `json:"template" validate:"required,oneof=reminder_upcoming reminder_due reminder_overdue reminder_final"`
+	Message      string     `json:"message" validate:"max=2000"`
+	LateFeeType  string     `json:"late_fee_type" validate:"omitempty,oneof=none percent fixed"`
+	LateFeeValue float64    `json:"late_fee_value" validate:"gte=0"`
+}
+
+type ReminderSequenceResponse struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	IsDefault bool                   `json:"is_default"`
+	Steps     []ReminderStepResponse `json:"steps"`
+	CreatedAt string                 `json:"created_at"`
+	UpdatedAt string                 `json:"updated_at"`
+}
+
+type ReminderStepResponse struct {
+	ID           string  `json:"id"`
+	OffsetDays   int     `json:"offset_days"`
+	Template     string  `json:"template"`
+	Message      string  `json:"message"`
+	LateFeeType  string  `json:"late_fee_type"`
+	LateFeeValue float64 `json:"late_fee_value"`
+}
+
+type AssignReminderSequenceRequest struct {
+	SequenceID *uuid.UUID `json:"sequence_id"` // null reverts to the default sequence
+}
+
+type ReminderResponse struct {
+	ID         string  `json:"id"`
+	StepID     *string `json:"step_id"`
+	Status     string  `json:"status"`
+	DeliveryID *string `json:"delivery_id"`
+	Error      string  `json:"error,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+func (r ReminderSequenceRequest) ToDomain() dunning.SequenceRequest {
+	steps := make([]dunning.StepRequest, len(r.Steps))
+	for i, step := range r.Steps {
+		steps[i] = dunning.StepRequest{
+			ID:           step.ID,
+			OffsetDays:   step.OffsetDays,
+			Template:     step.Template,
+			Message:      step.Message,
+			LateFeeType:  dunning.LateFeeType(step.LateFeeType),
+			LateFeeValue: step.LateFeeValue,
+		}
+	}
+
+	return dunning.SequenceRequest{
+		Name:      r.Name,
+		IsDefault: r.IsDefault,
+		Steps:     steps,
+	}
+}
+
+func ReminderSequenceFromDomain(seq *dunning.Sequence) ReminderSequenceResponse {
+	steps := make([]ReminderStepResponse, len(seq.Steps))
+	for i, step := range seq.Steps {
+		steps[i] = ReminderStepResponse{
+			ID:           step.ID.String(),
+			OffsetDays:   step.OffsetDays,
+			Template:     step.Template,
+			Message:      step.Message,
+			LateFeeType:  string(step.LateFeeType),
+			LateFeeValue: step.LateFeeValue,
+		}
+	}
+
+	return ReminderSequenceResponse{
+		ID:        seq.ID.String(),
+		Name:      seq.Name,
+		IsDefault: seq.IsDefault,
+		Steps:     steps,
+		CreatedAt: seq.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: seq.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ReminderFromDomain(r *dunning.Reminder) ReminderResponse {
+	resp := ReminderResponse{
+		ID:        r.ID.String(),
+		Status:    string(r.Status),
+		Error:     r.Error,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.StepID != nil {
+		stepID := r.StepID.String()
+		resp.StepID = &stepID
+	}
+	if r.DeliveryID != nil {
+		deliveryID := r.DeliveryID.String()
+		resp.DeliveryID = &deliveryID
+	}
+	return resp
+}
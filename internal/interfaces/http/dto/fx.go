@@ -0,0 +1,37 @@
+// internal/interfaces/http/dto/fx.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/fx"
+)
+
+type CreateExchangeRateRequest struct {
+	BaseCurrency  string  `json:"base_currency" validate:"required,iso4217"`
+	QuoteCurrency string  `json:"quote_currency" validate:"required,iso4217,nefield=BaseCurrency"`
+	Rate          float64 `json:"rate" validate:"required,gt=0"`
+	EffectiveDate string  `json:"effective_date" validate:"required"` // YYYY-MM-DD
+}
+
+type ExchangeRateResponse struct {
+	ID            string  `json:"id"`
+	BaseCurrency  string  `json:"base_currency"`
+	QuoteCurrency string  `json:"quote_currency"`
+	Rate          float64 `json:"rate"`
+	EffectiveDate string  `json:"effective_date"`
+	Source        string  `json:"source"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+func ExchangeRateFromDomain(rate *fx.Rate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		ID:            rate.ID.String(),
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate.Format("2006-01-02"),
+		Source:        rate.Source,
+		CreatedAt:     rate.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,49 @@
+// internal/interfaces/http/dto/hourlyrate.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+)
+
+type CreateHourlyRateRequest struct {
+	Scope         string     `json:"scope" validate:"required,oneof=task project client user"`
+	ScopeID       *uuid.UUID `json:"scope_id" validate:"required_unless=Scope user"`
+	HourlyRate    float64    `json:"hourly_rate" validate:"gte=0"`
+	EffectiveDate string     `json:"effective_date" validate:"required"` // YYYY-MM-DD
+}
+
+type HourlyRateResponse struct {
+	ID            string  `json:"id"`
+	Scope         string  `json:"scope"`
+	ScopeID       string  `json:"scope_id"`
+	HourlyRate    float64 `json:"hourly_rate"`
+	EffectiveDate string  `json:"effective_date"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type ResolvedRateResponse struct {
+	HourlyRate float64 `json:"hourly_rate"`
+	Source     string  `json:"source"`
+}
+
+func HourlyRateFromDomain(rate *hourlyrate.Rate) HourlyRateResponse {
+	return HourlyRateResponse{
+		ID:            rate.ID.String(),
+		Scope:         string(rate.Scope),
+		ScopeID:       rate.ScopeID.String(),
+		HourlyRate:    rate.HourlyRate,
+		EffectiveDate: rate.EffectiveDate.Format("2006-01-02"),
+		CreatedAt:     rate.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ResolvedRateFromDomain(resolved *hourlyrate.Resolved) ResolvedRateResponse {
+	return ResolvedRateResponse{
+		HourlyRate: resolved.HourlyRate,
+		Source:     string(resolved.Source),
+	}
+}
@@ -9,74 +9,233 @@ import (
 )
 
 type CreateInvoiceRequest struct {
-	ClientID  uuid.UUID              `json:"client_id" validate:"required"`
-	IssueDate time.Time              `json:"issue_date" validate:"required"`
-	DueDate   time.Time              `json:"due_date" validate:"required"`
-	TaxRate   float64                `json:"tax_rate" validate:"gte=0,lte=100"`
-	Currency  string                 `json:"currency" validate:"required,len=3"`
-	Notes     string                 `json:"notes"`
-	Items     []CreateInvoiceItemDTO `json:"items" validate:"required,min=1,dive"`
+	ClientID         uuid.UUID              `json:"client_id" validate:"required"`
+	IssueDate        time.Time              `json:"issue_date" validate:"required"`
+	DueDate          time.Time              `json:"due_date" validate:"required"`
+	TaxRate          float64                `json:"tax_rate" validate:"gte=0,lte=100"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Currency         string                 `json:"currency" validate:"required,iso4217"`
+	Notes            string                 `json:"notes"`
+	Items            []CreateInvoiceItemDTO `json:"items" validate:"required,min=1,dive"`
+
+	DiscountType                string  `json:"discount_type" validate:"omitempty,oneof=none percent fixed"`
+	DiscountValue               float64 `json:"discount_value" validate:"gte=0"`
+	EarlyPaymentDiscountPercent float64 `json:"early_payment_discount_percent" validate:"gte=0,lte=100"`
+	EarlyPaymentDiscountDays    int     `json:"early_payment_discount_days" validate:"gte=0"`
 }
 
 type CreateInvoiceItemDTO struct {
-	Description string  `json:"description" validate:"required"`
-	Quantity    float64 `json:"quantity" validate:"required,gt=0"`
-	UnitPrice   float64 `json:"unit_price" validate:"required,gte=0"`
+	Description string      `json:"description" validate:"required"`
+	Quantity    float64     `json:"quantity" validate:"required,gt=0"`
+	UnitPrice   float64     `json:"unit_price" validate:"required,gte=0"`
+	TaxCodeIDs  []uuid.UUID `json:"tax_code_ids" validate:"unique"`
+	TaxExempt   bool        `json:"tax_exempt"`
+
+	DiscountType  string  `json:"discount_type" validate:"omitempty,oneof=none percent fixed"`
+	DiscountValue float64 `json:"discount_value" validate:"gte=0"`
 }
 
 type InvoiceResponse struct {
-	ID            string           `json:"id"`
-	ClientID      string           `json:"client_id"`
-	InvoiceNumber string           `json:"invoice_number"`
-	Status        string           `json:"status"`
-	IssueDate     string           `json:"issue_date"`
-	DueDate       string           `json:"due_date"`
-	Subtotal      float64          `json:"subtotal"`
-	TaxRate       float64          `json:"tax_rate"`
-	TaxAmount     float64          `json:"tax_amount"`
-	Total         float64          `json:"total"`
-	Currency      string           `json:"currency"`
-	Notes         string           `json:"notes"`
-	Items         []InvoiceItemDTO `json:"items"`
-	CreatedAt     string           `json:"created_at"`
-	UpdatedAt     string           `json:"updated_at"`
+	ID               string           `json:"id"`
+	ClientID         string           `json:"client_id"`
+	InvoiceNumber    string           `json:"invoice_number"`
+	Status           string           `json:"status"`
+	IssueDate        string           `json:"issue_date"`
+	DueDate          string           `json:"due_date"`
+	Subtotal         float64          `json:"subtotal"`
+	DiscountType     string           `json:"discount_type"`
+	DiscountValue    float64          `json:"discount_value"`
+	DiscountAmount   float64          `json:"discount_amount"`
+	TaxRate          float64          `json:"tax_rate"`
+	TaxAmount        float64          `json:"tax_amount"`
+	TaxBreakdown     []TaxSummaryDTO  `json:"tax_breakdown"`
+	PricesIncludeTax bool             `json:"prices_include_tax"`
+	LateFees         float64          `json:"late_fees"`
+	Total            float64          `json:"total"`
+	AmountPaid       float64          `json:"amount_paid"`
+	BalanceDue       float64          `json:"balance_due"`
+	Currency         string           `json:"currency"`
+	BaseCurrency     string           `json:"base_currency"`
+	ExchangeRate     float64          `json:"exchange_rate"`
+	BaseTotal        float64          `json:"base_total"`
+	Notes            string           `json:"notes"`
+	Items            []InvoiceItemDTO `json:"items"`
+	PaymentTerms     string           `json:"payment_terms"`
+	EarlyPayment     *EarlyPaymentDTO `json:"early_payment,omitempty"`
+	RecurringID      *string          `json:"recurring_schedule_id,omitempty"`
+	QuoteID          *string          `json:"quote_id,omitempty"`
+	RemindersPaused  bool             `json:"reminders_paused"`
+	ReminderSequence *string          `json:"reminder_sequence_id"`
+	ViewedAt         *string          `json:"viewed_at"`
+	CreatedAt        string           `json:"created_at"`
+	UpdatedAt        string           `json:"updated_at"`
+}
+
+type EarlyPaymentDTO struct {
+	DiscountPercent float64 `json:"discount_percent"`
+	DiscountDays    int     `json:"discount_days"`
+	Deadline        string  `json:"deadline"`
+	DiscountAmount  float64 `json:"discount_amount"`
+	AmountDue       float64 `json:"amount_due"`
 }
 
 type InvoiceItemDTO struct {
-	ID          string  `json:"id"`
-	Description string  `json:"description"`
-	Quantity    float64 `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
-	Amount      float64 `json:"amount"`
+	ID             string       `json:"id"`
+	Description    string       `json:"description"`
+	Quantity       float64      `json:"quantity"`
+	UnitPrice      float64      `json:"unit_price"`
+	DiscountType   string       `json:"discount_type"`
+	DiscountValue  float64      `json:"discount_value"`
+	DiscountAmount float64      `json:"discount_amount"`
+	Amount         float64      `json:"amount"`
+	TaxExempt      bool         `json:"tax_exempt"`
+	TaxAmount      float64      `json:"tax_amount"`
+	Taxes          []ItemTaxDTO `json:"taxes"`
+	IsLateFee      bool         `json:"is_late_fee"`
+}
+
+type ItemTaxDTO struct {
+	TaxCodeID  *string `json:"tax_code_id,omitempty"`
+	Name       string  `json:"name"`
+	Rate       float64 `json:"rate"`
+	IsCompound bool    `json:"is_compound"`
+	Amount     float64 `json:"amount"`
+}
+
+type TaxSummaryDTO struct {
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	IsCompound    bool    `json:"is_compound"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	Amount        float64 `json:"amount"`
+}
+
+func (d CreateInvoiceItemDTO) ToDomain() invoice.CreateInvoiceItemRequest {
+	return invoice.CreateInvoiceItemRequest{
+		Description: d.Description,
+		Quantity:    d.Quantity,
+		UnitPrice:   d.UnitPrice,
+		TaxCodeIDs:  d.TaxCodeIDs,
+		TaxExempt:   d.TaxExempt,
+
+		DiscountType:  invoice.DiscountType(d.DiscountType),
+		DiscountValue: d.DiscountValue,
+	}
 }
 
 func InvoiceFromDomain(inv *invoice.Invoice) InvoiceResponse {
-	items := make([]InvoiceItemDTO, len(inv.Items))
-	for i, item := range inv.Items {
+	items := invoiceItemsFromDomain(inv.Items)
+	summary := taxSummaryFromDomain(inv.TaxBreakdown())
+
+	resp := InvoiceResponse{
+		ID:               inv.ID.String(),
+		ClientID:         inv.ClientID.String(),
+		InvoiceNumber:    inv.InvoiceNumber,
+		Status:           string(inv.Status),
+		IssueDate:        inv.IssueDate.Format("2006-01-02"),
+		DueDate:          inv.DueDate.Format("2006-01-02"),
+		Subtotal:         inv.Subtotal,
+		DiscountType:     string(inv.DiscountType),
+		DiscountValue:    inv.DiscountValue,
+		DiscountAmount:   inv.DiscountAmount,
+		TaxRate:          inv.TaxRate,
+		TaxAmount:        inv.TaxAmount,
+		TaxBreakdown:     summary,
+		PricesIncludeTax: inv.PricesIncludeTax,
+		LateFees:         inv.LateFees,
+		Total:            inv.Total,
+		RemindersPaused:  inv.RemindersPaused,
+		AmountPaid:       inv.AmountPaid,
+		BalanceDue:       inv.BalanceDue(),
+		Currency:         inv.Currency,
+		BaseCurrency:     inv.BaseCurrency,
+		ExchangeRate:     inv.ExchangeRate,
+		BaseTotal:        inv.BaseTotal(),
+		Notes:            inv.Notes,
+		Items:            items,
+		PaymentTerms:     inv.PaymentTerms(),
+		CreatedAt:        inv.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        inv.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if inv.DunningSequenceID != nil {
+		sequenceID := inv.DunningSequenceID.String()
+		resp.ReminderSequence = &sequenceID
+	}
+
+	if inv.QuoteID != nil {
+		quoteID := inv.QuoteID.String()
+		resp.QuoteID = &quoteID
+	}
+
+	if inv.ViewedAt != nil {
+		viewedAt := inv.ViewedAt.Format(time.RFC3339)
+		resp.ViewedAt = &viewedAt
+	}
+
+	if inv.RecurringScheduleID != nil {
+		scheduleID := inv.RecurringScheduleID.String()
+		resp.RecurringID = &scheduleID
+	}
+
+	if deadline, ok := inv.EarlyPaymentDeadline(); ok {
+		resp.EarlyPayment = &EarlyPaymentDTO{
+			DiscountPercent: inv.EarlyPaymentDiscountPercent,
+			DiscountDays:    inv.EarlyPaymentDiscountDays,
+			Deadline:        deadline.Format("2006-01-02"),
+			DiscountAmount:  inv.EarlyPaymentDiscount(),
+			AmountDue:       inv.AmountDueOn(deadline),
+		}
+	}
+
+	return resp
+}
+
+func invoiceItemsFromDomain(lines []invoice.InvoiceItem) []InvoiceItemDTO {
+	items := make([]InvoiceItemDTO, len(lines))
+	for i, item := range lines {
+		taxes := make([]ItemTaxDTO, len(item.Taxes))
+		for j, tax := range item.Taxes {
+			taxes[j] = ItemTaxDTO{
+				Name:       tax.Name,
+				Rate:       tax.Rate,
+				IsCompound: tax.IsCompound,
+				Amount:     tax.Amount,
+			}
+			if tax.TaxCodeID != nil {
+				codeID := tax.TaxCodeID.String()
+				taxes[j].TaxCodeID = &codeID
+			}
+		}
+
 		items[i] = InvoiceItemDTO{
-			ID:          item.ID.String(),
-			Description: item.Description,
-			Quantity:    item.Quantity,
-			UnitPrice:   item.UnitPrice,
-			Amount:      item.Amount,
+			ID:             item.ID.String(),
+			Description:    item.Description,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.UnitPrice,
+			DiscountType:   string(item.DiscountType),
+			DiscountValue:  item.DiscountValue,
+			DiscountAmount: item.DiscountAmount,
+			Amount:         item.Amount,
+			TaxExempt:      item.TaxExempt,
+			TaxAmount:      item.TaxAmount,
+			Taxes:          taxes,
+			IsLateFee:      item.IsLateFee,
 		}
 	}
+	return items
+}
 
-	return InvoiceResponse{
-		ID:            inv.ID.String(),
-		ClientID:      inv.ClientID.String(),
-		InvoiceNumber: inv.InvoiceNumber,
-		Status:        string(inv.Status),
-		IssueDate:     inv.IssueDate.Format("2006-01-02"),
-		DueDate:       inv.DueDate.Format("2006-01-02"),
-		Subtotal:      inv.Subtotal,
-		TaxRate:       inv.TaxRate,
-		TaxAmount:     inv.TaxAmount,
-		Total:         inv.Total,
-		Currency:      inv.Currency,
-		Notes:         inv.Notes,
-		Items:         items,
-		CreatedAt:     inv.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     inv.UpdatedAt.Format(time.RFC3339),
+func taxSummaryFromDomain(breakdown []invoice.TaxSummary) []TaxSummaryDTO {
+	summary := make([]TaxSummaryDTO, len(breakdown))
+	for i, tax := range breakdown {
+		summary[i] = TaxSummaryDTO{
+			Name:          tax.Name,
+			Rate:          tax.Rate,
+			IsCompound:    tax.IsCompound,
+			TaxableAmount: tax.TaxableAmount,
+			Amount:        tax.Amount,
+		}
 	}
+	return summary
 }
@@ -0,0 +1,108 @@
+// internal/interfaces/http/dto/latefee.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/latefee"
+)
+
+type LateFeePolicyRequest struct {
+	ClientID    *uuid.UUID `json:"client_id"` // null for the default policy
+	FeeType     string     `json:"fee_type" validate:"required,oneof=flat percent"`
+	Amount      float64    `json:"amount" validate:"gt=0"` // Flat fee, or percent per month
+	Compounding string     `json:"compounding" validate:"omitempty,oneof=daily monthly"`
+	GraceDays   int        `json:"grace_days" validate:"gte=0"`
+	Mode        string     `json:"mode" validate:"omitempty,oneof=line_item follow_up"`
+	MaxAmount   float64    `json:"max_amount" validate:"gte=0"`
+	MaxPercent  float64    `json:"max_percent" validate:"gte=0,lte=100"`
+}
+
+type LateFeePolicyResponse struct {
+	ID          string  `json:"id"`
+	ClientID    *string `json:"client_id"`
+	FeeType     string  `json:"fee_type"`
+	Amount      float64 `json:"amount"`
+	Compounding string  `json:"compounding"`
+	GraceDays   int     `json:"grace_days"`
+	Mode        string  `json:"mode"`
+	MaxAmount   float64 `json:"max_amount"`
+	MaxPercent  float64 `json:"max_percent"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type LateFeeChargeResponse struct {
+	ID                string  `json:"id"`
+	PolicyID          *string `json:"policy_id"`
+	FeeType           string  `json:"fee_type"`
+	Rate              float64 `json:"rate"`
+	Basis             float64 `json:"basis"`
+	PeriodStart       string  `json:"period_start"`
+	PeriodEnd         string  `json:"period_end"`
+	Amount            float64 `json:"amount"`
+	InvoiceItemID     *string `json:"invoice_item_id"`
+	FollowUpInvoiceID *string `json:"follow_up_invoice_id"`
+	CreatedAt         string  `json:"created_at"`
+}
+
+func (r LateFeePolicyRequest) ToDomain() latefee.PolicyRequest {
+	return latefee.PolicyRequest{
+		ClientID:    r.ClientID,
+		FeeType:     latefee.FeeType(r.FeeType),
+		Amount:      r.Amount,
+		Compounding: latefee.Compounding(r.Compounding),
+		GraceDays:   r.GraceDays,
+		Mode:        latefee.ChargeMode(r.Mode),
+		MaxAmount:   r.MaxAmount,
+		MaxPercent:  r.MaxPercent,
+	}
+}
+
+func LateFeePolicyFromDomain(p *latefee.Policy) LateFeePolicyResponse {
+	resp := LateFeePolicyResponse{
+		ID:          p.ID.String(),
+		FeeType:     string(p.FeeType),
+		Amount:      p.Amount,
+		Compounding: string(p.Compounding),
+		GraceDays:   p.GraceDays,
+		Mode:        string(p.Mode),
+		MaxAmount:   p.MaxAmount,
+		MaxPercent:  p.MaxPercent,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+	if p.ClientID != nil {
+		clientID := p.ClientID.String()
+		resp.ClientID = &clientID
+	}
+	return resp
+}
+
+func LateFeeChargeFromDomain(c *latefee.Charge) LateFeeChargeResponse {
+	resp := LateFeeChargeResponse{
+		ID:          c.ID.String(),
+		FeeType:     string(c.FeeType),
+		Rate:        c.Rate,
+		Basis:       c.Basis,
+		PeriodStart: c.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:   c.PeriodEnd.Format("2006-01-02"),
+		Amount:      c.Amount,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.PolicyID != nil {
+		policyID := c.PolicyID.String()
+		resp.PolicyID = &policyID
+	}
+	if c.InvoiceItemID != nil {
+		itemID := c.InvoiceItemID.String()
+		resp.InvoiceItemID = &itemID
+	}
+	if c.FollowUpInvoiceID != nil {
+		invoiceID := c.FollowUpInvoiceID.String()
+		resp.FollowUpInvoiceID = &invoiceID
+	}
+	return resp
+}
@@ -0,0 +1,56 @@
+// internal/interfaces/http/dto/mfa.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/mfa"
+)
+
+// MFACodeRequest carries a code from the authenticator app or a recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// MFAChallengeResponse answers a login with the right password when the
+// user has two-factor authentication. The token is traded, with a code, at
+// /auth/login/mfa.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int    `json:"expires_in"` // Seconds until the token expires
+}
+
+// MFAEnrollmentResponse is shown as a QR code of the URI, with the secret for
+// typing in by hand
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFAStatusResponse struct {
+	Enabled           bool    `json:"enabled"`
+	EnabledAt         *string `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int     `json:"recovery_codes_left"`
+}
+
+func MFAStatusFromDomain(s *mfa.Status) MFAStatusResponse {
+	response := MFAStatusResponse{
+		Enabled:           s.Enabled,
+		RecoveryCodesLeft: s.RecoveryCodesLeft,
+	}
+	if s.EnabledAt != nil {
+		enabledAt := s.EnabledAt.Format(time.RFC3339)
+		response.EnabledAt = &enabledAt
+	}
+	return response
+}
@@ -0,0 +1,78 @@
+// internal/interfaces/http/dto/organization.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+)
+
+type OrganizationRequest struct {
+	Name         string `json:"name" validate:"required"`
+	BaseCurrency string `json:"base_currency" validate:"required,len=3"`
+}
+
+type UpdateOrganizationRequest struct {
+	OrganizationRequest
+	RequireMFA bool `json:"require_mfa"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type OrganizationResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	BaseCurrency string `json:"base_currency"`
+	RequireMFA   bool   `json:"require_mfa"`
+	Role         string `json:"role,omitempty"` // The caller's, when listing their organizations
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type MemberResponse struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SwitchOrganizationResponse carries a token scoped to the chosen organization
+type SwitchOrganizationResponse struct {
+	Token        string               `json:"token"`
+	Organization OrganizationResponse `json:"organization"`
+}
+
+func OrganizationFromDomain(o *organization.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:           o.ID.String(),
+		Name:         o.Name,
+		BaseCurrency: o.BaseCurrency,
+		RequireMFA:   o.RequireMFA,
+		CreatedAt:    o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    o.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func MembershipFromDomain(m *organization.Membership) OrganizationResponse {
+	response := OrganizationFromDomain(&m.Organization)
+	response.Role = string(m.Role)
+	return response
+}
+
+func MemberFromDomain(m *organization.Member) MemberResponse {
+	return MemberResponse{
+		UserID:    m.UserID.String(),
+		Email:     m.Email,
+		FullName:  m.FullName,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,53 @@
+// internal/interfaces/http/dto/payment.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/payment"
+)
+
+type RecordPaymentRequest struct {
+	Amount    float64 `json:"amount" validate:"required,ne=0"`
+	Method    string  `json:"method" validate:"required,oneof=square bank_transfer cash check"`
+	PaidAt    string  `json:"paid_at"` // YYYY-MM-DD, defaults to today
+	Reference string  `json:"reference" validate:"max=255"`
+	Notes     string  `json:"notes"`
+}
+
+type PaymentResponse struct {
+	ID           string  `json:"id"`
+	InvoiceID    string  `json:"invoice_id"`
+	Amount       float64 `json:"amount"`
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchange_rate"`
+	FXGainLoss   float64 `json:"fx_gain_loss"`
+	Method       string  `json:"method"`
+	PaidAt       string  `json:"paid_at"`
+	Reference    string  `json:"reference"`
+	Notes        string  `json:"notes"`
+	IsRefund     bool    `json:"is_refund"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+type RecordPaymentResponse struct {
+	Payment PaymentResponse `json:"payment"`
+	Invoice InvoiceResponse `json:"invoice"`
+}
+
+func PaymentFromDomain(p *payment.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:           p.ID.String(),
+		InvoiceID:    p.InvoiceID.String(),
+		Amount:       p.Amount,
+		Currency:     p.Currency,
+		ExchangeRate: p.ExchangeRate,
+		FXGainLoss:   p.FXGainLoss,
+		Method:       string(p.Method),
+		PaidAt:       p.PaidAt.Format("2006-01-02"),
+		Reference:    p.Reference,
+		Notes:        p.Notes,
+		IsRefund:     p.IsRefund(),
+		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+	}
+}
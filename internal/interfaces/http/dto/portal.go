@@ -0,0 +1,135 @@
+// internal/interfaces/http/dto/portal.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/payment"
+	"github.com/invoice-app-be/internal/domain/portal"
+)
+
+type CreateShareRequest struct {
+	ExpiresInDays int `json:"expires_in_days" validate:"gte=0,lte=365"` // 0 uses the default lifetime
+}
+
+type ShareResponse struct {
+	ID           string  `json:"id"`
+	URL          string  `json:"url"`
+	Token        string  `json:"token"`
+	ExpiresAt    string  `json:"expires_at"`
+	RevokedAt    *string `json:"revoked_at"`
+	ViewCount    int     `json:"view_count"`
+	LastViewedAt *string `json:"last_viewed_at"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+type InvoiceViewResponse struct {
+	ID        string `json:"id"`
+	ShareID   string `json:"share_id"`
+	Resource  string `json:"resource"`
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+	CreatedAt string `json:"created_at"`
+}
+
+// PortalInvoiceResponse is the client's view of an invoice, without the
+// issuer's internal settings
+type PortalInvoiceResponse struct {
+	InvoiceNumber  string           `json:"invoice_number"`
+	Status         string           `json:"status"`
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	IssueDate      string           `json:"issue_date"`
+	DueDate        string           `json:"due_date"`
+	Currency       string           `json:"currency"`
+	Items          []InvoiceItemDTO `json:"items"`
+	Subtotal       float64          `json:"subtotal"`
+	DiscountAmount float64          `json:"discount_amount"`
+	TaxAmount      float64          `json:"tax_amount"`
+	TaxBreakdown   []TaxSummaryDTO  `json:"tax_breakdown"`
+	LateFees       float64          `json:"late_fees"`
+	Total          float64          `json:"total"`
+	AmountPaid     float64          `json:"amount_paid"`
+	BalanceDue     float64          `json:"balance_due"`
+	PaymentTerms   string           `json:"payment_terms"`
+	EarlyPayment   *EarlyPaymentDTO `json:"early_payment,omitempty"`
+	Notes          string           `json:"notes"`
+	ExpiresAt      string           `json:"link_expires_at"`
+}
+
+type PortalPaymentResponse struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Method    string  `json:"method"`
+	PaidAt    string  `json:"paid_at"`
+	Reference string  `json:"reference"`
+	IsRefund  bool    `json:"is_refund"`
+}
+
+func ShareFromDomain(s *portal.Share, token, baseURL string) ShareResponse {
+	resp := ShareResponse{
+		ID:        s.ID.String(),
+		URL:       baseURL + "/invoices/" + token,
+		Token:     token,
+		ExpiresAt: s.ExpiresAt.Format(time.RFC3339),
+		ViewCount: s.ViewCount,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.RevokedAt != nil {
+		revokedAt := s.RevokedAt.Format(time.RFC3339)
+		resp.RevokedAt = &revokedAt
+	}
+	if s.LastViewedAt != nil {
+		lastViewedAt := s.LastViewedAt.Format(time.RFC3339)
+		resp.LastViewedAt = &lastViewedAt
+	}
+	return resp
+}
+
+func InvoiceViewFromDomain(v *portal.View) InvoiceViewResponse {
+	return InvoiceViewResponse{
+		ID:        v.ID.String(),
+		ShareID:   v.ShareID.String(),
+		Resource:  string(v.Resource),
+		IPAddress: v.IPAddress,
+		UserAgent: v.UserAgent,
+		CreatedAt: v.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func PortalInvoiceFromDomain(doc *portal.Document) PortalInvoiceResponse {
+	inv := InvoiceFromDomain(doc.Invoice)
+	return PortalInvoiceResponse{
+		InvoiceNumber:  inv.InvoiceNumber,
+		Status:         inv.Status,
+		From:           doc.IssuerName,
+		To:             doc.Client.DisplayName(),
+		IssueDate:      inv.IssueDate,
+		DueDate:        inv.DueDate,
+		Currency:       inv.Currency,
+		Items:          inv.Items,
+		Subtotal:       inv.Subtotal,
+		DiscountAmount: inv.DiscountAmount,
+		TaxAmount:      inv.TaxAmount,
+		TaxBreakdown:   inv.TaxBreakdown,
+		LateFees:       inv.LateFees,
+		Total:          inv.Total,
+		AmountPaid:     inv.AmountPaid,
+		BalanceDue:     inv.BalanceDue,
+		PaymentTerms:   inv.PaymentTerms,
+		EarlyPayment:   inv.EarlyPayment,
+		Notes:          inv.Notes,
+		ExpiresAt:      doc.Share.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+func PortalPaymentFromDomain(p *payment.Payment) PortalPaymentResponse {
+	return PortalPaymentResponse{
+		Amount:    p.Amount,
+		Currency:  p.Currency,
+		Method:    string(p.Method),
+		PaidAt:    p.PaidAt.Format("2006-01-02"),
+		Reference: p.Reference,
+		IsRefund:  p.IsRefund(),
+	}
+}
@@ -0,0 +1,112 @@
+// internal/interfaces/http/dto/project.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/project"
+)
+
+type ProjectRequest struct {
+	ClientID       uuid.UUID `json:"client_id" validate:"required"`
+	Name           string    `json:"name" validate:"required"`
+	HourlyRate     *float64  `json:"hourly_rate" validate:"omitempty,gte=0"`
+	BudgetHours    *float64  `json:"budget_hours" validate:"omitempty,gte=0"`
+	BudgetAmount   *float64  `json:"budget_amount" validate:"omitempty,gte=0"`
+	JiraProjectKey *string   `json:"jira_project_key"`
+	Archived       bool      `json:"archived"`
+}
+
+type TaskRequest struct {
+	Name       string   `json:"name" validate:"required"`
+	HourlyRate *float64 `json:"hourly_rate" validate:"omitempty,gte=0"`
+	Archived   bool     `json:"archived"`
+}
+
+type ProjectResponse struct {
+	ID             string         `json:"id"`
+	ClientID       string         `json:"client_id"`
+	Name           string         `json:"name"`
+	HourlyRate     *float64       `json:"hourly_rate"`
+	BudgetHours    *float64       `json:"budget_hours"`
+	BudgetAmount   *float64       `json:"budget_amount"`
+	JiraProjectKey *string        `json:"jira_project_key"`
+	Archived       bool           `json:"archived"`
+	Usage          *UsageResponse `json:"usage,omitempty"` // Only on single projects
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+}
+
+type UsageResponse struct {
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billable_hours"`
+	InvoicedHours float64 `json:"invoiced_hours"`
+}
+
+type TaskResponse struct {
+	ID         string   `json:"id"`
+	ProjectID  string   `json:"project_id"`
+	Name       string   `json:"name"`
+	HourlyRate *float64 `json:"hourly_rate"`
+	Archived   bool     `json:"archived"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+func (r ProjectRequest) ToDomain() project.ProjectRequest {
+	return project.ProjectRequest{
+		ClientID:       r.ClientID,
+		Name:           r.Name,
+		HourlyRate:     r.HourlyRate,
+		BudgetHours:    r.BudgetHours,
+		BudgetAmount:   r.BudgetAmount,
+		JiraProjectKey: r.JiraProjectKey,
+		Archived:       r.Archived,
+	}
+}
+
+func (r TaskRequest) ToDomain() project.TaskRequest {
+	return project.TaskRequest{
+		Name:       r.Name,
+		HourlyRate: r.HourlyRate,
+		Archived:   r.Archived,
+	}
+}
+
+// ProjectFromDomain converts a project; usage may be nil
+func ProjectFromDomain(p *project.Project, usage *project.Usage) ProjectResponse {
+	resp := ProjectResponse{
+		ID:             p.ID.String(),
+		ClientID:       p.ClientID.String(),
+		Name:           p.Name,
+		HourlyRate:     p.HourlyRate,
+		BudgetHours:    p.BudgetHours,
+		BudgetAmount:   p.BudgetAmount,
+		JiraProjectKey: p.JiraProjectKey,
+		Archived:       p.Archived,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      p.UpdatedAt.Format(time.RFC3339),
+	}
+	if usage != nil {
+		resp.Usage = &UsageResponse{
+			Hours:         usage.Hours,
+			BillableHours: usage.BillableHours,
+			InvoicedHours: usage.InvoicedHours,
+		}
+	}
+	return resp
+}
+
+func TaskFromDomain(t *project.Task) TaskResponse {
+	return TaskResponse{
+		ID:         t.ID.String(),
+		ProjectID:  t.ProjectID.String(),
+		Name:       t.Name,
+		HourlyRate: t.HourlyRate,
+		Archived:   t.Archived,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  t.UpdatedAt.Format(time.RFC3339),
+	}
+}
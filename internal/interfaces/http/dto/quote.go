@@ -0,0 +1,171 @@
+// internal/interfaces/http/dto/quote.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/quote"
+)
+
+type QuoteRequest struct {
+	ClientID         uuid.UUID              `json:"client_id" validate:"required"`
+	IssueDate        time.Time              `json:"issue_date" validate:"required"`
+	ExpiryDate       time.Time              `json:"expiry_date" validate:"required"`
+	PaymentTermsDays int                    `json:"payment_terms_days" validate:"gte=0,lte=365"`
+	TaxRate          float64                `json:"tax_rate" validate:"gte=0,lte=100"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Currency         string                 `json:"currency" validate:"required,iso4217"`
+	Notes            string                 `json:"notes"`
+	Items            []CreateInvoiceItemDTO `json:"items" validate:"required,min=1,dive"`
+
+	DiscountType  string  `json:"discount_type" validate:"omitempty,oneof=none percent fixed"`
+	DiscountValue float64 `json:"discount_value" validate:"gte=0"`
+}
+
+type QuoteResponse struct {
+	ID               string           `json:"id"`
+	ClientID         string           `json:"client_id"`
+	QuoteNumber      string           `json:"quote_number"`
+	Status           string           `json:"status"`
+	IssueDate        string           `json:"issue_date"`
+	ExpiryDate       string           `json:"expiry_date"`
+	PaymentTermsDays int              `json:"payment_terms_days"`
+	Subtotal         float64          `json:"subtotal"`
+	DiscountType     string           `json:"discount_type"`
+	DiscountValue    float64          `json:"discount_value"`
+	DiscountAmount   float64          `json:"discount_amount"`
+	TaxRate          float64          `json:"tax_rate"`
+	TaxAmount        float64          `json:"tax_amount"`
+	TaxBreakdown     []TaxSummaryDTO  `json:"tax_breakdown"`
+	PricesIncludeTax bool             `json:"prices_include_tax"`
+	Total            float64          `json:"total"`
+	Currency         string           `json:"currency"`
+	Notes            string           `json:"notes"`
+	Items            []InvoiceItemDTO `json:"items"`
+	PortalURL        *string          `json:"portal_url,omitempty"` // Set once the quote has been sent
+	InvoiceID        *string          `json:"invoice_id"`
+	SentAt           *string          `json:"sent_at"`
+	AcceptedAt       *string          `json:"accepted_at"`
+	DeclinedAt       *string          `json:"declined_at"`
+	CreatedAt        string           `json:"created_at"`
+	UpdatedAt        string           `json:"updated_at"`
+}
+
+// QuoteConversionResponse is a quote along with the invoice it converted
+// into, when conversion succeeded
+type QuoteConversionResponse struct {
+	Quote   QuoteResponse    `json:"quote"`
+	Invoice *InvoiceResponse `json:"invoice,omitempty"`
+}
+
+// PortalQuoteResponse is the client's view of a quote
+type PortalQuoteResponse struct {
+	QuoteNumber    string           `json:"quote_number"`
+	Status         string           `json:"status"`
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	IssueDate      string           `json:"issue_date"`
+	ExpiryDate     string           `json:"expiry_date"`
+	Currency       string           `json:"currency"`
+	Items          []InvoiceItemDTO `json:"items"`
+	Subtotal       float64          `json:"subtotal"`
+	DiscountAmount float64          `json:"discount_amount"`
+	TaxAmount      float64          `json:"tax_amount"`
+	TaxBreakdown   []TaxSummaryDTO  `json:"tax_breakdown"`
+	Total          float64          `json:"total"`
+	Notes          string           `json:"notes"`
+	AcceptedAt     *string          `json:"accepted_at"`
+	DeclinedAt     *string          `json:"declined_at"`
+}
+
+func (r QuoteRequest) ToDomain() quote.QuoteRequest {
+	req := quote.QuoteRequest{
+		ClientID:         r.ClientID,
+		IssueDate:        r.IssueDate,
+		ExpiryDate:       r.ExpiryDate,
+		PaymentTermsDays: r.PaymentTermsDays,
+		TaxRate:          r.TaxRate,
+		PricesIncludeTax: r.PricesIncludeTax,
+		Currency:         r.Currency,
+		Notes:            r.Notes,
+		Items:            make([]invoice.CreateInvoiceItemRequest, len(r.Items)),
+		DiscountType:     invoice.DiscountType(r.DiscountType),
+		DiscountValue:    r.DiscountValue,
+	}
+	for i, item := range r.Items {
+		req.Items[i] = item.ToDomain()
+	}
+	return req
+}
+
+// QuoteFromDomain converts a quote; portalURL is its client link, or empty
+// for drafts
+func QuoteFromDomain(q *quote.Quote, portalURL string) QuoteResponse {
+	resp := QuoteResponse{
+		ID:               q.ID.String(),
+		ClientID:         q.ClientID.String(),
+		QuoteNumber:      q.QuoteNumber,
+		Status:           string(q.Status),
+		IssueDate:        q.IssueDate.Format("2006-01-02"),
+		ExpiryDate:       q.ExpiryDate.Format("2006-01-02"),
+		PaymentTermsDays: q.PaymentTermsDays,
+		Subtotal:         q.Subtotal,
+		DiscountType:     string(q.DiscountType),
+		DiscountValue:    q.DiscountValue,
+		DiscountAmount:   q.DiscountAmount,
+		TaxRate:          q.TaxRate,
+		TaxAmount:        q.TaxAmount,
+		TaxBreakdown:     taxSummaryFromDomain(q.TaxBreakdown()),
+		PricesIncludeTax: q.PricesIncludeTax,
+		Total:            q.Total,
+		Currency:         q.Currency,
+		Notes:            q.Notes,
+		Items:            invoiceItemsFromDomain(q.Items),
+		SentAt:           formatTime(q.SentAt),
+		AcceptedAt:       formatTime(q.AcceptedAt),
+		DeclinedAt:       formatTime(q.DeclinedAt),
+		CreatedAt:        q.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        q.UpdatedAt.Format(time.RFC3339),
+	}
+	if portalURL != "" {
+		resp.PortalURL = &portalURL
+	}
+	if q.InvoiceID != nil {
+		invoiceID := q.InvoiceID.String()
+		resp.InvoiceID = &invoiceID
+	}
+	return resp
+}
+
+func PortalQuoteFromDomain(doc *quote.Document) PortalQuoteResponse {
+	q := doc.Quote
+	return PortalQuoteResponse{
+		QuoteNumber:    q.QuoteNumber,
+		Status:         string(q.Status),
+		From:           doc.IssuerName,
+		To:             doc.Client.DisplayName(),
+		IssueDate:      q.IssueDate.Format("2006-01-02"),
+		ExpiryDate:     q.ExpiryDate.Format("2006-01-02"),
+		Currency:       q.Currency,
+		Items:          invoiceItemsFromDomain(q.Items),
+		Subtotal:       q.Subtotal,
+		DiscountAmount: q.DiscountAmount,
+		TaxAmount:      q.TaxAmount,
+		TaxBreakdown:   taxSummaryFromDomain(q.TaxBreakdown()),
+		Total:          q.Total,
+		Notes:          q.Notes,
+		AcceptedAt:     formatTime(q.AcceptedAt),
+		DeclinedAt:     formatTime(q.DeclinedAt),
+	}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,138 @@
+// internal/interfaces/http/dto/recurring.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/recurring"
+)
+
+type RecurringScheduleRequest struct {
+	ClientID         uuid.UUID              `json:"client_id" validate:"required"`
+	Name             string                 `json:"name" validate:"required,max=255"`
+	Rule             string                 `json:"rule" validate:"required"`       // e.g. FREQ=MONTHLY;BYMONTHDAY=1
+	StartDate        string                 `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate          string                 `json:"end_date"`                       // YYYY-MM-DD, optional
+	Mode             string                 `json:"mode" validate:"required,oneof=draft auto_send"`
+	PaymentTermsDays int                    `json:"payment_terms_days" validate:"gte=0"`
+	TaxRate          float64                `json:"tax_rate" validate:"gte=0,lte=100"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Currency         string                 `json:"currency" validate:"required,iso4217"`
+	Notes            string                 `json:"notes"`
+	Items            []CreateInvoiceItemDTO `json:"items" validate:"required,min=1,dive"`
+
+	DiscountType                string  `json:"discount_type" validate:"omitempty,oneof=none percent fixed"`
+	DiscountValue               float64 `json:"discount_value" validate:"gte=0"`
+	EarlyPaymentDiscountPercent float64 `json:"early_payment_discount_percent" validate:"gte=0,lte=100"`
+	EarlyPaymentDiscountDays    int     `json:"early_payment_discount_days" validate:"gte=0"`
+}
+
+type RecurringScheduleResponse struct {
+	ID               string                 `json:"id"`
+	ClientID         string                 `json:"client_id"`
+	Name             string                 `json:"name"`
+	Rule             string                 `json:"rule"`
+	StartDate        string                 `json:"start_date"`
+	EndDate          *string                `json:"end_date"`
+	Mode             string                 `json:"mode"`
+	Active           bool                   `json:"active"`
+	PaymentTermsDays int                    `json:"payment_terms_days"`
+	NextRunDate      *string                `json:"next_run_date"`
+	LastIssueDate    *string                `json:"last_issue_date"`
+	TaxRate          float64                `json:"tax_rate"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Currency         string                 `json:"currency"`
+	Notes            string                 `json:"notes"`
+	Items            []CreateInvoiceItemDTO `json:"items"`
+
+	DiscountType                string  `json:"discount_type"`
+	DiscountValue               float64 `json:"discount_value"`
+	EarlyPaymentDiscountPercent float64 `json:"early_payment_discount_percent"`
+	EarlyPaymentDiscountDays    int     `json:"early_payment_discount_days"`
+
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Template maps the request's invoice fields to the schedule template
+func (r RecurringScheduleRequest) Template() recurring.Template {
+	template := recurring.Template{
+		Currency:         r.Currency,
+		TaxRate:          r.TaxRate,
+		PricesIncludeTax: r.PricesIncludeTax,
+		Notes:            r.Notes,
+		Items:            make([]recurring.TemplateItem, len(r.Items)),
+
+		DiscountType:                invoice.DiscountType(r.DiscountType),
+		DiscountValue:               r.DiscountValue,
+		EarlyPaymentDiscountPercent: r.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    r.EarlyPaymentDiscountDays,
+	}
+
+	for i, item := range r.Items {
+		template.Items[i] = recurring.TemplateItem{
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			TaxCodeIDs:    item.TaxCodeIDs,
+			TaxExempt:     item.TaxExempt,
+			DiscountType:  invoice.DiscountType(item.DiscountType),
+			DiscountValue: item.DiscountValue,
+		}
+	}
+
+	return template
+}
+
+func RecurringScheduleFromDomain(s *recurring.Schedule) RecurringScheduleResponse {
+	items := make([]CreateInvoiceItemDTO, len(s.Template.Items))
+	for i, item := range s.Template.Items {
+		items[i] = CreateInvoiceItemDTO{
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			TaxCodeIDs:    item.TaxCodeIDs,
+			TaxExempt:     item.TaxExempt,
+			DiscountType:  string(item.DiscountType),
+			DiscountValue: item.DiscountValue,
+		}
+	}
+
+	return RecurringScheduleResponse{
+		ID:               s.ID.String(),
+		ClientID:         s.ClientID.String(),
+		Name:             s.Name,
+		Rule:             s.Rule,
+		StartDate:        s.StartDate.Format("2006-01-02"),
+		EndDate:          formatDate(s.EndDate),
+		Mode:             string(s.Mode),
+		Active:           s.Active,
+		PaymentTermsDays: s.PaymentTermsDays,
+		NextRunDate:      formatDate(s.NextRunDate),
+		LastIssueDate:    formatDate(s.LastIssueDate),
+		TaxRate:          s.Template.TaxRate,
+		PricesIncludeTax: s.Template.PricesIncludeTax,
+		Currency:         s.Template.Currency,
+		Notes:            s.Template.Notes,
+		Items:            items,
+
+		DiscountType:                string(s.Template.DiscountType),
+		DiscountValue:               s.Template.DiscountValue,
+		EarlyPaymentDiscountPercent: s.Template.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    s.Template.EarlyPaymentDiscountDays,
+
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
@@ -0,0 +1,108 @@
+// internal/interfaces/http/dto/report.go
+package dto
+
+import "github.com/invoice-app-be/internal/domain/report"
+
+type RevenueReportResponse struct {
+	BaseCurrency       string               `json:"base_currency"`
+	From               string               `json:"from"`
+	To                 string               `json:"to"`
+	Invoiced           float64              `json:"invoiced"`
+	Collected          float64              `json:"collected"`
+	RealizedFXGainLoss float64              `json:"realized_fx_gain_loss"`
+	ByCurrency         []CurrencyRevenueDTO `json:"by_currency"`
+}
+
+type CurrencyRevenueDTO struct {
+	Currency      string  `json:"currency"`
+	Invoiced      float64 `json:"invoiced"`
+	InvoicedBase  float64 `json:"invoiced_base"`
+	Collected     float64 `json:"collected"`
+	CollectedBase float64 `json:"collected_base"`
+	FXGainLoss    float64 `json:"fx_gain_loss"`
+}
+
+func RevenueReportFromDomain(r *report.RevenueReport) RevenueReportResponse {
+	rows := make([]CurrencyRevenueDTO, len(r.ByCurrency))
+	for i, row := range r.ByCurrency {
+		rows[i] = CurrencyRevenueDTO{
+			Currency:      row.Currency,
+			Invoiced:      row.Invoiced,
+			InvoicedBase:  row.InvoicedBase,
+			Collected:     row.Collected,
+			CollectedBase: row.CollectedBase,
+			FXGainLoss:    row.FXGainLoss,
+		}
+	}
+
+	return RevenueReportResponse{
+		BaseCurrency:       r.BaseCurrency,
+		From:               r.From.Format("2006-01-02"),
+		To:                 r.To.Format("2006-01-02"),
+		Invoiced:           r.Invoiced,
+		Collected:          r.Collected,
+		RealizedFXGainLoss: r.RealizedFXGainLoss,
+		ByCurrency:         rows,
+	}
+}
+
+type TimeReportResponse struct {
+	BaseCurrency string            `json:"base_currency"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	GroupBy      string            `json:"group_by"`
+	Interval     string            `json:"interval,omitempty"`
+	Rows         []TimeRowResponse `json:"rows"`
+	Groups       []TimeRowResponse `json:"groups"`
+	Totals       TimeRowResponse   `json:"totals"`
+}
+
+type TimeRowResponse struct {
+	Period         *string `json:"period,omitempty"`
+	GroupID        *string `json:"group_id,omitempty"`
+	GroupName      string  `json:"group_name"`
+	Hours          float64 `json:"hours"`
+	BillableHours  float64 `json:"billable_hours"`
+	BillableAmount float64 `json:"billable_amount"`
+	Utilization    float64 `json:"utilization"`
+}
+
+func TimeReportFromDomain(r *report.TimeReport) TimeReportResponse {
+	return TimeReportResponse{
+		BaseCurrency: r.BaseCurrency,
+		From:         r.From.Format("2006-01-02"),
+		To:           r.To.Format("2006-01-02"),
+		GroupBy:      string(r.GroupBy),
+		Interval:     string(r.Interval),
+		Rows:         timeRowsFromDomain(r.Rows),
+		Groups:       timeRowsFromDomain(r.Groups),
+		Totals:       timeRowFromDomain(r.Totals),
+	}
+}
+
+func timeRowsFromDomain(rows []report.TimeRow) []TimeRowResponse {
+	response := make([]TimeRowResponse, len(rows))
+	for i, row := range rows {
+		response[i] = timeRowFromDomain(row)
+	}
+	return response
+}
+
+func timeRowFromDomain(row report.TimeRow) TimeRowResponse {
+	response := TimeRowResponse{
+		GroupName:      row.GroupName,
+		Hours:          row.Hours,
+		BillableHours:  row.BillableHours,
+		BillableAmount: row.BillableAmount,
+		Utilization:    row.Utilization,
+	}
+	if row.Period != nil {
+		period := row.Period.Format("2006-01-02")
+		response.Period = &period
+	}
+	if row.GroupID != nil {
+		id := row.GroupID.String()
+		response.GroupID = &id
+	}
+	return response
+}
@@ -0,0 +1,34 @@
+// internal/interfaces/http/dto/session.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/session"
+)
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	Current    bool   `json:"current"` // The session making the request
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+func SessionFromDomain(s *session.Session, current bool) SessionResponse {
+	return SessionResponse{
+		ID:         s.ID.String(),
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		Current:    current,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		LastUsedAt: s.LastUsedAt.Format(time.RFC3339),
+		ExpiresAt:  s.ExpiresAt.Format(time.RFC3339),
+	}
+}
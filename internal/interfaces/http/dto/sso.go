@@ -0,0 +1,18 @@
+// internal/interfaces/http/dto/sso.go
+package dto
+
+type SSOProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// SSOStartResponse carries where to send the user to log in at the provider
+type SSOStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// SSOCallbackRequest carries the query params the provider sent the user
+// back to the redirect URL with
+type SSOCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
@@ -0,0 +1,34 @@
+// internal/interfaces/http/dto/taxcode.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+type TaxCodeRequest struct {
+	Name       string  `json:"name" validate:"required,max=100"`
+	Rate       float64 `json:"rate" validate:"gte=0,lte=100"`
+	IsCompound bool    `json:"is_compound"`
+}
+
+type TaxCodeResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Rate       float64 `json:"rate"`
+	IsCompound bool    `json:"is_compound"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+func TaxCodeFromDomain(code *invoice.TaxCode) TaxCodeResponse {
+	return TaxCodeResponse{
+		ID:         code.ID.String(),
+		Name:       code.Name,
+		Rate:       code.Rate,
+		IsCompound: code.IsCompound,
+		CreatedAt:  code.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  code.UpdatedAt.Format(time.RFC3339),
+	}
+}
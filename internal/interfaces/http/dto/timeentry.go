@@ -2,38 +2,105 @@
 package dto
 
 import (
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/invoice-app-be/internal/domain/timeentry"
 )
 
 type CreateTimeEntryRequest struct {
-	Description string  `json:"description" validate:"required"`
-	Hours       float64 `json:"hours" validate:"required,gt=0"`
-	Date        string  `json:"date"`
-	IsBillable  bool    `json:"is_billable"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	TaskID      *uuid.UUID `json:"task_id"`
+	Description string     `json:"description" validate:"required"`
+	Hours       float64    `json:"hours" validate:"required,gt=0"`
+	HourlyRate  *float64   `json:"hourly_rate" validate:"omitempty,gte=0"`
+	Date        string     `json:"date"`
+	IsBillable  bool       `json:"is_billable"`
 }
 
 type UpdateTimeEntryRequest struct {
-	Description  string  `json:"description" validate:"required"`
-	Hours        float64 `json:"hours" validate:"required,gt=0"`
-	Date         string  `json:"date" validate:"required"`
-	IsBillable   bool    `json:"is_billable"`
-	JiraIssueKey *string `json:"jira_issue_key"`
+	ProjectID    *uuid.UUID `json:"project_id"`
+	TaskID       *uuid.UUID `json:"task_id"`
+	Description  string     `json:"description" validate:"required"`
+	Hours        float64    `json:"hours" validate:"required,gt=0"`
+	HourlyRate   *float64   `json:"hourly_rate" validate:"omitempty,gte=0"`
+	Date         string     `json:"date" validate:"required"`
+	IsBillable   bool       `json:"is_billable"`
+	JiraIssueKey *string    `json:"jira_issue_key"`
+}
+
+// InvoiceTimeRequest bills a client's uninvoiced time, optionally limited to
+// some of its projects and a date range
+type InvoiceTimeRequest struct {
+	ClientID   uuid.UUID   `json:"client_id" validate:"required"`
+	ProjectIDs []uuid.UUID `json:"project_ids"`
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	IssueDate  time.Time   `json:"issue_date" validate:"required"`
+	DueDate    time.Time   `json:"due_date" validate:"required"`
+	Currency   string      `json:"currency" validate:"required,iso4217"`
+	Notes      string      `json:"notes"`
+}
+
+// InvoiceTimeResponse is the invoice time was billed on, with the entries
+// it covers
+type InvoiceTimeResponse struct {
+	Invoice     InvoiceResponse     `json:"invoice"`
+	TimeEntries []TimeEntryResponse `json:"time_entries"`
 }
 
 type SyncToJiraRequest struct {
 	IssueKey string `json:"issue_key" validate:"required"`
 }
 
+type StartTimerRequest struct {
+	ProjectID    *uuid.UUID `json:"project_id"`
+	TaskID       *uuid.UUID `json:"task_id"`
+	Description  string     `json:"description"`
+	IsBillable   bool       `json:"is_billable"`
+	JiraIssueKey *string    `json:"jira_issue_key"`
+}
+
+type StopTimerRequest struct {
+	PushToJira bool `json:"push_to_jira"`
+}
+
+type TimerResponse struct {
+	ID             string  `json:"id"`
+	ProjectID      *string `json:"project_id"`
+	TaskID         *string `json:"task_id"`
+	Description    string  `json:"description"`
+	IsBillable     bool    `json:"is_billable"`
+	JiraIssueKey   *string `json:"jira_issue_key,omitempty"`
+	StartedAt      string  `json:"started_at"`
+	Paused         bool    `json:"paused"`
+	PausedAt       *string `json:"paused_at"`
+	ElapsedSeconds int64   `json:"elapsed_seconds"`
+}
+
+// StopTimerResponse is the entry a stopped timer was recorded as, with the
+// reason it couldn't be pushed to Jira when that failed
+type StopTimerResponse struct {
+	TimeEntry TimeEntryResponse `json:"time_entry"`
+	JiraError string            `json:"jira_error,omitempty"`
+}
+
 type TimeEntryResponse struct {
 	ID            string   `json:"id"`
 	UserID        string   `json:"user_id"`
+	ProjectID     *string  `json:"project_id"`
+	TaskID        *string  `json:"task_id"`
 	InvoiceID     *string  `json:"invoice_id,omitempty"`
+	TimesheetID   *string  `json:"timesheet_id,omitempty"`
 	Description   string   `json:"description"`
 	Hours         float64  `json:"hours"`
 	HourlyRate    *float64 `json:"hourly_rate,omitempty"`
+	RateSource    *string  `json:"rate_source,omitempty"`
 	Date          string   `json:"date"`
+	StartedAt     *string  `json:"started_at,omitempty"`
+	EndedAt       *string  `json:"ended_at,omitempty"`
 	JiraIssueKey  *string  `json:"jira_issue_key,omitempty"`
 	JiraWorklogID *string  `json:"jira_worklog_id,omitempty"`
 	IsBillable    bool     `json:"is_billable"`
@@ -43,6 +110,39 @@ type TimeEntryResponse struct {
 	JiraSyncedAt  *string  `json:"jira_synced_at"`
 }
 
+// TimeEntryPageResponse is a page of time entries. Pass next_cursor back as
+// cursor for the next page; it's empty on the last page.
+type TimeEntryPageResponse struct {
+	TimeEntries []TimeEntryResponse `json:"time_entries"`
+	NextCursor  string              `json:"next_cursor,omitempty"`
+	Totals      TimeEntryTotals     `json:"totals"`
+}
+
+// TimeEntryTotals covers every entry matching the filters, across all pages
+type TimeEntryTotals struct {
+	Count          int     `json:"count"`
+	Hours          float64 `json:"hours"`
+	BillableHours  float64 `json:"billable_hours"`
+	BillableAmount float64 `json:"billable_amount"`
+}
+
+func TimeEntryPageFromDomain(page *timeentry.Page) TimeEntryPageResponse {
+	resp := TimeEntryPageResponse{
+		TimeEntries: make([]TimeEntryResponse, len(page.Entries)),
+		NextCursor:  page.NextCursor,
+		Totals: TimeEntryTotals{
+			Count:          page.Totals.Count,
+			Hours:          page.Totals.Hours,
+			BillableHours:  page.Totals.BillableHours,
+			BillableAmount: page.Totals.BillableAmount,
+		},
+	}
+	for i, entry := range page.Entries {
+		resp.TimeEntries[i] = TimeEntryFromDomain(&entry)
+	}
+	return resp
+}
+
 func TimeEntryFromDomain(entry *timeentry.TimeEntry) TimeEntryResponse {
 	resp := TimeEntryResponse{
 		ID:          entry.ID.String(),
@@ -56,6 +156,20 @@ func TimeEntryFromDomain(entry *timeentry.TimeEntry) TimeEntryResponse {
 		UpdatedAt:   entry.UpdatedAt.Format(time.RFC3339),
 	}
 
+	if entry.StartedAt != nil {
+		startedAt := entry.StartedAt.Format(time.RFC3339)
+		resp.StartedAt = &startedAt
+	}
+
+	if entry.EndedAt != nil {
+		endedAt := entry.EndedAt.Format(time.RFC3339)
+		resp.EndedAt = &endedAt
+	}
+
+	resp.ProjectID = formatUUID(entry.ProjectID)
+	resp.TaskID = formatUUID(entry.TaskID)
+	resp.TimesheetID = formatUUID(entry.TimesheetID)
+
 	if entry.InvoiceID != nil {
 		invoiceID := entry.InvoiceID.String()
 		resp.InvoiceID = &invoiceID
@@ -65,6 +179,8 @@ func TimeEntryFromDomain(entry *timeentry.TimeEntry) TimeEntryResponse {
 		resp.HourlyRate = entry.HourlyRate
 	}
 
+	resp.RateSource = entry.RateSource
+
 	if entry.JiraIssueKey != nil {
 		resp.JiraIssueKey = entry.JiraIssueKey
 	}
@@ -80,3 +196,227 @@ func TimeEntryFromDomain(entry *timeentry.TimeEntry) TimeEntryResponse {
 
 	return resp
 }
+
+func TimerFromDomain(t *timeentry.Timer) TimerResponse {
+	resp := TimerResponse{
+		ID:             t.ID.String(),
+		ProjectID:      formatUUID(t.ProjectID),
+		TaskID:         formatUUID(t.TaskID),
+		Description:    t.Description,
+		IsBillable:     t.IsBillable,
+		JiraIssueKey:   t.JiraIssueKey,
+		StartedAt:      t.StartedAt.Format(time.RFC3339),
+		Paused:         t.IsPaused(),
+		ElapsedSeconds: int64(t.Elapsed(time.Now()).Seconds()),
+	}
+
+	if t.PausedAt != nil {
+		pausedAt := t.PausedAt.Format(time.RFC3339)
+		resp.PausedAt = &pausedAt
+	}
+
+	return resp
+}
+
+func formatUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// TimeEntrySelection picks the entries for a bulk operation: the listed ids,
+// or else every entry matching filter
+type TimeEntrySelection struct {
+	IDs    []uuid.UUID           `json:"ids" validate:"max=1000"`
+	Filter *TimeEntryFilterQuery `json:"filter"`
+}
+
+// TimeEntryFilterQuery takes the same filters as listing time entries
+type TimeEntryFilterQuery struct {
+	StartDate    string     `json:"start_date"`
+	EndDate      string     `json:"end_date"`
+	Billable     *bool      `json:"billable"`
+	Invoiced     *bool      `json:"invoiced"`
+	JiraSynced   *bool      `json:"jira_synced"`
+	JiraIssueKey *string    `json:"jira_issue_key"`
+	ProjectID    *uuid.UUID `json:"project_id"`
+	ClientID     *uuid.UUID `json:"client_id"`
+	Query        string     `json:"q"`
+}
+
+type BulkBillableRequest struct {
+	TimeEntrySelection
+	IsBillable bool `json:"is_billable"`
+}
+
+type BulkJiraIssueRequest struct {
+	TimeEntrySelection
+	JiraIssueKey *string `json:"jira_issue_key"` // null clears the issue
+}
+
+type BulkProjectRequest struct {
+	TimeEntrySelection
+	ProjectID *uuid.UUID `json:"project_id"` // null with no task_id clears the project
+	TaskID    *uuid.UUID `json:"task_id"`
+}
+
+type BulkResultResponse struct {
+	ID        string `json:"id"`
+	OK        bool   `json:"ok"`
+	Error     string `json:"error,omitempty"`
+	InvoiceID string `json:"invoice_id,omitempty"` // The sent invoice keeping the entry from being changed
+}
+
+// InvoicedErrorResponse is the conflict returned for changes to time billed
+// on a sent invoice
+type InvoicedErrorResponse struct {
+	Error         string `json:"error"`
+	InvoiceID     string `json:"invoice_id"`
+	InvoiceNumber string `json:"invoice_number"`
+	InvoiceStatus string `json:"invoice_status"`
+}
+
+type BulkResponse struct {
+	Results   []BulkResultResponse `json:"results"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+}
+
+func (s TimeEntrySelection) ToDomain() (timeentry.Selection, error) {
+	sel := timeentry.Selection{IDs: s.IDs}
+	if s.Filter == nil {
+		return sel, nil
+	}
+
+	f := s.Filter
+	filters := timeentry.ListFilters{
+		IsBillable:   f.Billable,
+		IsInvoiced:   f.Invoiced,
+		JiraSynced:   f.JiraSynced,
+		JiraIssueKey: f.JiraIssueKey,
+		ProjectID:    f.ProjectID,
+		ClientID:     f.ClientID,
+		Search:       f.Query,
+	}
+	if f.StartDate != "" {
+		date, err := time.Parse("2006-01-02", f.StartDate)
+		if err != nil {
+			return sel, err
+		}
+		filters.DateFrom = &date
+	}
+	if f.EndDate != "" {
+		date, err := time.Parse("2006-01-02", f.EndDate)
+		if err != nil {
+			return sel, err
+		}
+		filters.DateTo = &date
+	}
+	sel.Filter = &filters
+	return sel, nil
+}
+
+func BulkResultsFromDomain(results []timeentry.BulkResult) BulkResponse {
+	resp := BulkResponse{Results: make([]BulkResultResponse, len(results))}
+	for i, result := range results {
+		resp.Results[i] = BulkResultResponse{ID: result.ID.String(), OK: result.Error == nil}
+		if result.Error != nil {
+			resp.Results[i].Error = result.Error.Error()
+			var invoiced *timeentry.InvoicedError
+			if errors.As(result.Error, &invoiced) {
+				resp.Results[i].InvoiceID = invoiced.InvoiceID.String()
+			}
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+	return resp
+}
+
+func InvoicedErrorFromDomain(err *timeentry.InvoicedError) InvoicedErrorResponse {
+	return InvoicedErrorResponse{
+		Error:         err.Error(),
+		InvoiceID:     err.InvoiceID.String(),
+		InvoiceNumber: err.InvoiceNumber,
+		InvoiceStatus: string(err.Status),
+	}
+}
+
+// ImportMapping names the CSV columns to import; set fields override the
+// chosen preset's
+type ImportMapping struct {
+	ExternalID   string `json:"external_id"`
+	Date         string `json:"date"`
+	StartTime    string `json:"start_time"`
+	EndDate      string `json:"end_date"`
+	EndTime      string `json:"end_time"`
+	Duration     string `json:"duration"`
+	Hours        string `json:"hours"`
+	Description  string `json:"description"`
+	Project      string `json:"project"`
+	Task         string `json:"task"`
+	Billable     string `json:"billable"`
+	JiraIssueKey string `json:"jira_issue_key"`
+	DateLayout   string `json:"date_layout"`
+	TimeLayout   string `json:"time_layout"`
+}
+
+type ImportRowErrorResponse struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type ImportResponse struct {
+	Rows       int                      `json:"rows"`
+	Imported   int                      `json:"imported"`
+	Duplicates int                      `json:"duplicates"`
+	Failed     int                      `json:"failed"`
+	Errors     []ImportRowErrorResponse `json:"errors"`
+	DryRun     bool                     `json:"dry_run"`
+}
+
+// Over returns base with the mapping's set fields replacing its own
+func (m ImportMapping) Over(base timeentry.ColumnMapping) timeentry.ColumnMapping {
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&base.ExternalID, m.ExternalID},
+		{&base.Date, m.Date},
+		{&base.StartTime, m.StartTime},
+		{&base.EndDate, m.EndDate},
+		{&base.EndTime, m.EndTime},
+		{&base.Duration, m.Duration},
+		{&base.Hours, m.Hours},
+		{&base.Description, m.Description},
+		{&base.Project, m.Project},
+		{&base.Task, m.Task},
+		{&base.Billable, m.Billable},
+		{&base.JiraIssueKey, m.JiraIssueKey},
+		{&base.DateLayout, m.DateLayout},
+		{&base.TimeLayout, m.TimeLayout},
+	} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+	return base
+}
+
+func ImportResultFromDomain(result *timeentry.ImportResult) ImportResponse {
+	resp := ImportResponse{
+		Rows:       result.Rows,
+		Imported:   result.Imported,
+		Duplicates: result.Duplicates,
+		Failed:     len(result.Errors),
+		Errors:     make([]ImportRowErrorResponse, len(result.Errors)),
+		DryRun:     result.DryRun,
+	}
+	for i, rowErr := range result.Errors {
+		resp.Errors[i] = ImportRowErrorResponse{Row: rowErr.Row, Error: rowErr.Error}
+	}
+	return resp
+}
@@ -0,0 +1,121 @@
+// internal/interfaces/http/dto/timesheet.go
+package dto
+
+import (
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/timesheet"
+)
+
+type SubmitTimesheetRequest struct {
+	ApproverEmail *string `json:"approver_email" validate:"omitempty,email"`
+	ReviewerEmail *string `json:"reviewer_email" validate:"omitempty,email"`
+}
+
+type ReviewTimesheetRequest struct {
+	Comment string `json:"comment"`
+}
+
+// PortalReviewRequest is a client reviewer's decision through a review link
+type PortalReviewRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Comment string `json:"comment"`
+}
+
+type TimesheetResponse struct {
+	ID            string  `json:"id"`
+	WeekStart     string  `json:"week_start"`
+	WeekEnd       string  `json:"week_end"`
+	Status        string  `json:"status"`
+	ApproverID    *string `json:"approver_id"`
+	ReviewerEmail *string `json:"reviewer_email"`
+	SubmittedAt   *string `json:"submitted_at"`
+	ReviewedAt    *string `json:"reviewed_at"`
+	ReviewedBy    *string `json:"reviewed_by"`
+	Comment       *string `json:"comment"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type WeekResponse struct {
+	WeekStart          string                `json:"week_start"`
+	WeekEnd            string                `json:"week_end"`
+	Owner              string                `json:"owner"`
+	Status             string                `json:"status"`
+	Timesheet          *TimesheetResponse    `json:"timesheet"`
+	Days               []DayResponse         `json:"days"`
+	Projects           []ProjectWeekResponse `json:"projects"`
+	Hours              float64               `json:"hours"`
+	BillableHours      float64               `json:"billable_hours"`
+	UnsubmittedEntries int                   `json:"unsubmitted_entries"`
+	Entries            []TimeEntryResponse   `json:"entries"`
+}
+
+type DayResponse struct {
+	Date          string  `json:"date"`
+	Hours         float64 `json:"hours"`
+	BillableHours float64 `json:"billable_hours"`
+}
+
+// ProjectWeekResponse is a project's hours for each day, Monday first
+type ProjectWeekResponse struct {
+	ProjectID *string   `json:"project_id"`
+	Name      string    `json:"name"`
+	Hours     float64   `json:"hours"`
+	Days      []float64 `json:"days"`
+}
+
+func TimesheetFromDomain(ts *timesheet.Timesheet) TimesheetResponse {
+	return TimesheetResponse{
+		ID:            ts.ID.String(),
+		WeekStart:     ts.WeekStart.Format("2006-01-02"),
+		WeekEnd:       ts.WeekStart.AddDate(0, 0, 6).Format("2006-01-02"),
+		Status:        string(ts.Status),
+		ApproverID:    formatUUID(ts.ApproverID),
+		ReviewerEmail: ts.ReviewerEmail,
+		SubmittedAt:   formatTime(ts.SubmittedAt),
+		ReviewedAt:    formatTime(ts.ReviewedAt),
+		ReviewedBy:    ts.ReviewedBy,
+		Comment:       ts.Comment,
+		CreatedAt:     ts.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     ts.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func WeekFromDomain(week *timesheet.Week) WeekResponse {
+	resp := WeekResponse{
+		WeekStart:          week.Start.Format("2006-01-02"),
+		WeekEnd:            week.Start.AddDate(0, 0, 6).Format("2006-01-02"),
+		Owner:              week.Owner,
+		Status:             string(week.Status),
+		Days:               make([]DayResponse, len(week.Days)),
+		Projects:           make([]ProjectWeekResponse, len(week.Projects)),
+		Hours:              week.Hours,
+		BillableHours:      week.BillableHours,
+		UnsubmittedEntries: week.Unsubmitted,
+		Entries:            make([]TimeEntryResponse, len(week.Entries)),
+	}
+	if week.Timesheet != nil {
+		ts := TimesheetFromDomain(week.Timesheet)
+		resp.Timesheet = &ts
+	}
+	for i, day := range week.Days {
+		resp.Days[i] = DayResponse{
+			Date:          day.Date.Format("2006-01-02"),
+			Hours:         day.Hours,
+			BillableHours: day.BillableHours,
+		}
+	}
+	for i, p := range week.Projects {
+		resp.Projects[i] = ProjectWeekResponse{
+			ProjectID: formatUUID(p.ProjectID),
+			Name:      p.Name,
+			Hours:     p.Hours,
+			Days:      p.Days[:],
+		}
+	}
+	for i, entry := range week.Entries {
+		resp.Entries[i] = TimeEntryFromDomain(&entry)
+	}
+	return resp
+}
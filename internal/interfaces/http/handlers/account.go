@@ -0,0 +1,117 @@
+// internal/interfaces/http/handlers/account.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/invoice-app-be/internal/domain/session"
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type AccountHandler struct {
+	userService    *user.Service
+	sessionService *session.Service
+}
+
+func NewAccountHandler(userService *user.Service, sessionService *session.Service) *AccountHandler {
+	return &AccountHandler{userService: userService, sessionService: sessionService}
+}
+
+func (h *AccountHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	u, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.AccountFromDomain(u))
+}
+
+func (h *AccountHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	var req dto.UpdateAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	u, err := h.userService.UpdateProfile(r.Context(), userID, user.UpdateProfileRequest{
+		FullName: req.FullName,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update account")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.AccountFromDomain(u))
+}
+
+// ChangePassword sets a new password and logs the user out of their other
+// sessions
+func (h *AccountHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	var req dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.userService.ChangePassword(r.Context(), userID, req.CurrentPassword, req.NewPassword)
+	if errors.Is(err, user.ErrInvalidCredentials) {
+		respondError(w, http.StatusForbidden, "Current password is incorrect")
+		return
+	}
+	if errors.Is(err, user.ErrWeakPassword) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+
+	if err := h.sessionService.RevokeOthers(r.Context(), userID, middleware.GetSessionID(r.Context())); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to end other sessions")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// ResendVerification emails a new link to verify the user's address
+func (h *AccountHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	err := h.userService.SendVerification(r.Context(), userID)
+	switch {
+	case errors.Is(err, user.ErrAlreadyVerified):
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, user.ErrEmailDisabled):
+		respondError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	case err != nil:
+		respondError(w, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, nil)
+}
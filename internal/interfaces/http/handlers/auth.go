@@ -3,21 +3,42 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/mfa"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/session"
+	"github.com/invoice-app-be/internal/domain/sso"
 	"github.com/invoice-app-be/internal/domain/user"
 	"github.com/invoice-app-be/internal/infrastructure/auth"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
 )
 
 type AuthHandler struct {
-	userService *user.Service
-	jwtManager  *auth.JWTManager
+	userService         *user.Service
+	organizationService *organization.Service
+	sessionService      *session.Service
+	mfaService          *mfa.Service
+	ssoService          *sso.Service
+	jwtManager          *auth.JWTManager
 }
 
-func NewAuthHandler(userService *user.Service, jwtManager *auth.JWTManager) *AuthHandler {
+func NewAuthHandler(userService *user.Service, organizationService *organization.Service, sessionService *session.Service,
+	mfaService *mfa.Service, ssoService *sso.Service, jwtManager *auth.JWTManager) *AuthHandler {
 	return &AuthHandler{
-		userService: userService,
-		jwtManager:  jwtManager,
+		userService:         userService,
+		organizationService: organizationService,
+		sessionService:      sessionService,
+		mfaService:          mfaService,
+		ssoService:          ssoService,
+		jwtManager:          jwtManager,
 	}
 }
 
@@ -33,8 +54,10 @@ type LoginRequest struct {
 }
 
 type AuthResponse struct {
-	Token string  `json:"token"`
-	User  UserDTO `json:"user"`
+	Token        string  `json:"token"`
+	RefreshToken string  `json:"refresh_token"`
+	ExpiresIn    int     `json:"expires_in"` // Seconds until the access token expires
+	User         UserDTO `json:"user"`
 }
 
 type UserDTO struct {
@@ -56,20 +79,13 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.jwtManager.Generate(authenticate.ID, authenticate.Email)
+	org, err := h.organizationService.CreatePersonal(r.Context(), authenticate)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate token")
+		respondError(w, http.StatusInternalServerError, "Failed to create organization")
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, AuthResponse{
-		Token: token,
-		User: UserDTO{
-			ID:       authenticate.ID.String(),
-			Email:    authenticate.Email,
-			FullName: authenticate.FullName,
-		},
-	})
+	h.startSession(w, r, http.StatusCreated, authenticate, org.ID, false)
 }
 
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
@@ -84,18 +100,436 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.jwtManager.Generate(authenticate.ID, authenticate.Email)
+	h.authenticated(w, r, authenticate)
+}
+
+// SSOProviders lists the identity providers users can log in with
+func (h *AuthHandler) SSOProviders(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, dto.SSOProvidersResponse{Providers: h.ssoService.Providers()})
+}
+
+// The SSO state cookie binds a login's state to the browser that started it
+const (
+	ssoStateCookie = "sso_state"
+	ssoCookiePath  = "/api/auth/sso"
+)
+
+// SSOStart begins logging in with an identity provider, returning the URL
+// to send the user to. The provider sends them back to its redirect URL.
+func (h *AuthHandler) SSOStart(w http.ResponseWriter, r *http.Request) {
+	authorizationURL, state, err := h.ssoService.Start(r.Context(), chi.URLParam(r, "provider"))
+	if errors.Is(err, sso.ErrUnknownProvider) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadGateway, "Identity provider is unavailable")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    state,
+		Path:     ssoCookiePath,
+		Expires:  time.Now().Add(sso.LoginLifetime),
+		MaxAge:   int(sso.LoginLifetime.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	respondJSON(w, http.StatusOK, dto.SSOStartResponse{AuthorizationURL: authorizationURL})
+}
+
+// SSOCallback completes logging in with an identity provider, given the code
+// and state it sent the user back with
+func (h *AuthHandler) SSOCallback(w http.ResponseWriter, r *http.Request) {
+	var req dto.SSOCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The state works once, so the cookie is cleared whatever the outcome
+	var boundState string
+	if cookie, err := r.Cookie(ssoStateCookie); err == nil {
+		boundState = cookie.Value
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Path:     ssoCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	u, err := h.ssoService.Complete(r.Context(), chi.URLParam(r, "provider"), req.Code, req.State, boundState)
+	switch {
+	case errors.Is(err, sso.ErrUnknownProvider):
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, sso.ErrInvalidState):
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, sso.ErrEmailNotVerified), errors.Is(err, sso.ErrDomainNotAllowed),
+		errors.Is(err, sso.ErrAccountNotVerified):
+		respondError(w, http.StatusForbidden, err.Error())
+		return
+	case err != nil:
+		respondError(w, http.StatusUnauthorized, "Identity provider login failed")
+		return
+	}
+
+	h.authenticated(w, r, u)
+}
+
+// authenticated logs in a user who proved who they are with a password or
+// identity provider. With two-factor authentication that only earns a
+// challenge, completed at LoginMFA.
+func (h *AuthHandler) authenticated(w http.ResponseWriter, r *http.Request, u *user.User) {
+	enabled, err := h.mfaService.Enabled(r.Context(), u.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check two-factor authentication")
+		return
+	}
+	if enabled {
+		token, err := h.jwtManager.GenerateChallenge(u.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to generate token")
+			return
+		}
+		respondJSON(w, http.StatusOK, dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    token,
+			ExpiresIn:   int(h.jwtManager.ChallengeDuration().Seconds()),
+		})
+		return
+	}
+
+	h.logIn(w, r, u, false)
+}
+
+// LoginMFA completes a login with the challenge token from Login and a code
+// from the user's authenticator app or a recovery code
+func (h *AuthHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req dto.MFALoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, err := h.jwtManager.VerifyChallenge(req.MFAToken)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Invalid or expired MFA token")
+		return
+	}
+
+	err = h.mfaService.Verify(r.Context(), userID, req.Code)
+	if errors.Is(err, mfa.ErrInvalidCode) || errors.Is(err, mfa.ErrNotEnabled) {
+		respondError(w, http.StatusUnauthorized, mfa.ErrInvalidCode.Error())
+		return
+	}
+	if errors.Is(err, mfa.ErrTooManyAttempts) {
+		respondError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to verify code")
+		return
+	}
+
+	u, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+
+	h.logIn(w, r, u, true)
+}
+
+// logIn starts a session for a user who proved who they are
+func (h *AuthHandler) logIn(w http.ResponseWriter, r *http.Request, u *user.User, mfaVerified bool) {
+	// Users start in the organization they joined first. Anyone left
+	// without one, such as by an interrupted registration, gets their own.
+	organizationID, err := h.startingOrganization(r, u)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to resolve organization")
+		return
+	}
+
+	h.startSession(w, r, http.StatusOK, u, organizationID, mfaVerified)
+}
+
+// Refresh trades a refresh token for a new access token and the next
+// refresh token. Reusing a refresh token revokes its session.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sess, refreshToken, err := h.sessionService.Refresh(r.Context(), req.RefreshToken, sessionClient(r))
+	if errors.Is(err, session.ErrInvalidRefreshToken) || errors.Is(err, session.ErrRefreshTokenReused) ||
+		errors.Is(err, session.ErrSessionEnded) {
+		respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to refresh session")
+		return
+	}
+
+	u, err := h.userService.GetUser(r.Context(), sess.UserID)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+
+	// Members removed from the session's organization carry on in another
+	organizationID := sess.OrganizationID
+	if _, err := h.organizationService.Principal(r.Context(), organizationID, u.ID); err != nil {
+		if !errors.Is(err, organization.ErrNotMember) {
+			respondError(w, http.StatusInternalServerError, "Failed to resolve organization")
+			return
+		}
+		if organizationID, err = h.startingOrganization(r, u); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to resolve organization")
+			return
+		}
+		if err := h.sessionService.SwitchOrganization(r.Context(), sess.ID, organizationID); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to refresh session")
+			return
+		}
+	}
+
+	h.respondTokens(w, http.StatusOK, u, organizationID, sess.ID, refreshToken)
+}
+
+// Logout revokes the session the access token belongs to
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	if err := h.sessionService.Revoke(r.Context(), userID, middleware.GetSessionID(r.Context())); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// LogoutEverywhere revokes every one of the user's sessions, this one included
+func (h *AuthHandler) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	if err := h.sessionService.RevokeAll(r.Context(), userID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// Sessions lists the user's active sessions
+func (h *AuthHandler) Sessions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+	current := middleware.GetSessionID(r.Context())
+
+	sessions, err := h.sessionService.List(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch sessions")
+		return
+	}
+
+	response := make([]dto.SessionResponse, len(sessions))
+	for i, sess := range sessions {
+		response[i] = dto.SessionFromDomain(&sess, sess.ID == current)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RevokeSession logs one of the user's sessions out
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	err = h.sessionService.Revoke(r.Context(), userID, sessionID)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		respondError(w, http.StatusNotFound, "Session not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// VerifyEmail confirms the user's address with the token from the
+// verification email
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	u, err := h.userService.VerifyEmail(r.Context(), req.Token)
+	if errors.Is(err, user.ErrInvalidToken) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to verify email")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.AccountFromDomain(u))
+}
+
+// ForgotPassword emails a reset link. It answers the same whether or not
+// the address has an account.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.userService.RequestPasswordReset(r.Context(), req.Email)
+	if errors.Is(err, user.ErrEmailDisabled) {
+		respondError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to send reset email")
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, nil)
+}
+
+// ResetPassword sets a new password with the token from the reset email and
+// logs the user out everywhere
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	u, err := h.userService.ResetPassword(r.Context(), req.Token, req.Password)
+	if errors.Is(err, user.ErrInvalidToken) || errors.Is(err, user.ErrWeakPassword) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	if err := h.sessionService.RevokeAll(r.Context(), u.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to end sessions")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// JWKS publishes the public keys access tokens are verified with
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondJSON(w, http.StatusOK, h.jwtManager.JWKS())
+}
+
+// startSession opens a session for the user working in the organization and
+// responds with its tokens
+func (h *AuthHandler) startSession(w http.ResponseWriter, r *http.Request, status int, u *user.User, organizationID uuid.UUID,
+	mfaVerified bool) {
+	sess, refreshToken, err := h.sessionService.Start(r.Context(), u.ID, organizationID, sessionClient(r), mfaVerified)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to start session")
+		return
+	}
+
+	h.respondTokens(w, status, u, organizationID, sess.ID, refreshToken)
+}
+
+func (h *AuthHandler) respondTokens(w http.ResponseWriter, status int, u *user.User, organizationID, sessionID uuid.UUID,
+	refreshToken string) {
+	token, err := h.jwtManager.Generate(u.ID, organizationID, sessionID, u.Email)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, AuthResponse{
-		Token: token,
+	respondJSON(w, status, AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(h.jwtManager.Duration().Seconds()),
 		User: UserDTO{
-			ID:       authenticate.ID.String(),
-			Email:    authenticate.Email,
-			FullName: authenticate.FullName,
+			ID:       u.ID.String(),
+			Email:    u.Email,
+			FullName: u.FullName,
 		},
 	})
 }
+
+func (h *AuthHandler) startingOrganization(r *http.Request, u *user.User) (uuid.UUID, error) {
+	p, err := h.organizationService.DefaultPrincipal(r.Context(), u.ID)
+	if errors.Is(err, organization.ErrNoOrganization) {
+		org, err := h.organizationService.CreatePersonal(r.Context(), u)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return org.ID, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return p.OrganizationID, nil
+}
+
+func sessionClient(r *http.Request) session.Client {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return session.Client{UserAgent: r.UserAgent(), IPAddress: ip}
+}
@@ -0,0 +1,185 @@
+// internal/interfaces/http/handlers/dunning.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/dunning"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type DunningHandler struct {
+	service *dunning.Service
+}
+
+func NewDunningHandler(service *dunning.Service) *DunningHandler {
+	return &DunningHandler{service: service}
+}
+
+func (h *DunningHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	sequences, err := h.service.ListSequences(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch reminder sequences")
+		return
+	}
+
+	response := make([]dto.ReminderSequenceResponse, len(sequences))
+	for i, sequence := range sequences {
+		response[i] = dto.ReminderSequenceFromDomain(&sequence)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *DunningHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.ReminderSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sequence, err := h.service.CreateSequence(r.Context(), p, req.ToDomain())
+	if err != nil {
+		respondDunningError(w, err, "Failed to create reminder sequence")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ReminderSequenceFromDomain(sequence))
+}
+
+func (h *DunningHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	sequenceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid reminder sequence ID")
+		return
+	}
+
+	sequence, err := h.service.GetSequence(r.Context(), p, sequenceID)
+	if err != nil {
+		respondDunningError(w, err, "Failed to fetch reminder sequence")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ReminderSequenceFromDomain(sequence))
+}
+
+func (h *DunningHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	sequenceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid reminder sequence ID")
+		return
+	}
+
+	var req dto.ReminderSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sequence, err := h.service.UpdateSequence(r.Context(), p, sequenceID, req.ToDomain())
+	if err != nil {
+		respondDunningError(w, err, "Failed to update reminder sequence")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ReminderSequenceFromDomain(sequence))
+}
+
+func (h *DunningHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	sequenceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid reminder sequence ID")
+		return
+	}
+
+	if err := h.service.DeleteSequence(r.Context(), p, sequenceID); err != nil {
+		respondDunningError(w, err, "Failed to delete reminder sequence")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// InvoiceReminders lists the reminders handled for an invoice
+func (h *DunningHandler) InvoiceReminders(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	reminders, err := h.service.ListReminders(r.Context(), p, invoiceID)
+	if err != nil {
+		respondDunningError(w, err, "Failed to fetch reminders")
+		return
+	}
+
+	response := make([]dto.ReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		response[i] = dto.ReminderFromDomain(&reminder)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// AssignSequence picks the reminder sequence an invoice follows
+func (h *DunningHandler) AssignSequence(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	var req dto.AssignReminderSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	inv, err := h.service.AssignSequence(r.Context(), p, invoiceID, req.SequenceID)
+	if err != nil {
+		respondDunningError(w, err, "Failed to update invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.InvoiceFromDomain(inv))
+}
+
+func respondDunningError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, dunning.ErrSequenceNotFound), errors.Is(err, dunning.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Reminder sequence not found")
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+	case errors.Is(err, dunning.ErrInvalidSequence):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
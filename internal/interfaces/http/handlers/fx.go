@@ -0,0 +1,76 @@
+// internal/interfaces/http/handlers/fx.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/fx"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type ExchangeRateHandler struct {
+	service *fx.Service
+}
+
+func NewExchangeRateHandler(service *fx.Service) *ExchangeRateHandler {
+	return &ExchangeRateHandler{service: service}
+}
+
+func (h *ExchangeRateHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	rates, err := h.service.ListRates(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch exchange rates")
+		return
+	}
+
+	response := make([]dto.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = dto.ExchangeRateFromDomain(&rate)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *ExchangeRateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.CreateExchangeRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid effective_date format. Expected YYYY-MM-DD")
+		return
+	}
+
+	rate, err := h.service.CreateRate(r.Context(), p, fx.CreateRateRequest{
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Rate:          req.Rate,
+		EffectiveDate: effectiveDate,
+	})
+	if errors.Is(err, fx.ErrInvalidCurrency) || errors.Is(err, fx.ErrInvalidRate) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to save exchange rate")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ExchangeRateFromDomain(rate))
+}
@@ -0,0 +1,118 @@
+// internal/interfaces/http/handlers/hourlyrate.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type HourlyRateHandler struct {
+	service *hourlyrate.Service
+}
+
+func NewHourlyRateHandler(service *hourlyrate.Service) *HourlyRateHandler {
+	return &HourlyRateHandler{service: service}
+}
+
+func (h *HourlyRateHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var filters hourlyrate.ListFilters
+	if scope := r.URL.Query().Get("scope"); scope != "" {
+		s := hourlyrate.Scope(scope)
+		filters.Scope = &s
+	}
+	if scopeID := r.URL.Query().Get("scope_id"); scopeID != "" {
+		id, err := uuid.Parse(scopeID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid scope ID")
+			return
+		}
+		filters.ScopeID = &id
+	}
+
+	rates, err := h.service.ListRates(r.Context(), p, filters)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch hourly rates")
+		return
+	}
+
+	response := make([]dto.HourlyRateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = dto.HourlyRateFromDomain(&rate)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *HourlyRateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.CreateHourlyRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid effective_date format. Expected YYYY-MM-DD")
+		return
+	}
+
+	rate, err := h.service.CreateRate(r.Context(), p, hourlyrate.CreateRateRequest{
+		Scope:         hourlyrate.Scope(req.Scope),
+		ScopeID:       req.ScopeID,
+		HourlyRate:    req.HourlyRate,
+		EffectiveDate: effectiveDate,
+	})
+	if errors.Is(err, hourlyrate.ErrInvalidRate) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, hourlyrate.ErrScopeNotFound) {
+		respondError(w, http.StatusBadRequest, "Task, project or client not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to save hourly rate")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.HourlyRateFromDomain(rate))
+}
+
+func (h *HourlyRateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	rateID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid hourly rate ID")
+		return
+	}
+
+	err = h.service.DeleteRate(r.Context(), p, rateID)
+	if errors.Is(err, hourlyrate.ErrRateNotFound) || errors.Is(err, hourlyrate.ErrUnauthorized) {
+		respondError(w, http.StatusNotFound, "Hourly rate not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to delete hourly rate")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
 	"github.com/invoice-app-be/internal/interfaces/http/dto"
 	"github.com/invoice-app-be/internal/interfaces/http/middleware"
 )
@@ -29,7 +31,7 @@ func NewInvoiceHandler(service *invoice.Service, clientRepo interface{}) *Invoic
 }
 
 func (h *InvoiceHandler) Create(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 
 	var req dto.CreateInvoiceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -45,26 +47,44 @@ func (h *InvoiceHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Map DTO to domain request
 	domainReq := invoice.CreateInvoiceRequest{
-		ClientID:  req.ClientID,
-		IssueDate: req.IssueDate,
-		DueDate:   req.DueDate,
-		TaxRate:   req.TaxRate,
-		Currency:  req.Currency,
-		Notes:     req.Notes,
-		Items:     make([]invoice.CreateInvoiceItemRequest, len(req.Items)),
+		ClientID:         req.ClientID,
+		IssueDate:        req.IssueDate,
+		DueDate:          req.DueDate,
+		TaxRate:          req.TaxRate,
+		PricesIncludeTax: req.PricesIncludeTax,
+		Currency:         req.Currency,
+		Notes:            req.Notes,
+		Items:            make([]invoice.CreateInvoiceItemRequest, len(req.Items)),
+
+		DiscountType:                invoice.DiscountType(req.DiscountType),
+		DiscountValue:               req.DiscountValue,
+		EarlyPaymentDiscountPercent: req.EarlyPaymentDiscountPercent,
+		EarlyPaymentDiscountDays:    req.EarlyPaymentDiscountDays,
 	}
 
 	for i, item := range req.Items {
-		domainReq.Items[i] = invoice.CreateInvoiceItemRequest{
-			Description: item.Description,
-			Quantity:    item.Quantity,
-			UnitPrice:   item.UnitPrice,
-		}
+		domainReq.Items[i] = item.ToDomain()
 	}
 
-	inv, err := h.service.CreateInvoice(r.Context(), userID, domainReq)
+	inv, err := h.service.CreateInvoice(r.Context(), p, domainReq)
+	if errors.Is(err, invoice.ErrTaxCodeNotFound) {
+		respondError(w, http.StatusBadRequest, "Unknown tax code")
+		return
+	}
+	if errors.Is(err, invoice.ErrInvalidDiscount) {
+		respondError(w, http.StatusBadRequest, "Invalid discount")
+		return
+	}
+	if errors.Is(err, invoice.ErrInvalidCurrency) {
+		respondError(w, http.StatusBadRequest, "Invalid currency")
+		return
+	}
+	if errors.Is(err, invoice.ErrExchangeRateNotFound) {
+		respondError(w, http.StatusUnprocessableEntity, "No exchange rate to the account's base currency")
+		return
+	}
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create invoice")
+		respondFailure(w, err, "Failed to create invoice")
 		return
 	}
 
@@ -88,20 +108,182 @@ func (h *InvoiceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *InvoiceHandler) Send(w http.ResponseWriter, r *http.Request) {
-	respondJSON(w, http.StatusOK, map[string]string{"status": "sent"})
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	// The body is optional; an empty one sends with the defaults
+	var req dto.SendInvoiceRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	inv, delivery, err := h.service.SendInvoice(r.Context(), p, invoiceID, invoice.SendOptions{
+		CC:      req.CC,
+		BCC:     req.BCC,
+		ReplyTo: req.ReplyTo,
+		Message: req.Message,
+	})
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	case errors.Is(err, invoice.ErrInvalidStatusTransition):
+		respondError(w, http.StatusConflict, "Invoice cannot be sent in its current status")
+		return
+	case errors.Is(err, invoice.ErrClientHasNoEmail):
+		respondError(w, http.StatusUnprocessableEntity, "Client has no email address")
+		return
+	case errors.Is(err, invoice.ErrEmailNotVerified):
+		respondError(w, http.StatusForbidden, "Verify your email address before sending invoices")
+		return
+	case errors.Is(err, invoice.ErrDeliveryFailed):
+		respondError(w, http.StatusBadGateway, err.Error())
+		return
+	case err != nil:
+		respondFailure(w, err, "Failed to send invoice")
+		return
+	}
+
+	response := dto.SendInvoiceResponse{Invoice: dto.InvoiceFromDomain(inv)}
+	if delivery != nil {
+		d := dto.DeliveryFromDomain(delivery)
+		response.Delivery = &d
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RevertToDraft takes an unpaid sent invoice back to draft, unlocking the
+// time billed on it
+func (h *InvoiceHandler) RevertToDraft(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	inv, err := h.service.RevertToDraft(r.Context(), p, invoiceID)
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	case errors.Is(err, invoice.ErrInvalidStatusTransition):
+		respondError(w, http.StatusConflict, "Only sent or overdue invoices with nothing paid can be reverted to draft")
+		return
+	case err != nil:
+		respondFailure(w, err, "Failed to revert invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.InvoiceFromDomain(inv))
+}
+
+func (h *InvoiceHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), p, invoiceID)
+	if errors.Is(err, invoice.ErrInvoiceNotFound) || errors.Is(err, invoice.ErrUnauthorized) {
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch deliveries")
+		return
+	}
+
+	response := make([]dto.DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = dto.DeliveryFromDomain(&delivery)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *InvoiceHandler) PauseReminders(w http.ResponseWriter, r *http.Request) {
+	h.setRemindersPaused(w, r, true)
+}
+
+func (h *InvoiceHandler) ResumeReminders(w http.ResponseWriter, r *http.Request) {
+	h.setRemindersPaused(w, r, false)
+}
+
+// Activity returns the invoice's timeline, newest first
+func (h *InvoiceHandler) Activity(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	activities, err := h.service.ListActivities(r.Context(), p, invoiceID)
+	if errors.Is(err, invoice.ErrInvoiceNotFound) || errors.Is(err, invoice.ErrUnauthorized) {
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch activity")
+		return
+	}
+
+	response := make([]dto.ActivityResponse, len(activities))
+	for i, activity := range activities {
+		response[i] = dto.ActivityFromDomain(&activity)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *InvoiceHandler) setRemindersPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	inv, err := h.service.SetRemindersPaused(r.Context(), p, invoiceID, paused)
+	if errors.Is(err, invoice.ErrInvoiceNotFound) || errors.Is(err, invoice.ErrUnauthorized) {
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to update reminders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.InvoiceFromDomain(inv))
 }
 
 func (h *InvoiceHandler) GeneratePDF(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
 		return
 	}
 
-	pdfBytes, err := h.service.GeneratePDF(r.Context(), userID, invoiceID)
+	pdfBytes, err := h.service.GeneratePDF(r.Context(), p, invoiceID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		respondFailure(w, err, "Failed to generate PDF")
 		return
 	}
 
@@ -122,3 +304,13 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
+
+// respondFailure answers an error no other case covers: forbidden when the
+// member's role doesn't allow the action, otherwise an internal error
+func respondFailure(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, organization.ErrForbidden) {
+		respondError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	respondError(w, http.StatusInternalServerError, message)
+}
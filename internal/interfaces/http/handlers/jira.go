@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/invoice-app-be/internal/domain/organization"
 	"github.com/invoice-app-be/internal/domain/timeentry"
 	"github.com/invoice-app-be/internal/infrastructure/integrations/jira"
 	"github.com/invoice-app-be/internal/interfaces/http/dto"
@@ -28,7 +29,7 @@ func NewJiraHandler(jiraSyncService *jira.SyncService, timeEntryRepo timeentry.R
 
 // PullWorklogs pulls worklogs from Jira for a date range
 func (h *JiraHandler) PullWorklogs(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 
 	var req dto.PullWorklogsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -61,9 +62,9 @@ func (h *JiraHandler) PullWorklogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Pull worklogs from Jira
-	count, err := h.jiraSyncService.PullWorklogsByDateRange(r.Context(), userID, startDate, endDate, req.IssueKeys)
+	count, err := h.jiraSyncService.PullWorklogsByDateRange(r.Context(), p, startDate, endDate, req.IssueKeys)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to pull worklogs from Jira: "+err.Error())
+		respondFailure(w, err, "Failed to pull worklogs from Jira: "+err.Error())
 		return
 	}
 
@@ -75,7 +76,7 @@ func (h *JiraHandler) PullWorklogs(w http.ResponseWriter, r *http.Request) {
 
 // PullWorklogsForIssue pulls worklogs for a specific Jira issue
 func (h *JiraHandler) PullWorklogsForIssue(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 
 	var req dto.PullIssueWorklogsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -90,8 +91,8 @@ func (h *JiraHandler) PullWorklogsForIssue(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Sync worklogs for the issue
-	if err := h.jiraSyncService.SyncWorklogsForIssue(r.Context(), userID, req.IssueKey); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to sync worklogs: "+err.Error())
+	if err := h.jiraSyncService.SyncWorklogsForIssue(r.Context(), p, req.IssueKey); err != nil {
+		respondFailure(w, err, "Failed to sync worklogs: "+err.Error())
 		return
 	}
 
@@ -102,7 +103,7 @@ func (h *JiraHandler) PullWorklogsForIssue(w http.ResponseWriter, r *http.Reques
 
 // PushWorklog pushes a time entry to Jira
 func (h *JiraHandler) PushWorklog(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 
 	var req dto.PushWorklogRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -129,14 +130,14 @@ func (h *JiraHandler) PushWorklog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if entry.UserID != userID {
+	if !p.Owns(entry.OrganizationID) || (entry.UserID != p.UserID && !p.Can(organization.ActionManageTeamTime)) {
 		respondError(w, http.StatusForbidden, "Unauthorized")
 		return
 	}
 
 	// Push to Jira
 	if err := h.jiraSyncService.PushTimeEntryToJira(r.Context(), entry, req.IssueKey); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to push worklog to Jira: "+err.Error())
+		respondFailure(w, err, "Failed to push worklog to Jira: "+err.Error())
 		return
 	}
 
@@ -0,0 +1,163 @@
+// internal/interfaces/http/handlers/latefee.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/latefee"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type LateFeeHandler struct {
+	service *latefee.Service
+}
+
+func NewLateFeeHandler(service *latefee.Service) *LateFeeHandler {
+	return &LateFeeHandler{service: service}
+}
+
+func (h *LateFeeHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	policies, err := h.service.ListPolicies(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch late fee policies")
+		return
+	}
+
+	response := make([]dto.LateFeePolicyResponse, len(policies))
+	for i, policy := range policies {
+		response[i] = dto.LateFeePolicyFromDomain(&policy)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *LateFeeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.LateFeePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policy, err := h.service.CreatePolicy(r.Context(), p, req.ToDomain())
+	if err != nil {
+		respondLateFeeError(w, err, "Failed to create late fee policy")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.LateFeePolicyFromDomain(policy))
+}
+
+func (h *LateFeeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	policyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid late fee policy ID")
+		return
+	}
+
+	policy, err := h.service.GetPolicy(r.Context(), p, policyID)
+	if err != nil {
+		respondLateFeeError(w, err, "Failed to fetch late fee policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.LateFeePolicyFromDomain(policy))
+}
+
+func (h *LateFeeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	policyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid late fee policy ID")
+		return
+	}
+
+	var req dto.LateFeePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(r.Context(), p, policyID, req.ToDomain())
+	if err != nil {
+		respondLateFeeError(w, err, "Failed to update late fee policy")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.LateFeePolicyFromDomain(policy))
+}
+
+func (h *LateFeeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	policyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid late fee policy ID")
+		return
+	}
+
+	if err := h.service.DeletePolicy(r.Context(), p, policyID); err != nil {
+		respondLateFeeError(w, err, "Failed to delete late fee policy")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// InvoiceCharges lists the late fees charged on an invoice
+func (h *LateFeeHandler) InvoiceCharges(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	charges, err := h.service.ListCharges(r.Context(), p, invoiceID)
+	if err != nil {
+		respondLateFeeError(w, err, "Failed to fetch late fees")
+		return
+	}
+
+	response := make([]dto.LateFeeChargeResponse, len(charges))
+	for i, charge := range charges {
+		response[i] = dto.LateFeeChargeFromDomain(&charge)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func respondLateFeeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, latefee.ErrPolicyNotFound), errors.Is(err, latefee.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Late fee policy not found")
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+	case errors.Is(err, latefee.ErrInvalidPolicy), errors.Is(err, latefee.ErrClientNotFound):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, latefee.ErrPolicyExists):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
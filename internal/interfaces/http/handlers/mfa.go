@@ -0,0 +1,140 @@
+// internal/interfaces/http/handlers/mfa.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/invoice-app-be/internal/domain/mfa"
+	"github.com/invoice-app-be/internal/domain/session"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+// MFAHandler sets up and manages the caller's two-factor authentication
+type MFAHandler struct {
+	service        *mfa.Service
+	sessionService *session.Service
+}
+
+func NewMFAHandler(service *mfa.Service, sessionService *session.Service) *MFAHandler {
+	return &MFAHandler{service: service, sessionService: sessionService}
+}
+
+func (h *MFAHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	status, err := h.service.Status(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch two-factor status")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.MFAStatusFromDomain(status))
+}
+
+// Enroll generates a secret for the user's authenticator app
+func (h *MFAHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	enrollment, err := h.service.Enroll(r.Context(), userID)
+	if err != nil {
+		respondMFAError(w, err, "Failed to start enrollment")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.MFAEnrollmentResponse{Secret: enrollment.Secret, URI: enrollment.URI})
+}
+
+// Activate turns two-factor authentication on with a code from the enrolled
+// app and returns the recovery codes. The session it's done from counts as
+// having given a second factor.
+func (h *MFAHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	var req dto.MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codes, err := h.service.Activate(r.Context(), userID, req.Code)
+	if err != nil {
+		respondMFAError(w, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	if err := h.sessionService.MarkMFAVerified(r.Context(), middleware.GetSessionID(r.Context())); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update session")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable turns two-factor authentication off, given a current code
+func (h *MFAHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	var req dto.MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.Disable(r.Context(), userID, req.Code); err != nil {
+		respondMFAError(w, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, given a
+// current code
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r.Context())
+
+	var req dto.MFACodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+	if err != nil {
+		respondMFAError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func respondMFAError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, mfa.ErrInvalidCode):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, mfa.ErrTooManyAttempts):
+		respondError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, mfa.ErrAlreadyEnabled), errors.Is(err, mfa.ErrNotEnabled), errors.Is(err, mfa.ErrNotEnrolled):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,287 @@
+// internal/interfaces/http/handlers/organization.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/session"
+	"github.com/invoice-app-be/internal/domain/user"
+	"github.com/invoice-app-be/internal/infrastructure/auth"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+// OrganizationHandler manages the caller's organizations, the active one's
+// settings and its members
+type OrganizationHandler struct {
+	service        *organization.Service
+	userService    *user.Service
+	sessionService *session.Service
+	jwtManager     *auth.JWTManager
+}
+
+func NewOrganizationHandler(service *organization.Service, userService *user.Service, sessionService *session.Service,
+	jwtManager *auth.JWTManager) *OrganizationHandler {
+	return &OrganizationHandler{
+		service:        service,
+		userService:    userService,
+		sessionService: sessionService,
+		jwtManager:     jwtManager,
+	}
+}
+
+// List returns every organization the caller belongs to, with their role in each
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	memberships, err := h.service.Memberships(r.Context(), p.UserID)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch organizations")
+		return
+	}
+
+	response := make([]dto.OrganizationResponse, len(memberships))
+	for i, m := range memberships {
+		response[i] = dto.MembershipFromDomain(&m)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Create starts a new organization owned by the caller. The token stays
+// scoped to the current one until the caller switches.
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	org, err := h.service.Create(r.Context(), p.UserID, organization.CreateRequest{
+		Name:         req.Name,
+		BaseCurrency: req.BaseCurrency,
+	})
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to create organization")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.OrganizationFromDomain(org))
+}
+
+// Switch issues a token scoped to another organization the caller belongs
+// to. The session follows, so refreshed tokens stay in that organization.
+func (h *OrganizationHandler) Switch(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	organizationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid organization ID")
+		return
+	}
+
+	target, err := h.service.Principal(r.Context(), organizationID, p.UserID)
+	if errors.Is(err, organization.ErrNotMember) {
+		respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to switch organization")
+		return
+	}
+
+	org, err := h.service.GetOrganization(r.Context(), target)
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to switch organization")
+		return
+	}
+
+	u, err := h.userService.GetUser(r.Context(), p.UserID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	sessionID := middleware.GetSessionID(r.Context())
+	if err := h.sessionService.SwitchOrganization(r.Context(), sessionID, org.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to switch organization")
+		return
+	}
+
+	token, err := h.jwtManager.Generate(u.ID, org.ID, sessionID, u.Email)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	response := dto.SwitchOrganizationResponse{
+		Token:        token,
+		Organization: dto.OrganizationFromDomain(org),
+	}
+	response.Organization.Role = string(target.Role)
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Get returns the active organization
+func (h *OrganizationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	org, err := h.service.GetOrganization(r.Context(), p)
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to fetch organization")
+		return
+	}
+
+	response := dto.OrganizationFromDomain(org)
+	response.Role = string(p.Role)
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *OrganizationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Requiring two-factor authentication from a session without it would
+	// lock the caller out of the organization straight away
+	if req.RequireMFA && !middleware.IsMFAVerified(r.Context()) {
+		respondError(w, http.StatusConflict, "Log in with two-factor authentication before requiring it")
+		return
+	}
+
+	org, err := h.service.UpdateOrganization(r.Context(), p, organization.UpdateRequest{
+		Name:         req.Name,
+		BaseCurrency: req.BaseCurrency,
+		RequireMFA:   req.RequireMFA,
+	})
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to update organization")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.OrganizationFromDomain(org))
+}
+
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	members, err := h.service.ListMembers(r.Context(), p)
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to fetch members")
+		return
+	}
+
+	response := make([]dto.MemberResponse, len(members))
+	for i, m := range members {
+		response[i] = dto.MemberFromDomain(&m)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// AddMember adds an existing user to the organization by their email
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := h.service.AddMember(r.Context(), p, req.Email, organization.Role(req.Role))
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to add member")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.MemberFromDomain(member))
+}
+
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := h.service.UpdateMember(r.Context(), p, userID, organization.Role(req.Role))
+	if err != nil {
+		respondOrganizationError(w, err, "Failed to update member")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.MemberFromDomain(member))
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), p, userID); err != nil {
+		respondOrganizationError(w, err, "Failed to remove member")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func respondOrganizationError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, organization.ErrOrganizationNotFound):
+		respondError(w, http.StatusNotFound, "Organization not found")
+	case errors.Is(err, organization.ErrMemberNotFound), errors.Is(err, organization.ErrUserNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, organization.ErrAlreadyMember), errors.Is(err, organization.ErrLastOwner):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, organization.ErrInvalidRole), errors.Is(err, organization.ErrInvalidCurrency),
+		errors.Is(err, organization.ErrInvalidOrganization):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
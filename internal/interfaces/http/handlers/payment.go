@@ -0,0 +1,109 @@
+// internal/interfaces/http/handlers/payment.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/payment"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type PaymentHandler struct {
+	service *payment.Service
+}
+
+func NewPaymentHandler(service *payment.Service) *PaymentHandler {
+	return &PaymentHandler{service: service}
+}
+
+// Record records a manual payment or, with a negative amount, a refund
+func (h *PaymentHandler) Record(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	var req dto.RecordPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var paidAt time.Time
+	if req.PaidAt != "" {
+		paidAt, err = time.Parse("2006-01-02", req.PaidAt)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid paid_at format. Expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	recorded, inv, err := h.service.RecordPayment(r.Context(), p, invoiceID, payment.RecordPaymentRequest{
+		Amount:    req.Amount,
+		Method:    payment.Method(req.Method),
+		PaidAt:    paidAt,
+		Reference: req.Reference,
+		Notes:     req.Notes,
+	})
+	if err != nil {
+		respondPaymentError(w, err, "Failed to record payment")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.RecordPaymentResponse{
+		Payment: dto.PaymentFromDomain(recorded),
+		Invoice: dto.InvoiceFromDomain(inv),
+	})
+}
+
+func (h *PaymentHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	payments, err := h.service.ListPayments(r.Context(), p, invoiceID)
+	if err != nil {
+		respondPaymentError(w, err, "Failed to fetch payments")
+		return
+	}
+
+	response := make([]dto.PaymentResponse, len(payments))
+	for i, pay := range payments {
+		response[i] = dto.PaymentFromDomain(&pay)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func respondPaymentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+	case errors.Is(err, payment.ErrInvalidAmount), errors.Is(err, payment.ErrInvalidMethod):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, payment.ErrInvoiceNotPayable), errors.Is(err, payment.ErrRefundExceedsPaid):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, payment.ErrRateNotFound):
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
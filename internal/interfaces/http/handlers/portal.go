@@ -0,0 +1,204 @@
+// internal/interfaces/http/handlers/portal.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/portal"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+// PortalHandler serves shared invoices to clients and lets issuers manage
+// their share links
+type PortalHandler struct {
+	service *portal.Service
+	baseURL string
+}
+
+func NewPortalHandler(service *portal.Service, baseURL string) *PortalHandler {
+	return &PortalHandler{service: service, baseURL: baseURL}
+}
+
+func (h *PortalHandler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	var req dto.CreateShareRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lifetime := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	share, token, err := h.service.CreateShare(r.Context(), p, invoiceID, lifetime)
+	if err != nil {
+		respondPortalError(w, err, "Failed to create share link")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ShareFromDomain(share, token, h.baseURL))
+}
+
+func (h *PortalHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	shares, err := h.service.ListShares(r.Context(), p, invoiceID)
+	if err != nil {
+		respondPortalError(w, err, "Failed to fetch share links")
+		return
+	}
+
+	response := make([]dto.ShareResponse, len(shares))
+	for i, share := range shares {
+		token, err := h.service.Token(&share)
+		if err != nil {
+			respondFailure(w, err, "Failed to fetch share links")
+			return
+		}
+		response[i] = dto.ShareFromDomain(&share, token, h.baseURL)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *PortalHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+	shareID, err := uuid.Parse(chi.URLParam(r, "shareID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid share ID")
+		return
+	}
+
+	if _, err := h.service.RevokeShare(r.Context(), p, invoiceID, shareID); err != nil {
+		respondPortalError(w, err, "Failed to revoke share link")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// Views lists the client's visits to an invoice through its share links
+func (h *PortalHandler) Views(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	invoiceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	views, err := h.service.ListViews(r.Context(), p, invoiceID)
+	if err != nil {
+		respondPortalError(w, err, "Failed to fetch invoice views")
+		return
+	}
+
+	response := make([]dto.InvoiceViewResponse, len(views))
+	for i, view := range views {
+		response[i] = dto.InvoiceViewFromDomain(&view)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// Invoice serves a shared invoice to the client
+func (h *PortalHandler) Invoice(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.service.Open(r.Context(), chi.URLParam(r, "token"), portal.ResourceInvoice, visitor(r))
+	if err != nil {
+		respondVisitorError(w, err, "Failed to fetch invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.PortalInvoiceFromDomain(doc))
+}
+
+func (h *PortalHandler) PDF(w http.ResponseWriter, r *http.Request) {
+	doc, pdfBytes, err := h.service.PDF(r.Context(), chi.URLParam(r, "token"), visitor(r))
+	if err != nil {
+		respondVisitorError(w, err, "Failed to generate PDF")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("inline; filename=invoice-%s.pdf", doc.Invoice.InvoiceNumber))
+	w.Write(pdfBytes)
+}
+
+func (h *PortalHandler) Payments(w http.ResponseWriter, r *http.Request) {
+	_, payments, err := h.service.Payments(r.Context(), chi.URLParam(r, "token"), visitor(r))
+	if err != nil {
+		respondVisitorError(w, err, "Failed to fetch payments")
+		return
+	}
+
+	response := make([]dto.PortalPaymentResponse, len(payments))
+	for i, p := range payments {
+		response[i] = dto.PortalPaymentFromDomain(&p)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func visitor(r *http.Request) portal.Visitor {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return portal.Visitor{IPAddress: ip, UserAgent: r.UserAgent()}
+}
+
+// respondVisitorError answers a client opening a link, who is told the link
+// is gone rather than why the invoice can no longer be shared
+func respondVisitorError(w http.ResponseWriter, err error, fallback string) {
+	if errors.Is(err, portal.ErrNotShareable) {
+		respondError(w, http.StatusGone, "This invoice link is no longer available")
+		return
+	}
+	respondPortalError(w, err, fallback)
+}
+
+func respondPortalError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, portal.ErrShareNotFound), errors.Is(err, portal.ErrInvalidToken):
+		respondError(w, http.StatusNotFound, "Invoice link not found")
+	case errors.Is(err, portal.ErrShareExpired), errors.Is(err, portal.ErrShareRevoked):
+		respondError(w, http.StatusGone, "This invoice link is no longer available")
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Invoice not found")
+	case errors.Is(err, portal.ErrNotShareable), errors.Is(err, portal.ErrInvalidExpiry):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
@@ -0,0 +1,263 @@
+// internal/interfaces/http/handlers/project.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/project"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type ProjectHandler struct {
+	service *project.Service
+}
+
+func NewProjectHandler(service *project.Service) *ProjectHandler {
+	return &ProjectHandler{service: service}
+}
+
+func (h *ProjectHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var filters project.ListFilters
+	if clientID := r.URL.Query().Get("client_id"); clientID != "" {
+		id, err := uuid.Parse(clientID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid client ID")
+			return
+		}
+		filters.ClientID = &id
+	}
+	filters.IncludeArchived = r.URL.Query().Get("include_archived") == "true"
+
+	projects, err := h.service.ListProjects(r.Context(), p, filters)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch projects")
+		return
+	}
+
+	response := make([]dto.ProjectResponse, len(projects))
+	for i, proj := range projects {
+		response[i] = dto.ProjectFromDomain(&proj, nil)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	proj, err := h.service.CreateProject(r.Context(), p, req.ToDomain())
+	if err != nil {
+		respondProjectError(w, err, "Failed to create project")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ProjectFromDomain(proj, nil))
+}
+
+// Get returns the project with the time tracked against it
+func (h *ProjectHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	proj, err := h.service.GetProject(r.Context(), p, projectID)
+	if err != nil {
+		respondProjectError(w, err, "Failed to fetch project")
+		return
+	}
+
+	usage, err := h.service.Usage(r.Context(), p, projectID)
+	if err != nil {
+		respondProjectError(w, err, "Failed to fetch project")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ProjectFromDomain(proj, usage))
+}
+
+func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req dto.ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	proj, err := h.service.UpdateProject(r.Context(), p, projectID, req.ToDomain())
+	if err != nil {
+		respondProjectError(w, err, "Failed to update project")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ProjectFromDomain(proj, nil))
+}
+
+func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	if err := h.service.DeleteProject(r.Context(), p, projectID); err != nil {
+		respondProjectError(w, err, "Failed to delete project")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func (h *ProjectHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	tasks, err := h.service.ListTasks(r.Context(), p, projectID)
+	if err != nil {
+		respondProjectError(w, err, "Failed to fetch tasks")
+		return
+	}
+
+	response := make([]dto.TaskResponse, len(tasks))
+	for i, t := range tasks {
+		response[i] = dto.TaskFromDomain(&t)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *ProjectHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req dto.TaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	task, err := h.service.CreateTask(r.Context(), p, projectID, req.ToDomain())
+	if err != nil {
+		respondProjectError(w, err, "Failed to create task")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.TaskFromDomain(task))
+}
+
+func (h *ProjectHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req dto.TaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	task, err := h.service.UpdateTask(r.Context(), p, projectID, taskID, req.ToDomain())
+	if err != nil {
+		respondProjectError(w, err, "Failed to update task")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TaskFromDomain(task))
+}
+
+func (h *ProjectHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	projectID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	if err := h.service.DeleteTask(r.Context(), p, projectID, taskID); err != nil {
+		respondProjectError(w, err, "Failed to delete task")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func respondProjectError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, project.ErrProjectNotFound), errors.Is(err, project.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Project not found")
+	case errors.Is(err, project.ErrTaskNotFound):
+		respondError(w, http.StatusNotFound, "Task not found")
+	case errors.Is(err, project.ErrClientNotFound):
+		respondError(w, http.StatusBadRequest, "Client not found")
+	case errors.Is(err, project.ErrJiraKeyTaken):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, project.ErrInvalidProject):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
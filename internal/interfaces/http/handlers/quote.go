@@ -0,0 +1,332 @@
+// internal/interfaces/http/handlers/quote.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/quote"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+// QuoteHandler manages quotes and lets clients accept or decline them
+// through their portal link
+type QuoteHandler struct {
+	service *quote.Service
+}
+
+func NewQuoteHandler(service *quote.Service) *QuoteHandler {
+	return &QuoteHandler{service: service}
+}
+
+func (h *QuoteHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var filters quote.ListFilters
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := quote.Status(status)
+		filters.Status = &s
+	}
+	if clientID := r.URL.Query().Get("client_id"); clientID != "" {
+		id, err := uuid.Parse(clientID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid client ID")
+			return
+		}
+		filters.ClientID = &id
+	}
+
+	quotes, err := h.service.ListQuotes(r.Context(), p, filters)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch quotes")
+		return
+	}
+
+	response := make([]dto.QuoteResponse, len(quotes))
+	for i, q := range quotes {
+		if response[i], err = h.quoteResponse(&q); err != nil {
+			respondFailure(w, err, "Failed to fetch quotes")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *QuoteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q, err := h.service.CreateQuote(r.Context(), p, req.ToDomain())
+	if err != nil {
+		respondQuoteError(w, err, "Failed to create quote")
+		return
+	}
+
+	h.respondQuote(w, http.StatusCreated, q)
+}
+
+func (h *QuoteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	q, err := h.service.GetQuote(r.Context(), p, quoteID)
+	if err != nil {
+		respondQuoteError(w, err, "Failed to fetch quote")
+		return
+	}
+
+	h.respondQuote(w, http.StatusOK, q)
+}
+
+func (h *QuoteHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	var req dto.QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q, err := h.service.UpdateQuote(r.Context(), p, quoteID, req.ToDomain())
+	if err != nil {
+		respondQuoteError(w, err, "Failed to update quote")
+		return
+	}
+
+	h.respondQuote(w, http.StatusOK, q)
+}
+
+func (h *QuoteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	if err := h.service.DeleteQuote(r.Context(), p, quoteID); err != nil {
+		respondQuoteError(w, err, "Failed to delete quote")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+// Send marks the quote as sent and emails the client its portal link
+func (h *QuoteHandler) Send(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	// The body is optional; an empty one sends with the defaults
+	var req dto.SendInvoiceRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q, err := h.service.SendQuote(r.Context(), p, quoteID, invoice.SendOptions{
+		CC:      req.CC,
+		BCC:     req.BCC,
+		ReplyTo: req.ReplyTo,
+		Message: req.Message,
+	})
+	if err != nil {
+		respondQuoteError(w, err, "Failed to send quote")
+		return
+	}
+
+	h.respondQuote(w, http.StatusOK, q)
+}
+
+// Accept records the client's acceptance on their behalf, for quotes
+// accepted outside the portal, and converts the quote into a draft invoice
+func (h *QuoteHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	q, inv, err := h.service.AcceptQuote(r.Context(), p, quoteID)
+	if err != nil {
+		respondQuoteError(w, err, "Failed to accept quote")
+		return
+	}
+
+	// Without an invoice, conversion failed and can be retried
+	h.respondConversion(w, q, inv)
+}
+
+func (h *QuoteHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	q, err := h.service.DeclineQuote(r.Context(), p, quoteID)
+	if err != nil {
+		respondQuoteError(w, err, "Failed to decline quote")
+		return
+	}
+
+	h.respondQuote(w, http.StatusOK, q)
+}
+
+// Convert creates the draft invoice for an accepted quote
+func (h *QuoteHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	quoteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid quote ID")
+		return
+	}
+
+	q, inv, err := h.service.ConvertQuote(r.Context(), p, quoteID)
+	if err != nil {
+		respondQuoteError(w, err, "Failed to convert quote")
+		return
+	}
+
+	h.respondConversion(w, q, inv)
+}
+
+// PortalGet serves a quote to the client
+func (h *QuoteHandler) PortalGet(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.service.Open(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondQuoteError(w, err, "Failed to fetch quote")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.PortalQuoteFromDomain(doc))
+}
+
+func (h *QuoteHandler) PortalAccept(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.service.AcceptByToken(r.Context(), chi.URLParam(r, "token")); err != nil {
+		respondQuoteError(w, err, "Failed to accept quote")
+		return
+	}
+
+	h.PortalGet(w, r)
+}
+
+func (h *QuoteHandler) PortalDecline(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.service.DeclineByToken(r.Context(), chi.URLParam(r, "token")); err != nil {
+		respondQuoteError(w, err, "Failed to decline quote")
+		return
+	}
+
+	h.PortalGet(w, r)
+}
+
+func (h *QuoteHandler) quoteResponse(q *quote.Quote) (dto.QuoteResponse, error) {
+	var link string
+	if q.Status != quote.StatusDraft {
+		var err error
+		if link, err = h.service.Link(q); err != nil {
+			return dto.QuoteResponse{}, err
+		}
+	}
+	return dto.QuoteFromDomain(q, link), nil
+}
+
+func (h *QuoteHandler) respondQuote(w http.ResponseWriter, status int, q *quote.Quote) {
+	resp, err := h.quoteResponse(q)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch quote")
+		return
+	}
+
+	respondJSON(w, status, resp)
+}
+
+func (h *QuoteHandler) respondConversion(w http.ResponseWriter, q *quote.Quote, inv *invoice.Invoice) {
+	resp, err := h.quoteResponse(q)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch quote")
+		return
+	}
+
+	conversion := dto.QuoteConversionResponse{Quote: resp}
+	if inv != nil {
+		invResp := dto.InvoiceFromDomain(inv)
+		conversion.Invoice = &invResp
+	}
+
+	respondJSON(w, http.StatusOK, conversion)
+}
+
+func respondQuoteError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, quote.ErrQuoteNotFound), errors.Is(err, quote.ErrUnauthorized),
+		errors.Is(err, quote.ErrInvalidToken):
+		respondError(w, http.StatusNotFound, "Quote not found")
+	case errors.Is(err, quote.ErrQuoteExpired):
+		respondError(w, http.StatusGone, "This quote has expired")
+	case errors.Is(err, quote.ErrInvalidStatusTransition):
+		respondError(w, http.StatusConflict, "Quote cannot be changed in its current status")
+	case errors.Is(err, quote.ErrAlreadyConverted):
+		respondError(w, http.StatusConflict, "Quote has already been converted into an invoice")
+	case errors.Is(err, quote.ErrInvalidQuote):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, invoice.ErrTaxCodeNotFound):
+		respondError(w, http.StatusBadRequest, "Unknown tax code")
+	case errors.Is(err, invoice.ErrInvalidDiscount):
+		respondError(w, http.StatusBadRequest, "Invalid discount")
+	case errors.Is(err, invoice.ErrInvalidCurrency):
+		respondError(w, http.StatusBadRequest, "Invalid currency")
+	case errors.Is(err, invoice.ErrExchangeRateNotFound):
+		respondError(w, http.StatusUnprocessableEntity, "No exchange rate to the account's base currency")
+	case errors.Is(err, invoice.ErrClientHasNoEmail):
+		respondError(w, http.StatusUnprocessableEntity, "Client has no email address")
+	case errors.Is(err, invoice.ErrDeliveryFailed):
+		respondError(w, http.StatusBadGateway, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
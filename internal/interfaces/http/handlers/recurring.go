@@ -0,0 +1,217 @@
+// internal/interfaces/http/handlers/recurring.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/recurring"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type RecurringHandler struct {
+	service *recurring.Service
+}
+
+func NewRecurringHandler(service *recurring.Service) *RecurringHandler {
+	return &RecurringHandler{service: service}
+}
+
+func (h *RecurringHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	schedules, err := h.service.ListSchedules(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch recurring invoices")
+		return
+	}
+
+	response := make([]dto.RecurringScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		response[i] = dto.RecurringScheduleFromDomain(&schedule)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *RecurringHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	req, ok := decodeScheduleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(r.Context(), p, req)
+	if err != nil {
+		respondRecurringError(w, err, "Failed to create recurring invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.RecurringScheduleFromDomain(schedule))
+}
+
+func (h *RecurringHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recurring invoice ID")
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(r.Context(), p, id)
+	if err != nil {
+		respondRecurringError(w, err, "Failed to fetch recurring invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RecurringScheduleFromDomain(schedule))
+}
+
+func (h *RecurringHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recurring invoice ID")
+		return
+	}
+
+	req, ok := decodeScheduleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(r.Context(), p, id, req)
+	if err != nil {
+		respondRecurringError(w, err, "Failed to update recurring invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RecurringScheduleFromDomain(schedule))
+}
+
+func (h *RecurringHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recurring invoice ID")
+		return
+	}
+
+	if err := h.service.DeleteSchedule(r.Context(), p, id); err != nil {
+		respondRecurringError(w, err, "Failed to delete recurring invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func (h *RecurringHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+func (h *RecurringHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+// Invoices lists the invoices a schedule has generated
+func (h *RecurringHandler) Invoices(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recurring invoice ID")
+		return
+	}
+
+	invoices, err := h.service.ListInvoices(r.Context(), p, id)
+	if err != nil {
+		respondRecurringError(w, err, "Failed to fetch invoices")
+		return
+	}
+
+	response := make([]dto.InvoiceResponse, len(invoices))
+	for i, inv := range invoices {
+		response[i] = dto.InvoiceFromDomain(&inv)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *RecurringHandler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	p := middleware.GetPrincipal(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recurring invoice ID")
+		return
+	}
+
+	schedule, err := h.service.SetActive(r.Context(), p, id, active)
+	if err != nil {
+		respondRecurringError(w, err, "Failed to update recurring invoice")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RecurringScheduleFromDomain(schedule))
+}
+
+func decodeScheduleRequest(w http.ResponseWriter, r *http.Request) (recurring.ScheduleRequest, bool) {
+	var req dto.RecurringScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return recurring.ScheduleRequest{}, false
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return recurring.ScheduleRequest{}, false
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid start_date format. Expected YYYY-MM-DD")
+		return recurring.ScheduleRequest{}, false
+	}
+
+	var endDate *time.Time
+	if req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid end_date format. Expected YYYY-MM-DD")
+			return recurring.ScheduleRequest{}, false
+		}
+		endDate = &parsed
+	}
+
+	return recurring.ScheduleRequest{
+		ClientID:         req.ClientID,
+		Name:             req.Name,
+		Rule:             req.Rule,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Mode:             recurring.Mode(req.Mode),
+		PaymentTermsDays: req.PaymentTermsDays,
+		Template:         req.Template(),
+	}, true
+}
+
+func respondRecurringError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, recurring.ErrScheduleNotFound), errors.Is(err, recurring.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Recurring invoice not found")
+	case errors.Is(err, recurring.ErrInvalidRule), errors.Is(err, recurring.ErrInvalidSchedule):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
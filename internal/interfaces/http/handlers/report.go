@@ -0,0 +1,138 @@
+// internal/interfaces/http/handlers/report.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/report"
+	"github.com/invoice-app-be/internal/infrastructure/timesheet"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type ReportHandler struct {
+	service *report.Service
+}
+
+func NewReportHandler(service *report.Service) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// Revenue reports invoiced and collected revenue in the user's base currency
+func (h *ReportHandler) Revenue(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	from, to, ok := parseDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	rep, err := h.service.Revenue(r.Context(), p, from, to)
+	if err != nil {
+		respondFailure(w, err, "Failed to build revenue report")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.RevenueReportFromDomain(rep))
+}
+
+// Time reports hours, billable amounts and utilization by member, project or
+// client, as JSON or with format=csv as a download
+func (h *ReportHandler) Time(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	query := r.URL.Query()
+
+	from, to, ok := parseDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	filters := report.TimeFilters{
+		From:     from,
+		To:       to,
+		GroupBy:  report.Grouping(query.Get("group_by")),
+		Interval: report.Interval(query.Get("interval")),
+	}
+	if filters.GroupBy == "" {
+		filters.GroupBy = report.GroupByMember
+	}
+
+	var msg string
+	if filters.UserID, msg = uuidParam(query, "user_id"); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if filters.ProjectID, msg = uuidParam(query, "project_id"); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if filters.ClientID, msg = uuidParam(query, "client_id"); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		respondError(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+
+	rep, err := h.service.TeamTime(r.Context(), p, filters)
+	if errors.Is(err, report.ErrInvalidGrouping) || errors.Is(err, report.ErrInvalidInterval) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondFailure(w, err, "Failed to build time report")
+		return
+	}
+
+	if format != "csv" {
+		respondJSON(w, http.StatusOK, dto.TimeReportFromDomain(rep))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=time-by-%s-%s-%s.csv",
+		rep.GroupBy, from.Format("2006-01-02"), to.Format("2006-01-02")))
+	if err := timesheet.WriteReportCSV(w, rep); err != nil {
+		log.Print(err)
+	}
+}
+
+// parseDateRange reads the from and to query params (YYYY-MM-DD), defaulting
+// to the current calendar year
+func parseDateRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	now := time.Now()
+	from := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), 12, 31, 0, 0, 0, 0, time.UTC)
+
+	query := r.URL.Query()
+	if v := query.Get("from"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid from format. Expected YYYY-MM-DD")
+			return from, to, false
+		}
+		from = parsed
+	}
+	if v := query.Get("to"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid to format. Expected YYYY-MM-DD")
+			return from, to, false
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		respondError(w, http.StatusBadRequest, "to must be after from")
+		return from, to, false
+	}
+
+	return from, to, true
+}
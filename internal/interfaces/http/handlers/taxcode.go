@@ -0,0 +1,124 @@
+// internal/interfaces/http/handlers/taxcode.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type TaxCodeHandler struct {
+	service *invoice.Service
+}
+
+func NewTaxCodeHandler(service *invoice.Service) *TaxCodeHandler {
+	return &TaxCodeHandler{service: service}
+}
+
+func (h *TaxCodeHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	codes, err := h.service.ListTaxCodes(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch tax codes")
+		return
+	}
+
+	response := make([]dto.TaxCodeResponse, len(codes))
+	for i, code := range codes {
+		response[i] = dto.TaxCodeFromDomain(&code)
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *TaxCodeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.TaxCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	code, err := h.service.CreateTaxCode(r.Context(), p, invoice.TaxCodeRequest{
+		Name:       req.Name,
+		Rate:       req.Rate,
+		IsCompound: req.IsCompound,
+	})
+	if err != nil {
+		respondFailure(w, err, "Failed to create tax code")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.TaxCodeFromDomain(code))
+}
+
+func (h *TaxCodeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	codeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid tax code ID")
+		return
+	}
+
+	var req dto.TaxCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	code, err := h.service.UpdateTaxCode(r.Context(), p, codeID, invoice.TaxCodeRequest{
+		Name:       req.Name,
+		Rate:       req.Rate,
+		IsCompound: req.IsCompound,
+	})
+	if err != nil {
+		respondTaxCodeError(w, err, "Failed to update tax code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TaxCodeFromDomain(code))
+}
+
+func (h *TaxCodeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	codeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid tax code ID")
+		return
+	}
+
+	if err := h.service.DeleteTaxCode(r.Context(), p, codeID); err != nil {
+		respondTaxCodeError(w, err, "Failed to delete tax code")
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, nil)
+}
+
+func respondTaxCodeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, invoice.ErrTaxCodeNotFound), errors.Is(err, invoice.ErrUnauthorized):
+		respondError(w, http.StatusNotFound, "Tax code not found")
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
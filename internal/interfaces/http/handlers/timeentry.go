@@ -3,14 +3,24 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/invoice-app-be/internal/domain/hourlyrate"
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/project"
 	"github.com/invoice-app-be/internal/domain/timeentry"
+	"github.com/invoice-app-be/internal/infrastructure/timesheet"
 	"github.com/invoice-app-be/internal/interfaces/http/dto"
 	"github.com/invoice-app-be/internal/interfaces/http/middleware"
 )
@@ -24,25 +34,125 @@ func NewTimeEntryHandler(service *timeentry.Service) *TimeEntryHandler {
 }
 
 func (h *TimeEntryHandler) List(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
-	query := r.URL.Query()
-	entries, err := h.service.ListTimeEntries(r.Context(), userID, query.Get("start_date"), query.Get("end_date"))
+	p := middleware.GetPrincipal(r.Context())
+
+	filters, msg := parseTimeEntryFilters(r)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	page, err := h.service.ListTimeEntries(r.Context(), p, filters)
+	if errors.Is(err, timeentry.ErrInvalidSort) {
+		respondError(w, http.StatusBadRequest, "sort must be date, hours or created_at")
+		return
+	}
 	if err != nil {
 		log.Print(err)
-		respondError(w, http.StatusInternalServerError, "Failed to fetch time entries")
+		respondFailure(w, err, "Failed to fetch time entries")
 		return
 	}
 
-	response := make([]dto.TimeEntryResponse, len(entries))
-	for i, entry := range entries {
-		response[i] = dto.TimeEntryFromDomain(&entry)
+	respondJSON(w, http.StatusOK, dto.TimeEntryPageFromDomain(page))
+}
+
+// parseTimeEntryFilters reads the list query params, returning a message
+// for the first invalid one
+func parseTimeEntryFilters(r *http.Request) (timeentry.ListFilters, string) {
+	query := r.URL.Query()
+	filters := timeentry.ListFilters{
+		Sort:      timeentry.SortField(query.Get("sort")),
+		Ascending: query.Get("order") == "asc",
+		Search:    strings.TrimSpace(query.Get("q")),
+	}
+
+	var msg string
+	if filters.DateFrom, msg = dateParam(query, "start_date"); msg != "" {
+		return filters, msg
+	}
+	if filters.DateTo, msg = dateParam(query, "end_date"); msg != "" {
+		return filters, msg
+	}
+	if filters.IsBillable, msg = boolParam(query, "billable"); msg != "" {
+		return filters, msg
+	}
+	if filters.IsInvoiced, msg = boolParam(query, "invoiced"); msg != "" {
+		return filters, msg
+	}
+	if filters.JiraSynced, msg = boolParam(query, "jira_synced"); msg != "" {
+		return filters, msg
+	}
+	if filters.ProjectID, msg = uuidParam(query, "project_id"); msg != "" {
+		return filters, msg
+	}
+	if filters.ClientID, msg = uuidParam(query, "client_id"); msg != "" {
+		return filters, msg
+	}
+	if filters.UserID, msg = uuidParam(query, "user_id"); msg != "" {
+		return filters, msg
+	}
+
+	if v := query.Get("jira_issue_key"); v != "" {
+		filters.JiraIssueKey = &v
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return filters, "limit must be a positive number"
+		}
+		filters.Limit = limit
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	if v := query.Get("cursor"); v != "" {
+		cursor, err := timeentry.DecodeCursor(v)
+		if err != nil {
+			return filters, "Invalid cursor"
+		}
+		filters.After = cursor
+	}
+
+	return filters, ""
+}
+
+func dateParam(query url.Values, param string) (*time.Time, string) {
+	v := query.Get(param)
+	if v == "" {
+		return nil, ""
+	}
+	date, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, "Invalid " + param + " format. Expected YYYY-MM-DD"
+	}
+	return &date, ""
+}
+
+func boolParam(query url.Values, param string) (*bool, string) {
+	v := query.Get(param)
+	if v == "" {
+		return nil, ""
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, param + " must be true or false"
+	}
+	return &b, ""
+}
+
+func uuidParam(query url.Values, param string) (*uuid.UUID, string) {
+	v := query.Get(param)
+	if v == "" {
+		return nil, ""
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return nil, "Invalid " + param
+	}
+	return &id, ""
 }
 
 func (h *TimeEntryHandler) Create(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 
 	var req dto.CreateTimeEntryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -65,15 +175,18 @@ func (h *TimeEntryHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Map to domain request
 	domainReq := timeentry.CreateTimeEntryRequest{
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
 		Description: req.Description,
 		Hours:       req.Hours,
+		HourlyRate:  req.HourlyRate,
 		Date:        date,
 		IsBillable:  req.IsBillable,
 	}
 
-	entry, err := h.service.CreateTimeEntry(r.Context(), userID, domainReq)
+	entry, err := h.service.CreateTimeEntry(r.Context(), p, domainReq)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create time entry")
+		respondTimeEntryError(w, err, "Failed to create time entry")
 		return
 	}
 
@@ -81,7 +194,7 @@ func (h *TimeEntryHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TimeEntryHandler) Update(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid time entry ID")
@@ -107,15 +220,18 @@ func (h *TimeEntryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Println(date)
-	entry, err := h.service.UpdateTimeEntry(r.Context(), userID, entryID, timeentry.UpdateTimeEntryRequest{
+	entry, err := h.service.UpdateTimeEntry(r.Context(), p, entryID, timeentry.UpdateTimeEntryRequest{
+		ProjectID:    req.ProjectID,
+		TaskID:       req.TaskID,
 		Description:  req.Description,
 		Hours:        req.Hours,
+		HourlyRate:   req.HourlyRate,
 		Date:         date,
 		IsBillable:   req.IsBillable,
 		JiraIssueKey: req.JiraIssueKey,
 	})
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update time entry")
+		respondTimeEntryError(w, err, "Failed to update time entry")
 		return
 	}
 
@@ -123,14 +239,14 @@ func (h *TimeEntryHandler) Update(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TimeEntryHandler) Get(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid time entry ID")
 		return
 	}
 
-	entry, err := h.service.GetTimeEntry(r.Context(), userID, entryID)
+	entry, err := h.service.GetTimeEntry(r.Context(), p, entryID)
 	if err != nil {
 		respondError(w, http.StatusNotFound, "Time entry not found")
 		return
@@ -140,23 +256,246 @@ func (h *TimeEntryHandler) Get(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TimeEntryHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid time entry ID")
 		return
 	}
 
-	if err := h.service.DeleteTimeEntry(r.Context(), userID, entryID); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete time entry")
+	if err := h.service.DeleteTimeEntry(r.Context(), p, entryID); err != nil {
+		respondTimeEntryError(w, err, "Failed to delete time entry")
 		return
 	}
 
 	respondJSON(w, http.StatusNoContent, nil)
 }
 
+// BulkBillable marks the selected entries billable or non-billable
+func (h *TimeEntryHandler) BulkBillable(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkBillableRequest
+	h.bulk(w, r, &req, &req.TimeEntrySelection, func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error) {
+		return h.service.BulkSetBillable(r.Context(), p, sel, req.IsBillable)
+	})
+}
+
+// BulkJiraIssue sets or clears the selected entries' Jira issue key
+func (h *TimeEntryHandler) BulkJiraIssue(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkJiraIssueRequest
+	h.bulk(w, r, &req, &req.TimeEntrySelection, func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error) {
+		return h.service.BulkSetJiraIssue(r.Context(), p, sel, req.JiraIssueKey)
+	})
+}
+
+// BulkProject moves the selected entries to a project and task
+func (h *TimeEntryHandler) BulkProject(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkProjectRequest
+	h.bulk(w, r, &req, &req.TimeEntrySelection, func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error) {
+		return h.service.BulkSetProject(r.Context(), p, sel, req.ProjectID, req.TaskID)
+	})
+}
+
+func (h *TimeEntryHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	var req dto.TimeEntrySelection
+	h.bulk(w, r, &req, &req, func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error) {
+		return h.service.BulkDelete(r.Context(), p, sel)
+	})
+}
+
+// BulkPushToJira logs the selected entries as work on their Jira issues
+func (h *TimeEntryHandler) BulkPushToJira(w http.ResponseWriter, r *http.Request) {
+	var req dto.TimeEntrySelection
+	h.bulk(w, r, &req, &req, func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error) {
+		return h.service.BulkPushToJira(r.Context(), p, sel)
+	})
+}
+
+// bulk decodes a bulk request into req, whose selection is sel, and runs the
+// operation on the selected entries
+func (h *TimeEntryHandler) bulk(
+	w http.ResponseWriter,
+	r *http.Request,
+	req interface{},
+	sel *dto.TimeEntrySelection,
+	run func(p organization.Principal, sel timeentry.Selection) ([]timeentry.BulkResult, error),
+) {
+	p := middleware.GetPrincipal(r.Context())
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(sel); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	selection, err := sel.ToDomain()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date format. Expected YYYY-MM-DD")
+		return
+	}
+
+	results, err := run(p, selection)
+	if err != nil {
+		respondTimeEntryError(w, err, "Failed to update time entries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.BulkResultsFromDomain(results))
+}
+
+// maxImportSize caps the size of an uploaded timesheet
+const maxImportSize = 10 << 20
+
+// Import reads time entries from an uploaded CSV. The multipart form takes
+// the file, a preset (toggl, harvest or clockify) and/or a JSON column
+// mapping, and optionally dry_run, timezone and billable (the default for
+// rows without a billable column).
+func (h *TimeEntryHandler) Import(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid upload. Expected a multipart form of at most 10 MB")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	req := timeentry.ImportRequest{Source: "csv", DefaultBillable: true}
+	if preset := strings.ToLower(r.FormValue("preset")); preset != "" {
+		mapping, ok := timeentry.Presets[preset]
+		if !ok {
+			respondError(w, http.StatusBadRequest, "preset must be toggl, harvest or clockify")
+			return
+		}
+		req.Source, req.Mapping = preset, mapping
+	}
+	if v := r.FormValue("mapping"); v != "" {
+		var mapping dto.ImportMapping
+		if err := json.Unmarshal([]byte(v), &mapping); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid mapping")
+			return
+		}
+		req.Mapping = mapping.Over(req.Mapping)
+	}
+	if v := r.FormValue("timezone"); v != "" {
+		if req.Location, err = time.LoadLocation(v); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid timezone")
+			return
+		}
+	}
+	dryRun, msg := boolParam(r.Form, "dry_run")
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	req.DryRun = dryRun != nil && *dryRun
+	billable, msg := boolParam(r.Form, "billable")
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if billable != nil {
+		req.DefaultBillable = *billable
+	}
+
+	result, err := h.service.Import(r.Context(), p, file, req)
+	if errors.Is(err, timeentry.ErrInvalidImport) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Print(err)
+		respondFailure(w, err, "Failed to import time entries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ImportResultFromDomain(result))
+}
+
+// Export downloads the entries between start_date and end_date as CSV or,
+// with format=ics, as an iCalendar file
+func (h *TimeEntryHandler) Export(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	query := r.URL.Query()
+
+	from, msg := dateParam(query, "start_date")
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	to, msg := dateParam(query, "end_date")
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if from == nil || to == nil {
+		respondError(w, http.StatusBadRequest, "start_date and end_date are required")
+		return
+	}
+	if to.Before(*from) {
+		respondError(w, http.StatusBadRequest, "end_date must be after start_date")
+		return
+	}
+
+	write, contentType := timesheet.WriteCSV, "text/csv; charset=utf-8"
+	format := query.Get("format")
+	switch format {
+	case "", "csv":
+		format = "csv"
+	case "ics":
+		write, contentType = timesheet.WriteICS, "text/calendar; charset=utf-8"
+	default:
+		respondError(w, http.StatusBadRequest, "format must be csv or ics")
+		return
+	}
+
+	entries, err := h.service.ExportTimeEntries(r.Context(), p, *from, *to)
+	if err != nil {
+		log.Print(err)
+		respondFailure(w, err, "Failed to export time entries")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=timesheet-%s-%s.%s",
+		from.Format("2006-01-02"), to.Format("2006-01-02"), format))
+	if err := write(w, entries); err != nil {
+		log.Print(err)
+	}
+}
+
+// Rate returns the rate the entry is billed at and where it came from
+func (h *TimeEntryHandler) Rate(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid time entry ID")
+		return
+	}
+
+	resolved, err := h.service.ResolveRate(r.Context(), p, entryID)
+	if errors.Is(err, hourlyrate.ErrNoRate) {
+		respondError(w, http.StatusNotFound, "No hourly rate applies to this time entry")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Time entry not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ResolvedRateFromDomain(resolved))
+}
+
 func (h *TimeEntryHandler) SyncToJira(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserIDFromContext(r.Context())
+	p := middleware.GetPrincipal(r.Context())
 	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid time entry ID")
@@ -174,8 +513,8 @@ func (h *TimeEntryHandler) SyncToJira(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.SyncToJira(r.Context(), userID, entryID, req.IssueKey); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to sync to Jira: "+err.Error())
+	if err := h.service.SyncToJira(r.Context(), p, entryID, req.IssueKey); err != nil {
+		respondFailure(w, err, "Failed to sync to Jira: "+err.Error())
 		return
 	}
 
@@ -184,3 +523,195 @@ func (h *TimeEntryHandler) SyncToJira(w http.ResponseWriter, r *http.Request) {
 		"message": "Time entry synced to Jira successfully",
 	})
 }
+
+// Timer returns the user's running timer
+func (h *TimeEntryHandler) Timer(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	timer, err := h.service.GetTimer(r.Context(), p)
+	if err != nil {
+		respondTimerError(w, err, "Failed to fetch timer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TimerFromDomain(timer))
+}
+
+func (h *TimeEntryHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.StartTimerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	timer, err := h.service.StartTimer(r.Context(), p, timeentry.StartTimerRequest{
+		ProjectID:    req.ProjectID,
+		TaskID:       req.TaskID,
+		Description:  req.Description,
+		IsBillable:   req.IsBillable,
+		JiraIssueKey: req.JiraIssueKey,
+	})
+	if err != nil {
+		respondTimeEntryError(w, err, "Failed to start timer")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.TimerFromDomain(timer))
+}
+
+func (h *TimeEntryHandler) PauseTimer(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	timer, err := h.service.PauseTimer(r.Context(), p)
+	if err != nil {
+		respondTimerError(w, err, "Failed to pause timer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TimerFromDomain(timer))
+}
+
+func (h *TimeEntryHandler) ResumeTimer(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	timer, err := h.service.ResumeTimer(r.Context(), p)
+	if err != nil {
+		respondTimerError(w, err, "Failed to resume timer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TimerFromDomain(timer))
+}
+
+// StopTimer stops the user's timer and returns the time entry it was
+// recorded as
+func (h *TimeEntryHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.StopTimerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	entry, err := h.service.StopTimer(r.Context(), p, timeentry.StopTimerRequest{
+		PushToJira: req.PushToJira,
+	})
+	if entry == nil {
+		respondTimerError(w, err, "Failed to stop timer")
+		return
+	}
+
+	resp := dto.StopTimerResponse{TimeEntry: dto.TimeEntryFromDomain(entry)}
+	if err != nil {
+		resp.JiraError = err.Error()
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// InvoiceTime bills a client's uninvoiced time on a new draft invoice
+func (h *TimeEntryHandler) InvoiceTime(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var req dto.InvoiceTimeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	domainReq := timeentry.InvoiceTimeRequest{
+		ClientID:   req.ClientID,
+		ProjectIDs: req.ProjectIDs,
+		IssueDate:  req.IssueDate,
+		DueDate:    req.DueDate,
+		Currency:   req.Currency,
+		Notes:      req.Notes,
+	}
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid from format. Expected YYYY-MM-DD")
+			return
+		}
+		domainReq.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid to format. Expected YYYY-MM-DD")
+			return
+		}
+		domainReq.To = &to
+	}
+
+	inv, entries, err := h.service.InvoiceTime(r.Context(), p, domainReq)
+	if err != nil {
+		respondTimeEntryError(w, err, "Failed to invoice time")
+		return
+	}
+
+	resp := dto.InvoiceTimeResponse{
+		Invoice:     dto.InvoiceFromDomain(inv),
+		TimeEntries: make([]dto.TimeEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		resp.TimeEntries[i] = dto.TimeEntryFromDomain(&entry)
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// respondTimeEntryError maps the errors of assigning and billing time
+func respondTimeEntryError(w http.ResponseWriter, err error, fallback string) {
+	var invoiced *timeentry.InvoicedError
+	switch {
+	case errors.As(err, &invoiced):
+		respondJSON(w, http.StatusConflict, dto.InvoicedErrorFromDomain(invoiced))
+	case errors.Is(err, project.ErrProjectNotFound):
+		respondError(w, http.StatusBadRequest, "Project not found")
+	case errors.Is(err, project.ErrTaskNotFound):
+		respondError(w, http.StatusBadRequest, "Task not found")
+	case errors.Is(err, timeentry.ErrEmptySelection), errors.Is(err, timeentry.ErrTooManyEntries):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, timeentry.ErrWeekLocked), errors.Is(err, timeentry.ErrAlreadyInvoiced):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, timeentry.ErrNothingToInvoice):
+		respondError(w, http.StatusUnprocessableEntity, "No approved, uninvoiced billable time to invoice")
+	case errors.Is(err, hourlyrate.ErrNoRate):
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, invoice.ErrInvalidCurrency):
+		respondError(w, http.StatusBadRequest, "Invalid currency")
+	case errors.Is(err, invoice.ErrExchangeRateNotFound):
+		respondError(w, http.StatusUnprocessableEntity, "No exchange rate to the account's base currency")
+	default:
+		respondTimerError(w, err, fallback)
+	}
+}
+
+func respondTimerError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, timeentry.ErrNoTimer):
+		respondError(w, http.StatusNotFound, "No timer is running")
+	case errors.Is(err, timeentry.ErrTimerRunning), errors.Is(err, timeentry.ErrTimerPaused),
+		errors.Is(err, timeentry.ErrTimerNotPaused), errors.Is(err, timeentry.ErrWeekLocked):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, timeentry.ErrNoJiraIssue):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, timeentry.ErrJiraNotConfigured):
+		respondError(w, http.StatusServiceUnavailable, "Jira integration is not configured")
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
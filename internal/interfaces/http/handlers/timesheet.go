@@ -0,0 +1,268 @@
+// internal/interfaces/http/handlers/timesheet.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/timesheet"
+	"github.com/invoice-app-be/internal/interfaces/http/dto"
+	"github.com/invoice-app-be/internal/interfaces/http/middleware"
+)
+
+type TimesheetHandler struct {
+	service *timesheet.Service
+}
+
+func NewTimesheetHandler(service *timesheet.Service) *TimesheetHandler {
+	return &TimesheetHandler{service: service}
+}
+
+// List returns the weeks the user has submitted, optionally by status
+func (h *TimesheetHandler) List(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	var filters timesheet.ListFilters
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := timesheet.Status(status)
+		filters.Status = &s
+	}
+
+	sheets, err := h.service.ListTimesheets(r.Context(), p, filters)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch timesheets")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, timesheetsResponse(sheets))
+}
+
+// Pending returns the timesheets awaiting the user's approval
+func (h *TimesheetHandler) Pending(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+
+	sheets, err := h.service.Pending(r.Context(), p)
+	if err != nil {
+		respondFailure(w, err, "Failed to fetch timesheets")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, timesheetsResponse(sheets))
+}
+
+// GetWeek returns the user's week containing the date in the path
+func (h *TimesheetHandler) GetWeek(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	date, ok := weekDate(w, r)
+	if !ok {
+		return
+	}
+
+	week, err := h.service.GetWeek(r.Context(), p, date)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to fetch timesheet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+func (h *TimesheetHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	date, ok := weekDate(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.SubmitTimesheetRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	week, err := h.service.Submit(r.Context(), p, date, timesheet.SubmitRequest{
+		ApproverEmail: req.ApproverEmail,
+		ReviewerEmail: req.ReviewerEmail,
+	})
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to submit timesheet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+func (h *TimesheetHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	date, ok := weekDate(w, r)
+	if !ok {
+		return
+	}
+
+	week, err := h.service.Reopen(r.Context(), p, date)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to reopen timesheet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+// Get returns a timesheet to its owner or approver
+func (h *TimesheetHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p := middleware.GetPrincipal(r.Context())
+	timesheetID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid timesheet ID")
+		return
+	}
+
+	week, err := h.service.GetTimesheet(r.Context(), p, timesheetID)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to fetch timesheet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+func (h *TimesheetHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Approve, "Failed to approve timesheet")
+}
+
+func (h *TimesheetHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Reject, "Failed to reject timesheet")
+}
+
+func (h *TimesheetHandler) review(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(ctx context.Context, p organization.Principal, timesheetID uuid.UUID, comment string) (*timesheet.Week, error),
+	fallback string,
+) {
+	p := middleware.GetPrincipal(r.Context())
+	timesheetID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid timesheet ID")
+		return
+	}
+
+	var req dto.ReviewTimesheetRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	week, err := decide(r.Context(), p, timesheetID, req.Comment)
+	if err != nil {
+		respondTimesheetError(w, err, fallback)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+// PortalGet shows a client reviewer the week behind a review link
+func (h *TimesheetHandler) PortalGet(w http.ResponseWriter, r *http.Request) {
+	week, err := h.service.Open(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to fetch timesheet")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+func (h *TimesheetHandler) PortalApprove(w http.ResponseWriter, r *http.Request) {
+	h.portalReview(w, r, h.service.ApproveByToken, "Failed to approve timesheet")
+}
+
+func (h *TimesheetHandler) PortalReject(w http.ResponseWriter, r *http.Request) {
+	h.portalReview(w, r, h.service.RejectByToken, "Failed to reject timesheet")
+}
+
+func (h *TimesheetHandler) portalReview(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(ctx context.Context, token, reviewer, comment string) (*timesheet.Week, error),
+	fallback string,
+) {
+	var req dto.PortalReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	week, err := decide(r.Context(), chi.URLParam(r, "token"), req.Name, req.Comment)
+	if err != nil {
+		respondTimesheetError(w, err, fallback)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WeekFromDomain(week))
+}
+
+// weekDate reads the date (YYYY-MM-DD) in the path; any day of a week
+// stands for the week
+func weekDate(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", chi.URLParam(r, "date"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date format. Expected YYYY-MM-DD")
+		return date, false
+	}
+	return date, true
+}
+
+func timesheetsResponse(sheets []timesheet.Timesheet) []dto.TimesheetResponse {
+	response := make([]dto.TimesheetResponse, len(sheets))
+	for i, ts := range sheets {
+		response[i] = dto.TimesheetFromDomain(&ts)
+	}
+	return response
+}
+
+func respondTimesheetError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, timesheet.ErrTimesheetNotFound), errors.Is(err, timesheet.ErrUnauthorized),
+		errors.Is(err, timesheet.ErrInvalidToken):
+		respondError(w, http.StatusNotFound, "Timesheet not found")
+	case errors.Is(err, timesheet.ErrLinkExpired):
+		respondError(w, http.StatusGone, "This review link has expired")
+	case errors.Is(err, timesheet.ErrNotReviewer), errors.Is(err, timesheet.ErrSelfReview):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, timesheet.ErrInvalidStatusTransition):
+		respondError(w, http.StatusConflict, "Timesheet cannot be changed in its current status")
+	case errors.Is(err, timesheet.ErrEmptyWeek), errors.Is(err, timesheet.ErrCommentRequired),
+		errors.Is(err, timesheet.ErrApproverNotFound), errors.Is(err, timesheet.ErrReviewerConflict),
+		errors.Is(err, timesheet.ErrApproverCannotReview), errors.Is(err, timesheet.ErrReviewerNotClient):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, invoice.ErrEmailNotConfigured):
+		respondError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, invoice.ErrDeliveryFailed):
+		respondError(w, http.StatusBadGateway, err.Error())
+	default:
+		respondFailure(w, err, fallback)
+	}
+}
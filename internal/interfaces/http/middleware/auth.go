@@ -3,25 +3,35 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/invoice-app-be/internal/domain/organization"
+	"github.com/invoice-app-be/internal/domain/session"
 	"github.com/invoice-app-be/internal/infrastructure/auth"
 )
 
 type contextKey string
 
-const userIDKey contextKey = "userID"
+const (
+	userIDKey    contextKey = "userID"
+	principalKey contextKey = "principal"
+	sessionKey   contextKey = "session"
+	mfaKey       contextKey = "mfa"
+)
 
 type AuthMiddleware struct {
-	jwtManager *auth.JWTManager
+	jwtManager    *auth.JWTManager
+	organizations *organization.Service
+	sessions      *session.Service
 }
 
-func NewAuthMiddleware(jwtManager *auth.JWTManager) *AuthMiddleware {
-	return &AuthMiddleware{jwtManager: jwtManager}
+func NewAuthMiddleware(jwtManager *auth.JWTManager, organizations *organization.Service, sessions *session.Service) *AuthMiddleware {
+	return &AuthMiddleware{jwtManager: jwtManager, organizations: organizations, sessions: sessions}
 }
 
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
@@ -56,11 +66,64 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Logging out takes effect at once rather than when the token
+		// expires. Tokens from before sessions have none and are refused.
+		sess, err := m.sessions.Check(r.Context(), claims.SessionID)
+		if err != nil {
+			http.Error(w, "Session has ended", http.StatusUnauthorized)
+			return
+		}
+
+		// The role is looked up on every request, so changes to it and
+		// removals take effect without waiting for the token to expire.
+		// Tokens issued before organizations work in the user's first one.
+		var p organization.Principal
+		if claims.OrganizationID == uuid.Nil {
+			p, err = m.organizations.DefaultPrincipal(r.Context(), claims.UserID)
+		} else {
+			p, err = m.organizations.Principal(r.Context(), claims.OrganizationID, claims.UserID)
+		}
+		if errors.Is(err, organization.ErrNotMember) || errors.Is(err, organization.ErrNoOrganization) {
+			http.Error(w, "Not a member of the organization", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			log.Printf("[AUTH] ERROR: Resolving organization failed: %v", err)
+			http.Error(w, "Failed to resolve organization", http.StatusInternalServerError)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, principalKey, p)
+		ctx = context.WithValue(ctx, sessionKey, claims.SessionID)
+		ctx = context.WithValue(ctx, mfaKey, sess.MFAVerified)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// RequireMFA turns away sessions started without a second factor from
+// organizations that require one. It goes after Authenticate, on the routes
+// other than those the user needs to set two-factor authentication up or
+// switch organization.
+func (m *AuthMiddleware) RequireMFA(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsMFAVerified(r.Context()) {
+			required, err := m.organizations.RequiresMFA(r.Context(), GetPrincipal(r.Context()).OrganizationID)
+			if err != nil {
+				log.Printf("[AUTH] ERROR: Checking two-factor requirement failed: %v", err)
+				http.Error(w, "Failed to resolve organization", http.StatusInternalServerError)
+				return
+			}
+			if required {
+				http.Error(w, "The organization requires two-factor authentication", http.StatusForbidden)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Helper function to safely truncate strings for logging
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -73,3 +136,22 @@ func GetUserIDFromContext(ctx context.Context) uuid.UUID {
 	userID, _ := ctx.Value(userIDKey).(uuid.UUID)
 	return userID
 }
+
+// GetPrincipal returns the user acting in their active organization
+func GetPrincipal(ctx context.Context) organization.Principal {
+	p, _ := ctx.Value(principalKey).(organization.Principal)
+	return p
+}
+
+// GetSessionID returns the session the request's access token belongs to
+func GetSessionID(ctx context.Context) uuid.UUID {
+	sessionID, _ := ctx.Value(sessionKey).(uuid.UUID)
+	return sessionID
+}
+
+// IsMFAVerified reports whether the request's session was started or
+// confirmed with a second factor
+func IsMFAVerified(ctx context.Context) bool {
+	verified, _ := ctx.Value(mfaKey).(bool)
+	return verified
+}
@@ -16,7 +16,22 @@ type Router struct {
 	invoiceHandler   *handlers.InvoiceHandler
 	timeEntryHandler *handlers.TimeEntryHandler
 	authHandler      *handlers.AuthHandler
+	taxCodeHandler   *handlers.TaxCodeHandler
+	paymentHandler   *handlers.PaymentHandler
+	fxHandler        *handlers.ExchangeRateHandler
+	reportHandler    *handlers.ReportHandler
+	accountHandler   *handlers.AccountHandler
+	recurringHandler *handlers.RecurringHandler
+	dunningHandler   *handlers.DunningHandler
+	lateFeeHandler   *handlers.LateFeeHandler
+	portalHandler    *handlers.PortalHandler
+	quoteHandler     *handlers.QuoteHandler
+	projectHandler   *handlers.ProjectHandler
+	rateHandler      *handlers.HourlyRateHandler
 	jiraHandler      *handlers.JiraHandler // Can be nil
+	timesheetHandler *handlers.TimesheetHandler
+	orgHandler       *handlers.OrganizationHandler
+	mfaHandler       *handlers.MFAHandler
 	authMiddleware   *mw.AuthMiddleware
 }
 
@@ -24,14 +39,44 @@ func NewRouter(
 	invoiceHandler *handlers.InvoiceHandler,
 	timeEntryHandler *handlers.TimeEntryHandler,
 	authHandler *handlers.AuthHandler,
+	taxCodeHandler *handlers.TaxCodeHandler,
+	paymentHandler *handlers.PaymentHandler,
+	fxHandler *handlers.ExchangeRateHandler,
+	reportHandler *handlers.ReportHandler,
+	accountHandler *handlers.AccountHandler,
+	recurringHandler *handlers.RecurringHandler,
+	dunningHandler *handlers.DunningHandler,
+	lateFeeHandler *handlers.LateFeeHandler,
+	portalHandler *handlers.PortalHandler,
+	quoteHandler *handlers.QuoteHandler,
+	projectHandler *handlers.ProjectHandler,
+	rateHandler *handlers.HourlyRateHandler,
 	jiraHandler *handlers.JiraHandler,
+	timesheetHandler *handlers.TimesheetHandler,
+	orgHandler *handlers.OrganizationHandler,
+	mfaHandler *handlers.MFAHandler,
 	authMiddleware *mw.AuthMiddleware,
 ) *Router {
 	return &Router{
 		invoiceHandler:   invoiceHandler,
 		timeEntryHandler: timeEntryHandler,
 		authHandler:      authHandler,
+		taxCodeHandler:   taxCodeHandler,
+		paymentHandler:   paymentHandler,
+		fxHandler:        fxHandler,
+		reportHandler:    reportHandler,
+		accountHandler:   accountHandler,
+		recurringHandler: recurringHandler,
+		dunningHandler:   dunningHandler,
+		lateFeeHandler:   lateFeeHandler,
+		portalHandler:    portalHandler,
+		quoteHandler:     quoteHandler,
+		projectHandler:   projectHandler,
+		rateHandler:      rateHandler,
 		jiraHandler:      jiraHandler,
+		timesheetHandler: timesheetHandler,
+		orgHandler:       orgHandler,
+		mfaHandler:       mfaHandler,
 		authMiddleware:   authMiddleware,
 	}
 }
@@ -56,6 +101,9 @@ func (rt *Router) Setup() http.Handler {
 		MaxAge:           300,
 	}))
 
+	// Keys for verifying access tokens, for other services
+	r.Get("/.well-known/jwks.json", rt.authHandler.JWKS)
+
 	// Public routes
 	r.Route("/api/v1", func(r chi.Router) {
 		// Health check
@@ -67,49 +115,243 @@ func (rt *Router) Setup() http.Handler {
 		// Auth
 		r.Post("/auth/register", rt.authHandler.Register)
 		r.Post("/auth/login", rt.authHandler.Login)
+		r.Post("/auth/login/mfa", rt.authHandler.LoginMFA)
+		r.Get("/auth/sso/providers", rt.authHandler.SSOProviders)
+		r.Post("/auth/sso/{provider}/start", rt.authHandler.SSOStart)
+		r.Post("/auth/sso/{provider}/callback", rt.authHandler.SSOCallback)
+		r.Post("/auth/refresh", rt.authHandler.Refresh)
+		r.Post("/auth/verify-email", rt.authHandler.VerifyEmail)
+		r.Post("/auth/forgot-password", rt.authHandler.ForgotPassword)
+		r.Post("/auth/reset-password", rt.authHandler.ResetPassword)
+
+		// Client portal, authorized by the share token in the path
+		r.Route("/portal/invoices/{token}", func(r chi.Router) {
+			r.Get("/", rt.portalHandler.Invoice)
+			r.Get("/pdf", rt.portalHandler.PDF)
+			r.Get("/payments", rt.portalHandler.Payments)
+		})
+		r.Route("/portal/quotes/{token}", func(r chi.Router) {
+			r.Get("/", rt.quoteHandler.PortalGet)
+			r.Post("/accept", rt.quoteHandler.PortalAccept)
+			r.Post("/decline", rt.quoteHandler.PortalDecline)
+		})
+		r.Route("/portal/timesheets/{token}", func(r chi.Router) {
+			r.Get("/", rt.timesheetHandler.PortalGet)
+			r.Post("/approve", rt.timesheetHandler.PortalApprove)
+			r.Post("/reject", rt.timesheetHandler.PortalReject)
+		})
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(rt.authMiddleware.Authenticate)
 
-			// Invoices
-			r.Route("/invoices", func(r chi.Router) {
-				r.Get("/", rt.invoiceHandler.List)
-				r.Post("/", rt.invoiceHandler.Create)
-				r.Get("/{id}", rt.invoiceHandler.Get)
-				r.Put("/{id}", rt.invoiceHandler.Update)
-				r.Delete("/{id}", rt.invoiceHandler.Delete)
-				r.Post("/{id}/send", rt.invoiceHandler.Send)
-				r.Get("/{id}/pdf", rt.invoiceHandler.GeneratePDF)
+			// Sessions
+			r.Post("/auth/logout", rt.authHandler.Logout)
+			r.Post("/auth/logout-everywhere", rt.authHandler.LogoutEverywhere)
+			r.Get("/auth/sessions", rt.authHandler.Sessions)
+			r.Delete("/auth/sessions/{id}", rt.authHandler.RevokeSession)
+
+			// Account
+			r.Get("/account", rt.accountHandler.Get)
+			r.Put("/account", rt.accountHandler.Update)
+			r.Put("/account/password", rt.accountHandler.ChangePassword)
+			r.Post("/account/verify-email", rt.accountHandler.ResendVerification)
+
+			// Two-factor authentication
+			r.Route("/account/2fa", func(r chi.Router) {
+				r.Get("/", rt.mfaHandler.Status)
+				r.Post("/enroll", rt.mfaHandler.Enroll)
+				r.Post("/activate", rt.mfaHandler.Activate)
+				r.Post("/disable", rt.mfaHandler.Disable)
+				r.Post("/recovery-codes", rt.mfaHandler.RegenerateRecoveryCodes)
 			})
 
-			// Time Entries
-			r.Route("/time-entries", func(r chi.Router) {
-				r.Get("/", rt.timeEntryHandler.List)
-				r.Post("/", rt.timeEntryHandler.Create)
-				r.Get("/{id}", rt.timeEntryHandler.Get)
-				r.Put("/{id}", rt.timeEntryHandler.Update)
-				r.Delete("/{id}", rt.timeEntryHandler.Delete)
-				r.Post("/{id}/sync-jira", rt.timeEntryHandler.SyncToJira)
+			// Organizations the caller belongs to
+			r.Route("/organizations", func(r chi.Router) {
+				r.Get("/", rt.orgHandler.List)
+				r.Post("/", rt.orgHandler.Create)
+				r.Post("/{id}/switch", rt.orgHandler.Switch)
 			})
 
-			// Jira Integration - ALWAYS REGISTER (with nil checks in handler)
-			r.Route("/jira", func(r chi.Router) {
-				if rt.jiraHandler != nil {
-					r.Post("/pull-worklogs", rt.jiraHandler.PullWorklogs)
-					r.Post("/pull-issue-worklogs", rt.jiraHandler.PullWorklogsForIssue)
-					r.Post("/push-worklog", rt.jiraHandler.PushWorklog)
-				} else {
-					// Return error if Jira not configured
-					notConfigured := func(w http.ResponseWriter, r *http.Request) {
-						w.Header().Set("Content-Type", "application/json")
-						w.WriteHeader(http.StatusServiceUnavailable)
-						w.Write([]byte(`{"error":"Jira integration is not configured"}`))
+			// Everything else needs a second factor in organizations that
+			// require one
+			r.Group(func(r chi.Router) {
+				r.Use(rt.authMiddleware.RequireMFA)
+
+				// The active organization
+				r.Route("/organization", func(r chi.Router) {
+					r.Get("/", rt.orgHandler.Get)
+					r.Put("/", rt.orgHandler.Update)
+					r.Get("/members", rt.orgHandler.ListMembers)
+					r.Post("/members", rt.orgHandler.AddMember)
+					r.Put("/members/{userID}", rt.orgHandler.UpdateMember)
+					r.Delete("/members/{userID}", rt.orgHandler.RemoveMember)
+				})
+
+				// Invoices
+				r.Route("/invoices", func(r chi.Router) {
+					r.Get("/", rt.invoiceHandler.List)
+					r.Post("/", rt.invoiceHandler.Create)
+					r.Post("/from-time", rt.timeEntryHandler.InvoiceTime)
+					r.Get("/{id}", rt.invoiceHandler.Get)
+					r.Put("/{id}", rt.invoiceHandler.Update)
+					r.Delete("/{id}", rt.invoiceHandler.Delete)
+					r.Post("/{id}/send", rt.invoiceHandler.Send)
+					r.Post("/{id}/revert-to-draft", rt.invoiceHandler.RevertToDraft)
+					r.Get("/{id}/deliveries", rt.invoiceHandler.Deliveries)
+					r.Get("/{id}/activity", rt.invoiceHandler.Activity)
+					r.Get("/{id}/reminders", rt.dunningHandler.InvoiceReminders)
+					r.Put("/{id}/reminders", rt.dunningHandler.AssignSequence)
+					r.Post("/{id}/reminders/pause", rt.invoiceHandler.PauseReminders)
+					r.Post("/{id}/reminders/resume", rt.invoiceHandler.ResumeReminders)
+					r.Get("/{id}/late-fees", rt.lateFeeHandler.InvoiceCharges)
+					r.Get("/{id}/shares", rt.portalHandler.ListShares)
+					r.Post("/{id}/shares", rt.portalHandler.CreateShare)
+					r.Delete("/{id}/shares/{shareID}", rt.portalHandler.RevokeShare)
+					r.Get("/{id}/views", rt.portalHandler.Views)
+					r.Get("/{id}/pdf", rt.invoiceHandler.GeneratePDF)
+					r.Get("/{id}/payments", rt.paymentHandler.List)
+					r.Post("/{id}/payments", rt.paymentHandler.Record)
+				})
+
+				// Quotes
+				r.Route("/quotes", func(r chi.Router) {
+					r.Get("/", rt.quoteHandler.List)
+					r.Post("/", rt.quoteHandler.Create)
+					r.Get("/{id}", rt.quoteHandler.Get)
+					r.Put("/{id}", rt.quoteHandler.Update)
+					r.Delete("/{id}", rt.quoteHandler.Delete)
+					r.Post("/{id}/send", rt.quoteHandler.Send)
+					r.Post("/{id}/accept", rt.quoteHandler.Accept)
+					r.Post("/{id}/decline", rt.quoteHandler.Decline)
+					r.Post("/{id}/convert", rt.quoteHandler.Convert)
+				})
+
+				// Recurring invoices
+				r.Route("/recurring-invoices", func(r chi.Router) {
+					r.Get("/", rt.recurringHandler.List)
+					r.Post("/", rt.recurringHandler.Create)
+					r.Get("/{id}", rt.recurringHandler.Get)
+					r.Put("/{id}", rt.recurringHandler.Update)
+					r.Delete("/{id}", rt.recurringHandler.Delete)
+					r.Post("/{id}/pause", rt.recurringHandler.Pause)
+					r.Post("/{id}/resume", rt.recurringHandler.Resume)
+					r.Get("/{id}/invoices", rt.recurringHandler.Invoices)
+				})
+
+				// Payment reminder sequences
+				r.Route("/reminder-sequences", func(r chi.Router) {
+					r.Get("/", rt.dunningHandler.List)
+					r.Post("/", rt.dunningHandler.Create)
+					r.Get("/{id}", rt.dunningHandler.Get)
+					r.Put("/{id}", rt.dunningHandler.Update)
+					r.Delete("/{id}", rt.dunningHandler.Delete)
+				})
+
+				// Late fee policies
+				r.Route("/late-fee-policies", func(r chi.Router) {
+					r.Get("/", rt.lateFeeHandler.List)
+					r.Post("/", rt.lateFeeHandler.Create)
+					r.Get("/{id}", rt.lateFeeHandler.Get)
+					r.Put("/{id}", rt.lateFeeHandler.Update)
+					r.Delete("/{id}", rt.lateFeeHandler.Delete)
+				})
+
+				// Tax codes
+				r.Route("/tax-codes", func(r chi.Router) {
+					r.Get("/", rt.taxCodeHandler.List)
+					r.Post("/", rt.taxCodeHandler.Create)
+					r.Put("/{id}", rt.taxCodeHandler.Update)
+					r.Delete("/{id}", rt.taxCodeHandler.Delete)
+				})
+
+				// Exchange rates
+				r.Route("/exchange-rates", func(r chi.Router) {
+					r.Get("/", rt.fxHandler.List)
+					r.Post("/", rt.fxHandler.Create)
+				})
+
+				// Reports
+				r.Get("/reports/revenue", rt.reportHandler.Revenue)
+				r.Get("/reports/time", rt.reportHandler.Time)
+
+				// Projects
+				r.Route("/projects", func(r chi.Router) {
+					r.Get("/", rt.projectHandler.List)
+					r.Post("/", rt.projectHandler.Create)
+					r.Get("/{id}", rt.projectHandler.Get)
+					r.Put("/{id}", rt.projectHandler.Update)
+					r.Delete("/{id}", rt.projectHandler.Delete)
+					r.Get("/{id}/tasks", rt.projectHandler.ListTasks)
+					r.Post("/{id}/tasks", rt.projectHandler.CreateTask)
+					r.Put("/{id}/tasks/{taskID}", rt.projectHandler.UpdateTask)
+					r.Delete("/{id}/tasks/{taskID}", rt.projectHandler.DeleteTask)
+				})
+
+				// Hourly rates
+				r.Route("/hourly-rates", func(r chi.Router) {
+					r.Get("/", rt.rateHandler.List)
+					r.Post("/", rt.rateHandler.Create)
+					r.Delete("/{id}", rt.rateHandler.Delete)
+				})
+
+				// Timer
+				r.Route("/timer", func(r chi.Router) {
+					r.Get("/", rt.timeEntryHandler.Timer)
+					r.Post("/start", rt.timeEntryHandler.StartTimer)
+					r.Post("/pause", rt.timeEntryHandler.PauseTimer)
+					r.Post("/resume", rt.timeEntryHandler.ResumeTimer)
+					r.Post("/stop", rt.timeEntryHandler.StopTimer)
+				})
+
+				// Timesheets
+				r.Route("/timesheets", func(r chi.Router) {
+					r.Get("/", rt.timesheetHandler.List)
+					r.Get("/pending", rt.timesheetHandler.Pending)
+					r.Get("/weeks/{date}", rt.timesheetHandler.GetWeek)
+					r.Post("/weeks/{date}/submit", rt.timesheetHandler.Submit)
+					r.Post("/weeks/{date}/reopen", rt.timesheetHandler.Reopen)
+					r.Get("/{id}", rt.timesheetHandler.Get)
+					r.Post("/{id}/approve", rt.timesheetHandler.Approve)
+					r.Post("/{id}/reject", rt.timesheetHandler.Reject)
+				})
+
+				// Time Entries
+				r.Route("/time-entries", func(r chi.Router) {
+					r.Get("/", rt.timeEntryHandler.List)
+					r.Post("/", rt.timeEntryHandler.Create)
+					r.Post("/import", rt.timeEntryHandler.Import)
+					r.Get("/export", rt.timeEntryHandler.Export)
+					r.Post("/bulk/billable", rt.timeEntryHandler.BulkBillable)
+					r.Post("/bulk/jira-issue", rt.timeEntryHandler.BulkJiraIssue)
+					r.Post("/bulk/project", rt.timeEntryHandler.BulkProject)
+					r.Post("/bulk/delete", rt.timeEntryHandler.BulkDelete)
+					r.Post("/bulk/push-to-jira", rt.timeEntryHandler.BulkPushToJira)
+					r.Get("/{id}", rt.timeEntryHandler.Get)
+					r.Put("/{id}", rt.timeEntryHandler.Update)
+					r.Delete("/{id}", rt.timeEntryHandler.Delete)
+					r.Get("/{id}/rate", rt.timeEntryHandler.Rate)
+					r.Post("/{id}/sync-jira", rt.timeEntryHandler.SyncToJira)
+				})
+
+				// Jira Integration - ALWAYS REGISTER (with nil checks in handler)
+				r.Route("/jira", func(r chi.Router) {
+					if rt.jiraHandler != nil {
+						r.Post("/pull-worklogs", rt.jiraHandler.PullWorklogs)
+						r.Post("/pull-issue-worklogs", rt.jiraHandler.PullWorklogsForIssue)
+						r.Post("/push-worklog", rt.jiraHandler.PushWorklog)
+					} else {
+						// Return error if Jira not configured
+						notConfigured := func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "application/json")
+							w.WriteHeader(http.StatusServiceUnavailable)
+							w.Write([]byte(`{"error":"Jira integration is not configured"}`))
+						}
+						r.Post("/pull-worklogs", notConfigured)
+						r.Post("/pull-issue-worklogs", notConfigured)
+						r.Post("/push-worklog", notConfigured)
 					}
-					r.Post("/pull-worklogs", notConfigured)
-					r.Post("/pull-issue-worklogs", notConfigured)
-					r.Post("/push-worklog", notConfigured)
-				}
+				})
 			})
 		})
 	})
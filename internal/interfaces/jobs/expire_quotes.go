@@ -0,0 +1,35 @@
+// internal/interfaces/jobs/expire_quotes.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/quote"
+)
+
+// ExpireQuotesJob expires sent quotes once their expiry date has passed
+type ExpireQuotesJob struct {
+	service *quote.Service
+}
+
+func NewExpireQuotesJob(service *quote.Service) *ExpireQuotesJob {
+	return &ExpireQuotesJob{service: service}
+}
+
+func (j *ExpireQuotesJob) Name() string {
+	return "expire_quotes"
+}
+
+func (j *ExpireQuotesJob) Run(ctx context.Context) error {
+	expired, err := j.service.ExpireDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if expired > 0 {
+		slog.Info("Expired quotes", "count", expired)
+	}
+	return nil
+}
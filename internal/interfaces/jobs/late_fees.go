@@ -0,0 +1,35 @@
+// internal/interfaces/jobs/late_fees.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/latefee"
+)
+
+// LateFeesJob charges the late fees that have accrued on overdue invoices
+type LateFeesJob struct {
+	service *latefee.Service
+}
+
+func NewLateFeesJob(service *latefee.Service) *LateFeesJob {
+	return &LateFeesJob{service: service}
+}
+
+func (j *LateFeesJob) Name() string {
+	return "late_fees"
+}
+
+func (j *LateFeesJob) Run(ctx context.Context) error {
+	charged, err := j.service.ChargeDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if charged > 0 {
+		slog.Info("Charged late fees", "count", charged)
+	}
+	return nil
+}
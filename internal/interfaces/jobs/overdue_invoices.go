@@ -0,0 +1,35 @@
+// internal/interfaces/jobs/overdue_invoices.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/invoice"
+)
+
+// OverdueInvoicesJob marks sent invoices past their due date as overdue
+type OverdueInvoicesJob struct {
+	service *invoice.Service
+}
+
+func NewOverdueInvoicesJob(service *invoice.Service) *OverdueInvoicesJob {
+	return &OverdueInvoicesJob{service: service}
+}
+
+func (j *OverdueInvoicesJob) Name() string {
+	return "overdue_invoices"
+}
+
+func (j *OverdueInvoicesJob) Run(ctx context.Context) error {
+	marked, err := j.service.MarkOverdue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if marked > 0 {
+		slog.Info("Marked invoices overdue", "count", marked)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// internal/interfaces/jobs/payment_reminders.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/dunning"
+)
+
+// PaymentRemindersJob sends the reminder steps that have come due
+type PaymentRemindersJob struct {
+	service *dunning.Service
+}
+
+func NewPaymentRemindersJob(service *dunning.Service) *PaymentRemindersJob {
+	return &PaymentRemindersJob{service: service}
+}
+
+func (j *PaymentRemindersJob) Name() string {
+	return "payment_reminders"
+}
+
+func (j *PaymentRemindersJob) Run(ctx context.Context) error {
+	sent, err := j.service.SendDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if sent > 0 {
+		slog.Info("Sent payment reminders", "count", sent)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// internal/interfaces/jobs/recurring_invoices.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/recurring"
+)
+
+// RecurringInvoicesJob generates the invoices for recurring schedules that are due
+type RecurringInvoicesJob struct {
+	service *recurring.Service
+}
+
+func NewRecurringInvoicesJob(service *recurring.Service) *RecurringInvoicesJob {
+	return &RecurringInvoicesJob{service: service}
+}
+
+func (j *RecurringInvoicesJob) Name() string {
+	return "recurring_invoices"
+}
+
+func (j *RecurringInvoicesJob) Run(ctx context.Context) error {
+	generated, err := j.service.GenerateDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if generated > 0 {
+		slog.Info("Generated recurring invoices", "count", generated)
+	}
+	return nil
+}
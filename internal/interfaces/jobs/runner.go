@@ -0,0 +1,55 @@
+// internal/interfaces/jobs/runner.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is a unit of background work run by the worker on every tick
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// Runner runs its jobs one after another, once at start-up and then on every
+// interval until the context is cancelled
+type Runner struct {
+	interval time.Duration
+	jobs     []Job
+}
+
+func NewRunner(interval time.Duration, jobs ...Job) *Runner {
+	return &Runner{interval: interval, jobs: jobs}
+}
+
+func (r *Runner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.runAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) runAll(ctx context.Context) {
+	for _, job := range r.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		started := time.Now()
+		if err := job.Run(ctx); err != nil {
+			slog.Error("Job failed", "job", job.Name(), "error", err)
+			continue
+		}
+		slog.Debug("Job finished", "job", job.Name(), "duration", time.Since(started))
+	}
+}
@@ -0,0 +1,36 @@
+// internal/interfaces/jobs/stop_timers.go
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/invoice-app-be/internal/domain/timeentry"
+)
+
+// StopTimersJob stops timers that were left running for longer than the limit
+type StopTimersJob struct {
+	service *timeentry.Service
+	limit   time.Duration
+}
+
+func NewStopTimersJob(service *timeentry.Service, limit time.Duration) *StopTimersJob {
+	return &StopTimersJob{service: service, limit: limit}
+}
+
+func (j *StopTimersJob) Name() string {
+	return "stop_timers"
+}
+
+func (j *StopTimersJob) Run(ctx context.Context) error {
+	stopped, err := j.service.StopStale(ctx, time.Now(), j.limit)
+	if err != nil {
+		return err
+	}
+
+	if stopped > 0 {
+		slog.Info("Stopped forgotten timers", "count", stopped)
+	}
+	return nil
+}
@@ -8,13 +8,17 @@ import (
 )
 
 type SyncJiraJob struct {
-	syncer *jira.Syncer
+	syncer *jira.SyncService
 }
 
-func NewSyncJiraJob(syncer *jira.Syncer) *SyncJiraJob {
+func NewSyncJiraJob(syncer *jira.SyncService) *SyncJiraJob {
 	return &SyncJiraJob{syncer: syncer}
 }
 
+func (j *SyncJiraJob) Name() string {
+	return "sync_jira"
+}
+
 func (j *SyncJiraJob) Run(ctx context.Context) error {
 	log.Println("Starting Jira sync job...")
 
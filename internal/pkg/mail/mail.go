@@ -0,0 +1,37 @@
+// internal/pkg/mail/mail.go
+package mail
+
+// Message is an outgoing email. The body comes from the named template,
+// rendered with Data, unless Subject and the bodies are set directly.
+type Message struct {
+	To      []string
+	CC      []string
+	BCC     []string
+	ReplyTo string
+
+	// FromName overrides the configured sender's display name
+	FromName string
+
+	Template string
+	Data     interface{}
+
+	Subject  string
+	TextBody string
+	HTMLBody string
+
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Recipients is every address the message is delivered to
+func (m *Message) Recipients() []string {
+	recipients := make([]string, 0, len(m.To)+len(m.CC)+len(m.BCC))
+	recipients = append(recipients, m.To...)
+	recipients = append(recipients, m.CC...)
+	return append(recipients, m.BCC...)
+}
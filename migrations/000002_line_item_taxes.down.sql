@@ -0,0 +1,12 @@
+-- migrations/000002_line_item_taxes.down.sql
+
+DROP TABLE IF EXISTS invoice_item_taxes;
+
+ALTER TABLE invoice_items
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_exempt;
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS prices_include_tax;
+
+DROP TABLE IF EXISTS tax_codes;
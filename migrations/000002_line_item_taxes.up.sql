@@ -0,0 +1,50 @@
+-- migrations/000002_line_item_taxes.up.sql
+
+-- Per-user tax rate catalog
+CREATE TABLE tax_codes
+(
+    id          UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id     UUID          NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name        VARCHAR(100)  NOT NULL,
+    rate        DECIMAL(7, 4) NOT NULL CHECK (rate >= 0),
+    is_compound BOOLEAN                  DEFAULT false,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_tax_codes ON tax_codes (user_id);
+
+ALTER TABLE invoices
+    ADD COLUMN prices_include_tax BOOLEAN DEFAULT false;
+
+ALTER TABLE invoice_items
+    ADD COLUMN tax_exempt BOOLEAN        DEFAULT false,
+    ADD COLUMN tax_amount DECIMAL(12, 2) DEFAULT 0;
+
+-- Taxes applied to each line, copied from the catalog at invoice time
+CREATE TABLE invoice_item_taxes
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_item_id UUID           NOT NULL REFERENCES invoice_items (id) ON DELETE CASCADE,
+    tax_code_id     UUID           REFERENCES tax_codes (id) ON DELETE SET NULL,
+    name            VARCHAR(100)   NOT NULL,
+    rate            DECIMAL(7, 4)  NOT NULL,
+    is_compound     BOOLEAN          DEFAULT false,
+    taxable_amount  DECIMAL(12, 2) NOT NULL,
+    amount          DECIMAL(12, 2) NOT NULL,
+    sort_order      INT              DEFAULT 0
+);
+
+CREATE INDEX idx_item_taxes ON invoice_item_taxes (invoice_item_id);
+
+-- Carry the old header tax rate over to the lines of existing invoices
+INSERT INTO invoice_item_taxes (invoice_item_id, name, rate, taxable_amount, amount)
+SELECT ii.id, 'Tax', inv.tax_rate, ii.amount, ROUND(ii.amount * inv.tax_rate / 100, 2)
+FROM invoice_items ii
+         JOIN invoices inv ON inv.id = ii.invoice_id
+WHERE inv.tax_rate > 0;
+
+UPDATE invoice_items ii
+SET tax_amount = t.amount
+FROM invoice_item_taxes t
+WHERE t.invoice_item_id = ii.id;
@@ -0,0 +1,13 @@
+-- migrations/000003_discounts.down.sql
+
+ALTER TABLE invoice_items
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS discount_value,
+    DROP COLUMN IF EXISTS discount_type;
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS early_payment_discount_days,
+    DROP COLUMN IF EXISTS early_payment_discount_percent,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS discount_value,
+    DROP COLUMN IF EXISTS discount_type;
@@ -0,0 +1,15 @@
+-- migrations/000003_discounts.up.sql
+
+ALTER TABLE invoices
+    ADD COLUMN discount_type                  VARCHAR(10)    NOT NULL DEFAULT 'none'
+        CHECK (discount_type IN ('none', 'percent', 'fixed')),
+    ADD COLUMN discount_value                 DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_amount                DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN early_payment_discount_percent DECIMAL(5, 2)  NOT NULL DEFAULT 0,
+    ADD COLUMN early_payment_discount_days    INT            NOT NULL DEFAULT 0;
+
+ALTER TABLE invoice_items
+    ADD COLUMN discount_type   VARCHAR(10)    NOT NULL DEFAULT 'none'
+        CHECK (discount_type IN ('none', 'percent', 'fixed')),
+    ADD COLUMN discount_value  DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_amount DECIMAL(12, 2) NOT NULL DEFAULT 0;
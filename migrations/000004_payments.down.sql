@@ -0,0 +1,13 @@
+-- migrations/000004_payments.down.sql
+
+DROP TABLE IF EXISTS payments;
+
+UPDATE invoices
+SET status = 'sent'
+WHERE status = 'partially_paid';
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS amount_paid,
+    DROP CONSTRAINT IF EXISTS invoices_status_check,
+    ADD CONSTRAINT invoices_status_check
+        CHECK (status IN ('draft', 'sent', 'paid', 'overdue', 'cancelled'));
@@ -0,0 +1,39 @@
+-- migrations/000004_payments.up.sql
+
+ALTER TABLE invoices
+    DROP CONSTRAINT IF EXISTS invoices_status_check,
+    ADD CONSTRAINT invoices_status_check
+        CHECK (status IN ('draft', 'sent', 'partially_paid', 'paid', 'overdue', 'cancelled')),
+    ADD COLUMN amount_paid DECIMAL(12, 2) NOT NULL DEFAULT 0;
+
+-- Payment ledger; refunds are negative amounts
+CREATE TABLE payments
+(
+    id         UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    invoice_id UUID           NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    user_id    UUID           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    amount     DECIMAL(12, 2) NOT NULL CHECK (amount <> 0),
+    method     VARCHAR(20)    NOT NULL CHECK (method IN ('square', 'bank_transfer', 'cash', 'check')),
+    paid_at    DATE           NOT NULL,
+    reference  VARCHAR(255)   NOT NULL DEFAULT '',
+    notes      TEXT           NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_payments ON payments (invoice_id, paid_at);
+
+-- Record invoices already marked paid as a single payment
+INSERT INTO payments (invoice_id, user_id, amount, method, paid_at, reference)
+SELECT id,
+       user_id,
+       total,
+       CASE WHEN square_payment_id IS NOT NULL THEN 'square' ELSE 'bank_transfer' END,
+       updated_at::date,
+       COALESCE(square_payment_id, '')
+FROM invoices
+WHERE status = 'paid'
+  AND total <> 0;
+
+UPDATE invoices
+SET amount_paid = total
+WHERE status = 'paid';
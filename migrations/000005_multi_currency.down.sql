@@ -0,0 +1,33 @@
+-- migrations/000005_multi_currency.down.sql
+
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS fx_gain_loss,
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN amount TYPE DECIMAL(12, 2);
+
+ALTER TABLE invoice_item_taxes
+    ALTER COLUMN amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN taxable_amount TYPE DECIMAL(12, 2);
+
+ALTER TABLE invoice_items
+    ALTER COLUMN discount_amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN discount_value TYPE DECIMAL(12, 2),
+    ALTER COLUMN tax_amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN unit_price TYPE DECIMAL(12, 2);
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS base_currency,
+    ALTER COLUMN discount_amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN discount_value TYPE DECIMAL(12, 2),
+    ALTER COLUMN amount_paid TYPE DECIMAL(12, 2),
+    ALTER COLUMN total TYPE DECIMAL(12, 2),
+    ALTER COLUMN tax_amount TYPE DECIMAL(12, 2),
+    ALTER COLUMN subtotal TYPE DECIMAL(12, 2);
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS base_currency;
@@ -0,0 +1,61 @@
+-- migrations/000005_multi_currency.up.sql
+
+ALTER TABLE users
+    ADD COLUMN base_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+-- Widen money columns so currencies with 3 or 4 minor units fit
+ALTER TABLE invoices
+    ALTER COLUMN subtotal TYPE DECIMAL(16, 4),
+    ALTER COLUMN tax_amount TYPE DECIMAL(16, 4),
+    ALTER COLUMN total TYPE DECIMAL(16, 4),
+    ALTER COLUMN amount_paid TYPE DECIMAL(16, 4),
+    ALTER COLUMN discount_value TYPE DECIMAL(16, 4),
+    ALTER COLUMN discount_amount TYPE DECIMAL(16, 4),
+    ADD COLUMN base_currency VARCHAR(3),
+    ADD COLUMN exchange_rate DECIMAL(20, 10) NOT NULL DEFAULT 1;
+
+-- Existing invoices have no recorded rate, so they act as their own base
+UPDATE invoices
+SET base_currency = currency;
+
+ALTER TABLE invoices
+    ALTER COLUMN base_currency SET NOT NULL;
+
+ALTER TABLE invoice_items
+    ALTER COLUMN unit_price TYPE DECIMAL(16, 4),
+    ALTER COLUMN amount TYPE DECIMAL(16, 4),
+    ALTER COLUMN tax_amount TYPE DECIMAL(16, 4),
+    ALTER COLUMN discount_value TYPE DECIMAL(16, 4),
+    ALTER COLUMN discount_amount TYPE DECIMAL(16, 4);
+
+ALTER TABLE invoice_item_taxes
+    ALTER COLUMN taxable_amount TYPE DECIMAL(16, 4),
+    ALTER COLUMN amount TYPE DECIMAL(16, 4);
+
+ALTER TABLE payments
+    ALTER COLUMN amount TYPE DECIMAL(16, 4),
+    ADD COLUMN currency      VARCHAR(3),
+    ADD COLUMN exchange_rate DECIMAL(20, 10) NOT NULL DEFAULT 1,
+    ADD COLUMN fx_gain_loss  DECIMAL(16, 4)  NOT NULL DEFAULT 0;
+
+UPDATE payments p
+SET currency = i.currency
+FROM invoices i
+WHERE i.id = p.invoice_id;
+
+ALTER TABLE payments
+    ALTER COLUMN currency SET NOT NULL;
+
+-- Manually entered exchange rates
+CREATE TABLE exchange_rates
+(
+    id             UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id        UUID            NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_currency  VARCHAR(3)      NOT NULL,
+    quote_currency VARCHAR(3)      NOT NULL,
+    rate           DECIMAL(20, 10) NOT NULL CHECK (rate > 0),
+    effective_date DATE            NOT NULL,
+    source         VARCHAR(50)     NOT NULL DEFAULT 'manual',
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, base_currency, quote_currency, effective_date)
+);
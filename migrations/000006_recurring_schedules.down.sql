@@ -0,0 +1,8 @@
+-- migrations/000006_recurring_schedules.down.sql
+
+DROP INDEX IF EXISTS idx_invoices_recurring_occurrence;
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS recurring_schedule_id;
+
+DROP TABLE IF EXISTS recurring_schedules;
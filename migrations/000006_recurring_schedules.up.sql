@@ -0,0 +1,31 @@
+-- migrations/000006_recurring_schedules.up.sql
+
+CREATE TABLE recurring_schedules
+(
+    id                 UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id            UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id          UUID         NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    name               VARCHAR(255) NOT NULL,
+    rule               VARCHAR(255) NOT NULL,
+    start_date         DATE         NOT NULL,
+    end_date           DATE,
+    mode               VARCHAR(20)  NOT NULL DEFAULT 'draft' CHECK (mode IN ('draft', 'auto_send')),
+    active             BOOLEAN      NOT NULL DEFAULT TRUE,
+    payment_terms_days INTEGER      NOT NULL DEFAULT 30 CHECK (payment_terms_days >= 0),
+    next_run_date      DATE,
+    last_issue_date    DATE,
+    template           JSONB        NOT NULL,
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX idx_recurring_schedules_user ON recurring_schedules (user_id);
+CREATE INDEX idx_recurring_schedules_due ON recurring_schedules (next_run_date) WHERE active;
+
+ALTER TABLE invoices
+    ADD COLUMN recurring_schedule_id UUID REFERENCES recurring_schedules (id) ON DELETE SET NULL;
+
+-- One invoice per schedule occurrence, so a rerun can't double-bill
+CREATE UNIQUE INDEX idx_invoices_recurring_occurrence ON invoices (recurring_schedule_id, issue_date)
+    WHERE recurring_schedule_id IS NOT NULL;
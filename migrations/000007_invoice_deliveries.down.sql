@@ -0,0 +1,3 @@
+-- migrations/000007_invoice_deliveries.down.sql
+
+DROP TABLE IF EXISTS invoice_deliveries;
@@ -0,0 +1,19 @@
+-- migrations/000007_invoice_deliveries.up.sql
+
+-- One row per attempt to email an invoice
+CREATE TABLE invoice_deliveries
+(
+    id         UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    invoice_id UUID         NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    user_id    UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    recipient  VARCHAR(255) NOT NULL,
+    cc         TEXT         NOT NULL DEFAULT '',
+    bcc        TEXT         NOT NULL DEFAULT '',
+    reply_to   VARCHAR(255) NOT NULL DEFAULT '',
+    status     VARCHAR(20)  NOT NULL CHECK (status IN ('sent', 'failed')),
+    message_id VARCHAR(255) NOT NULL DEFAULT '',
+    error      TEXT         NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_deliveries ON invoice_deliveries (invoice_id, created_at DESC);
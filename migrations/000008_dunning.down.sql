@@ -0,0 +1,15 @@
+-- migrations/000008_dunning.down.sql
+
+DROP TABLE IF EXISTS invoice_activities;
+DROP TABLE IF EXISTS invoice_reminders;
+
+ALTER TABLE invoice_deliveries
+    DROP COLUMN IF EXISTS template;
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS dunning_sequence_id,
+    DROP COLUMN IF EXISTS reminders_paused,
+    DROP COLUMN IF EXISTS late_fees;
+
+DROP TABLE IF EXISTS dunning_steps;
+DROP TABLE IF EXISTS dunning_sequences;
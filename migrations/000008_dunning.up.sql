@@ -0,0 +1,63 @@
+-- migrations/000008_dunning.up.sql
+
+CREATE TABLE dunning_sequences
+(
+    id         UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id    UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       VARCHAR(255) NOT NULL,
+    is_default BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_dunning_sequences_default ON dunning_sequences (user_id) WHERE is_default;
+
+-- Reminder steps, offset in days from the due date (negative is before)
+CREATE TABLE dunning_steps
+(
+    id             UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    sequence_id    UUID           NOT NULL REFERENCES dunning_sequences (id) ON DELETE CASCADE,
+    offset_days    INTEGER        NOT NULL,
+    template       VARCHAR(50)    NOT NULL,
+    message        TEXT           NOT NULL DEFAULT '',
+    late_fee_type  VARCHAR(20)    NOT NULL DEFAULT 'none' CHECK (late_fee_type IN ('none', 'percent', 'fixed')),
+    late_fee_value DECIMAL(16, 4) NOT NULL DEFAULT 0,
+    sort_order     INTEGER        NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_dunning_steps_sequence ON dunning_steps (sequence_id);
+
+ALTER TABLE invoices
+    ADD COLUMN late_fees           DECIMAL(16, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN reminders_paused    BOOLEAN        NOT NULL DEFAULT FALSE,
+    ADD COLUMN dunning_sequence_id UUID REFERENCES dunning_sequences (id) ON DELETE SET NULL;
+
+ALTER TABLE invoice_deliveries
+    ADD COLUMN template VARCHAR(50) NOT NULL DEFAULT 'invoice';
+
+-- One row per step handled for an invoice, so each reminder goes out once
+CREATE TABLE invoice_reminders
+(
+    id          UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    invoice_id  UUID        NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    step_id     UUID REFERENCES dunning_steps (id) ON DELETE SET NULL,
+    status      VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed', 'skipped')),
+    delivery_id UUID REFERENCES invoice_deliveries (id) ON DELETE SET NULL,
+    error       TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_invoice_reminders_step ON invoice_reminders (invoice_id, step_id);
+
+-- Invoice timeline
+CREATE TABLE invoice_activities
+(
+    id         UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    invoice_id UUID        NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type       VARCHAR(50) NOT NULL,
+    message    TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_activities ON invoice_activities (invoice_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- migrations/000009_late_fee_policies.down.sql
+
+DROP TABLE IF EXISTS late_fee_charges;
+DROP TABLE IF EXISTS late_fee_policies;
@@ -0,0 +1,40 @@
+-- migrations/000009_late_fee_policies.up.sql
+
+-- A policy per client, plus one default per user (client_id NULL)
+CREATE TABLE late_fee_policies
+(
+    id          UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    user_id     UUID           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id   UUID REFERENCES clients (id) ON DELETE CASCADE,
+    fee_type    VARCHAR(20)    NOT NULL CHECK (fee_type IN ('flat', 'percent')),
+    amount      DECIMAL(16, 4) NOT NULL,
+    compounding VARCHAR(20)    NOT NULL DEFAULT 'none' CHECK (compounding IN ('none', 'daily', 'monthly')),
+    grace_days  INTEGER        NOT NULL DEFAULT 0,
+    mode        VARCHAR(20)    NOT NULL DEFAULT 'line_item' CHECK (mode IN ('line_item', 'follow_up')),
+    max_amount  DECIMAL(16, 4) NOT NULL DEFAULT 0,
+    max_percent DECIMAL(7, 4)  NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_late_fee_policies_client ON late_fee_policies (user_id, client_id) WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_late_fee_policies_default ON late_fee_policies (user_id) WHERE client_id IS NULL;
+
+-- Every late fee charged, with the balance and period it was worked out from
+CREATE TABLE late_fee_charges
+(
+    id                   UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    invoice_id           UUID           NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    policy_id            UUID REFERENCES late_fee_policies (id) ON DELETE SET NULL,
+    fee_type             VARCHAR(20)    NOT NULL,
+    rate                 DECIMAL(7, 4)  NOT NULL DEFAULT 0,
+    basis                DECIMAL(16, 4) NOT NULL DEFAULT 0,
+    period_start         DATE           NOT NULL,
+    period_end           DATE           NOT NULL,
+    amount               DECIMAL(16, 4) NOT NULL,
+    follow_up_invoice_id UUID REFERENCES invoices (id) ON DELETE SET NULL,
+    created_at           TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_late_fee_charges_invoice ON late_fee_charges (invoice_id, period_end);
+CREATE INDEX idx_late_fee_charges_follow_up ON late_fee_charges (follow_up_invoice_id);
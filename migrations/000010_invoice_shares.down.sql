@@ -0,0 +1,7 @@
+-- migrations/000010_invoice_shares.down.sql
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS viewed_at;
+
+DROP TABLE IF EXISTS invoice_views;
+DROP TABLE IF EXISTS invoice_shares;
@@ -0,0 +1,31 @@
+-- migrations/000010_invoice_shares.up.sql
+
+-- Signed links that give a client read access to one invoice
+CREATE TABLE invoice_shares
+(
+    id         UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    invoice_id UUID                     NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    user_id    UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_shares_invoice ON invoice_shares (invoice_id);
+
+CREATE TABLE invoice_views
+(
+    id         UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    share_id   UUID        NOT NULL REFERENCES invoice_shares (id) ON DELETE CASCADE,
+    invoice_id UUID        NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    resource   VARCHAR(20) NOT NULL CHECK (resource IN ('invoice', 'pdf', 'payments')),
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invoice_views_invoice ON invoice_views (invoice_id, created_at DESC);
+CREATE INDEX idx_invoice_views_share ON invoice_views (share_id);
+
+ALTER TABLE invoices
+    ADD COLUMN viewed_at TIMESTAMP WITH TIME ZONE;
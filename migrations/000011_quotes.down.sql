@@ -0,0 +1,6 @@
+-- migrations/000011_quotes.down.sql
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS quote_id;
+
+DROP TABLE IF EXISTS quotes;
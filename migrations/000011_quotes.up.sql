@@ -0,0 +1,40 @@
+-- migrations/000011_quotes.up.sql
+
+-- Estimates sent to clients; accepted quotes convert into draft invoices
+CREATE TABLE quotes
+(
+    id                 UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id            UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id          UUID                     NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    quote_number       VARCHAR(50)              NOT NULL,
+    status             VARCHAR(20)              NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'sent', 'accepted', 'declined', 'expired')),
+    issue_date         DATE                     NOT NULL,
+    expiry_date        DATE                     NOT NULL,
+    currency           VARCHAR(3)               NOT NULL DEFAULT 'USD',
+    tax_rate           DECIMAL(5, 2)            NOT NULL DEFAULT 0,
+    prices_include_tax BOOLEAN                  NOT NULL DEFAULT FALSE,
+    notes              TEXT                     NOT NULL DEFAULT '',
+    discount_type      VARCHAR(10)              NOT NULL DEFAULT 'none'
+        CHECK (discount_type IN ('none', 'percent', 'fixed')),
+    discount_value     DECIMAL(16, 4)           NOT NULL DEFAULT 0,
+    discount_amount    DECIMAL(16, 4)           NOT NULL DEFAULT 0,
+    subtotal           DECIMAL(16, 4)           NOT NULL DEFAULT 0,
+    tax_amount         DECIMAL(16, 4)           NOT NULL DEFAULT 0,
+    total              DECIMAL(16, 4)           NOT NULL DEFAULT 0,
+    payment_terms_days INT                      NOT NULL DEFAULT 30,
+    items              JSONB                    NOT NULL DEFAULT '[]',
+    invoice_id         UUID                     REFERENCES invoices (id) ON DELETE SET NULL,
+    sent_at            TIMESTAMP WITH TIME ZONE,
+    accepted_at        TIMESTAMP WITH TIME ZONE,
+    declined_at        TIMESTAMP WITH TIME ZONE,
+    created_at         TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, quote_number)
+);
+
+CREATE INDEX idx_quotes_user ON quotes (user_id, created_at DESC);
+CREATE INDEX idx_quotes_sent_expiry ON quotes (expiry_date) WHERE status = 'sent';
+
+ALTER TABLE invoices
+    ADD COLUMN quote_id UUID REFERENCES quotes (id) ON DELETE SET NULL;
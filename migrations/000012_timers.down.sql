@@ -0,0 +1,7 @@
+-- migrations/000012_timers.down.sql
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS ended_at,
+    DROP COLUMN IF EXISTS started_at;
+
+DROP TABLE IF EXISTS timers;
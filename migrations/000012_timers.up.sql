@@ -0,0 +1,20 @@
+-- migrations/000012_timers.up.sql
+
+-- Running timers; a user has at most one, and stopping it records a time entry
+CREATE TABLE timers
+(
+    id             UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id        UUID                     NOT NULL UNIQUE REFERENCES users (id) ON DELETE CASCADE,
+    description    TEXT                     NOT NULL DEFAULT '',
+    is_billable    BOOLEAN                  NOT NULL DEFAULT true,
+    jira_issue_key VARCHAR(50),
+    started_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    paused_at      TIMESTAMP WITH TIME ZONE,
+    paused_seconds BIGINT                   NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE time_entries
+    ADD COLUMN started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN ended_at   TIMESTAMP WITH TIME ZONE;
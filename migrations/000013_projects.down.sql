@@ -0,0 +1,12 @@
+-- migrations/000013_projects.down.sql
+
+ALTER TABLE timers
+    DROP COLUMN IF EXISTS task_id,
+    DROP COLUMN IF EXISTS project_id;
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS task_id,
+    DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS project_tasks;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,42 @@
+-- migrations/000013_projects.up.sql
+
+CREATE TABLE projects
+(
+    id               UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id          UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id        UUID                     NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+    name             VARCHAR(255)             NOT NULL,
+    hourly_rate      DECIMAL(16, 4),
+    budget_hours     DECIMAL(10, 2),
+    budget_amount    DECIMAL(16, 4),
+    jira_project_key VARCHAR(50),
+    archived         BOOLEAN                  NOT NULL DEFAULT false,
+    created_at       TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, jira_project_key)
+);
+
+CREATE INDEX idx_projects_client ON projects (client_id);
+
+CREATE TABLE project_tasks
+(
+    id          UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    project_id  UUID                     NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    name        VARCHAR(255)             NOT NULL,
+    hourly_rate DECIMAL(16, 4),
+    archived    BOOLEAN                  NOT NULL DEFAULT false,
+    created_at  TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_project_tasks_project ON project_tasks (project_id);
+
+ALTER TABLE time_entries
+    ADD COLUMN project_id UUID REFERENCES projects (id) ON DELETE SET NULL,
+    ADD COLUMN task_id    UUID REFERENCES project_tasks (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_time_entries_project ON time_entries (project_id, date);
+
+ALTER TABLE timers
+    ADD COLUMN project_id UUID REFERENCES projects (id) ON DELETE SET NULL,
+    ADD COLUMN task_id    UUID REFERENCES project_tasks (id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+-- migrations/000014_hourly_rates.down.sql
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS rate_source;
+
+DROP TABLE IF EXISTS hourly_rates;
@@ -0,0 +1,19 @@
+-- migrations/000014_hourly_rates.up.sql
+
+-- Dated rates for tasks, projects, clients and the user's default. scope_id
+-- is the user's own ID for the user scope.
+CREATE TABLE hourly_rates
+(
+    id             UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id        UUID           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    scope          VARCHAR(20)    NOT NULL CHECK (scope IN ('task', 'project', 'client', 'user')),
+    scope_id       UUID           NOT NULL,
+    hourly_rate    DECIMAL(16, 4) NOT NULL CHECK (hourly_rate >= 0),
+    effective_date DATE           NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, scope, scope_id, effective_date)
+);
+
+-- Where an invoiced entry's hourly_rate came from
+ALTER TABLE time_entries
+    ADD COLUMN rate_source VARCHAR(20);
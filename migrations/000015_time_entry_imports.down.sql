@@ -0,0 +1,6 @@
+-- migrations/000015_time_entry_imports.down.sql
+
+DROP INDEX IF EXISTS idx_time_entries_external_id;
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,9 @@
+-- migrations/000015_time_entry_imports.up.sql
+
+-- Where an imported entry came from, such as toggl:<row hash>; re-importing
+-- the same rows skips them
+ALTER TABLE time_entries
+    ADD COLUMN external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_time_entries_external_id ON time_entries (user_id, external_id)
+    WHERE external_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- migrations/000016_timesheets.down.sql
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS timesheet_id;
+
+DROP TABLE IF EXISTS timesheets;
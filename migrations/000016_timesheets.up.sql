@@ -0,0 +1,28 @@
+-- migrations/000016_timesheets.up.sql
+
+-- A user's week submitted for approval. approver_id is the user who reviews
+-- it; without one the owner or a client with the review link does.
+CREATE TABLE timesheets
+(
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    week_start   DATE        NOT NULL,
+    status       VARCHAR(20) NOT NULL CHECK (status IN ('open', 'submitted', 'approved', 'rejected')),
+    approver_id  UUID        REFERENCES users (id) ON DELETE SET NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_at  TIMESTAMP WITH TIME ZONE,
+    reviewed_by  VARCHAR(255),
+    comment      TEXT,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, week_start)
+);
+
+CREATE INDEX idx_timesheets_approver_id ON timesheets (approver_id) WHERE status = 'submitted';
+
+-- The timesheet an entry was submitted on; only entries submitted on an
+-- approved timesheet can be invoiced
+ALTER TABLE time_entries
+    ADD COLUMN timesheet_id UUID REFERENCES timesheets (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_time_entries_timesheet_id ON time_entries (timesheet_id);
@@ -0,0 +1,67 @@
+-- migrations/000017_organizations.down.sql
+
+-- Users get back the name and base currency of the organization they first
+-- joined; anything in other users' organizations stays with its creator
+ALTER TABLE users
+    ADD COLUMN company_name  VARCHAR(255),
+    ADD COLUMN base_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE users u
+SET company_name  = o.name,
+    base_currency = o.base_currency
+FROM organizations o
+WHERE o.id = (SELECT m.organization_id
+              FROM organization_members m
+              WHERE m.user_id = u.id
+              ORDER BY m.created_at
+              LIMIT 1);
+
+ALTER TABLE exchange_rates
+    DROP CONSTRAINT exchange_rates_organization_rate_key,
+    ADD UNIQUE (user_id, base_currency, quote_currency, effective_date);
+
+ALTER TABLE timesheets
+    DROP CONSTRAINT timesheets_organization_id_user_id_week_start_key,
+    ADD CONSTRAINT timesheets_user_id_week_start_key UNIQUE (user_id, week_start);
+
+ALTER TABLE hourly_rates
+    DROP CONSTRAINT hourly_rates_organization_id_scope_scope_id_effective_date_key,
+    ADD CONSTRAINT hourly_rates_user_id_scope_scope_id_effective_date_key
+        UNIQUE (user_id, scope, scope_id, effective_date);
+
+ALTER TABLE projects
+    DROP CONSTRAINT projects_organization_id_jira_project_key_key,
+    ADD CONSTRAINT projects_user_id_jira_project_key_key UNIQUE (user_id, jira_project_key);
+
+ALTER TABLE quotes
+    DROP CONSTRAINT quotes_organization_id_quote_number_key,
+    ADD CONSTRAINT quotes_user_id_quote_number_key UNIQUE (user_id, quote_number);
+
+DROP INDEX idx_time_entries_external_id;
+CREATE UNIQUE INDEX idx_time_entries_external_id ON time_entries (user_id, external_id)
+    WHERE external_id IS NOT NULL;
+
+DROP INDEX idx_late_fee_policies_client;
+DROP INDEX idx_late_fee_policies_default;
+CREATE UNIQUE INDEX idx_late_fee_policies_client ON late_fee_policies (user_id, client_id) WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_late_fee_policies_default ON late_fee_policies (user_id) WHERE client_id IS NULL;
+
+DROP INDEX idx_dunning_sequences_default;
+CREATE UNIQUE INDEX idx_dunning_sequences_default ON dunning_sequences (user_id) WHERE is_default;
+
+ALTER TABLE clients DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE invoices DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE time_entries DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE tax_codes DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE exchange_rates DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE recurring_schedules DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE dunning_sequences DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE late_fee_policies DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE quotes DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE timers DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE hourly_rates DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE timesheets DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
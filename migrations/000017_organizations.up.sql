@@ -0,0 +1,154 @@
+-- migrations/000017_organizations.up.sql
+
+-- An organization owns clients, projects, invoices and time. Its members
+-- work in it according to their role.
+CREATE TABLE organizations
+(
+    id            UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    name          VARCHAR(255) NOT NULL,
+    base_currency VARCHAR(3)   NOT NULL DEFAULT 'USD',
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members
+(
+    organization_id UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id         UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role            VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'accountant', 'member', 'viewer')),
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user ON organization_members (user_id);
+
+-- Every existing user owns an organization taking over their company name,
+-- base currency and data. It reuses the user's ID so rows can be moved
+-- across directly.
+INSERT INTO organizations (id, name, base_currency, created_at, updated_at)
+SELECT id, COALESCE(NULLIF(company_name, ''), full_name), base_currency, created_at, updated_at
+FROM users;
+
+INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+SELECT id, id, 'owner', created_at, updated_at
+FROM users;
+
+-- user_id stays on each row as the member who created it, or for time
+-- entries, timers and timesheets, whose time it is
+ALTER TABLE clients
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE invoices
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE time_entries
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE tax_codes
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE exchange_rates
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE recurring_schedules
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE dunning_sequences
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE late_fee_policies
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE quotes
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE timers
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE projects
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE hourly_rates
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE timesheets
+    ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+
+UPDATE clients SET organization_id = user_id;
+UPDATE invoices SET organization_id = user_id;
+UPDATE time_entries SET organization_id = user_id;
+UPDATE tax_codes SET organization_id = user_id;
+UPDATE exchange_rates SET organization_id = user_id;
+UPDATE recurring_schedules SET organization_id = user_id;
+UPDATE dunning_sequences SET organization_id = user_id;
+UPDATE late_fee_policies SET organization_id = user_id;
+UPDATE quotes SET organization_id = user_id;
+UPDATE timers SET organization_id = user_id;
+UPDATE projects SET organization_id = user_id;
+UPDATE hourly_rates SET organization_id = user_id;
+UPDATE timesheets SET organization_id = user_id;
+
+ALTER TABLE clients ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE invoices ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE time_entries ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE tax_codes ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE exchange_rates ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE recurring_schedules ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE dunning_sequences ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE late_fee_policies ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE quotes ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE timers ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE projects ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE hourly_rates ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE timesheets ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX idx_clients_organization ON clients (organization_id);
+CREATE INDEX idx_invoices_organization ON invoices (organization_id, created_at DESC);
+CREATE INDEX idx_time_entries_organization ON time_entries (organization_id, date DESC);
+CREATE INDEX idx_tax_codes_organization ON tax_codes (organization_id);
+CREATE INDEX idx_recurring_schedules_organization ON recurring_schedules (organization_id);
+CREATE INDEX idx_quotes_organization ON quotes (organization_id, created_at DESC);
+
+-- What was unique per user is now unique per organization
+DROP INDEX idx_dunning_sequences_default;
+CREATE UNIQUE INDEX idx_dunning_sequences_default ON dunning_sequences (organization_id) WHERE is_default;
+
+DROP INDEX idx_late_fee_policies_client;
+DROP INDEX idx_late_fee_policies_default;
+CREATE UNIQUE INDEX idx_late_fee_policies_client ON late_fee_policies (organization_id, client_id)
+    WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_late_fee_policies_default ON late_fee_policies (organization_id) WHERE client_id IS NULL;
+
+DROP INDEX idx_time_entries_external_id;
+CREATE UNIQUE INDEX idx_time_entries_external_id ON time_entries (organization_id, user_id, external_id)
+    WHERE external_id IS NOT NULL;
+
+ALTER TABLE quotes
+    DROP CONSTRAINT quotes_user_id_quote_number_key,
+    ADD CONSTRAINT quotes_organization_id_quote_number_key UNIQUE (organization_id, quote_number);
+
+ALTER TABLE projects
+    DROP CONSTRAINT projects_user_id_jira_project_key_key,
+    ADD CONSTRAINT projects_organization_id_jira_project_key_key UNIQUE (organization_id, jira_project_key);
+
+ALTER TABLE hourly_rates
+    DROP CONSTRAINT hourly_rates_user_id_scope_scope_id_effective_date_key,
+    ADD CONSTRAINT hourly_rates_organization_id_scope_scope_id_effective_date_key
+        UNIQUE (organization_id, scope, scope_id, effective_date);
+
+ALTER TABLE timesheets
+    DROP CONSTRAINT timesheets_user_id_week_start_key,
+    ADD CONSTRAINT timesheets_organization_id_user_id_week_start_key UNIQUE (organization_id, user_id, week_start);
+
+-- The generated name of the exchange rate constraint is truncated, so look
+-- it up
+DO
+$$
+    DECLARE
+        constraint_name TEXT;
+    BEGIN
+        SELECT conname
+        INTO constraint_name
+        FROM pg_constraint
+        WHERE conrelid = 'exchange_rates'::regclass
+          AND contype = 'u';
+        EXECUTE format('ALTER TABLE exchange_rates DROP CONSTRAINT %I', constraint_name);
+    END
+$$;
+
+ALTER TABLE exchange_rates
+    ADD CONSTRAINT exchange_rates_organization_rate_key
+        UNIQUE (organization_id, base_currency, quote_currency, effective_date);
+
+ALTER TABLE users
+    DROP COLUMN company_name,
+    DROP COLUMN base_currency;
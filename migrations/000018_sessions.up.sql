@@ -0,0 +1,32 @@
+-- migrations/000018_sessions.up.sql
+
+-- A login on one device. organization_id is the organization refreshed
+-- access tokens are issued for; it follows the user switching organizations.
+CREATE TABLE sessions
+(
+    id              UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id         UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    organization_id UUID         NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_agent      VARCHAR(512) NOT NULL    DEFAULT '',
+    ip_address      VARCHAR(64)  NOT NULL    DEFAULT '',
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_used_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions (user_id) WHERE revoked_at IS NULL;
+
+-- Each refresh of a session uses up its token and issues the next one. Only
+-- a SHA-256 hash of the token is kept; a used token presented again has
+-- leaked, and revokes the session.
+CREATE TABLE refresh_tokens
+(
+    id         UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    session_id UUID        NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    used_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
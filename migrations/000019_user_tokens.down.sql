@@ -0,0 +1,6 @@
+-- migrations/000019_user_tokens.down.sql
+
+DROP TABLE IF EXISTS user_tokens;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at;
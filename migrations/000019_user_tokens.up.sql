@@ -0,0 +1,19 @@
+-- migrations/000019_user_tokens.up.sql
+
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Single-use links emailed to a user to verify their address or reset their
+-- password. Only a SHA-256 hash of the token is kept.
+CREATE TABLE user_tokens
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    purpose    VARCHAR(30) NOT NULL CHECK (purpose IN ('email_verification', 'password_reset')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_tokens_user_id ON user_tokens (user_id, purpose) WHERE used_at IS NULL;
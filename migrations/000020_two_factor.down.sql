@@ -0,0 +1,10 @@
+-- migrations/000020_two_factor.down.sql
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS mfa_verified;
+
+ALTER TABLE organizations
+    DROP COLUMN IF EXISTS require_mfa;
+
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
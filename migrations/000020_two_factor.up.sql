@@ -0,0 +1,37 @@
+-- migrations/000020_two_factor.up.sql
+
+-- A user's TOTP authenticator. enabled_at is null until the user confirms
+-- enrollment with a code. last_used_step keeps codes from being replayed,
+-- and wrong codes in a row lock it for a while.
+CREATE TABLE user_mfa
+(
+    user_id         UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret          VARCHAR(64) NOT NULL,
+    enabled_at      TIMESTAMP WITH TIME ZONE,
+    last_used_step  BIGINT      NOT NULL DEFAULT 0,
+    failed_attempts INTEGER     NOT NULL DEFAULT 0,
+    last_failed_at  TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Single-use codes for users who lost their authenticator. Only a SHA-256
+-- hash of the code is kept.
+CREATE TABLE mfa_recovery_codes
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes (user_id) WHERE used_at IS NULL;
+
+-- Members of an organization that requires two-factor authentication can
+-- only work in it from sessions started with a second factor
+ALTER TABLE organizations
+    ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE sessions
+    ADD COLUMN mfa_verified BOOLEAN NOT NULL DEFAULT false;
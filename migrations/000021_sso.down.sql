@@ -0,0 +1,4 @@
+-- migrations/000021_sso.down.sql
+
+DROP TABLE IF EXISTS oidc_logins;
+DROP TABLE IF EXISTS user_identities;
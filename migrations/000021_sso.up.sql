@@ -0,0 +1,30 @@
+-- migrations/000021_sso.up.sql
+
+-- A user's account at an OpenID Connect identity provider, which names it
+-- by its subject. A user can be linked to several.
+CREATE TABLE user_identities
+(
+    id            UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
+    user_id       UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    provider      VARCHAR(50)  NOT NULL,
+    subject       VARCHAR(255) NOT NULL,
+    email         VARCHAR(255) NOT NULL    DEFAULT '',
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+
+-- Authorization requests waiting for the user to come back from the
+-- provider. Each is removed when completed; only a SHA-256 hash of the
+-- state is kept.
+CREATE TABLE oidc_logins
+(
+    state_hash    VARCHAR(64) PRIMARY KEY,
+    provider      VARCHAR(50)              NOT NULL,
+    nonce         VARCHAR(64)              NOT NULL,
+    code_verifier VARCHAR(128)             NOT NULL,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
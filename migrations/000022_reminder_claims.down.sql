@@ -0,0 +1,7 @@
+-- migrations/000022_reminder_claims.down.sql
+
+UPDATE invoice_reminders SET status = 'failed', error = 'interrupted while sending' WHERE status = 'pending';
+
+ALTER TABLE invoice_reminders
+    DROP CONSTRAINT invoice_reminders_status_check,
+    ADD CONSTRAINT invoice_reminders_status_check CHECK (status IN ('sent', 'failed', 'skipped'));
@@ -0,0 +1,7 @@
+-- migrations/000022_reminder_claims.up.sql
+
+-- Reminders are recorded as pending before they're sent, along with any late
+-- fee, so a step is never charged twice
+ALTER TABLE invoice_reminders
+    DROP CONSTRAINT invoice_reminders_status_check,
+    ADD CONSTRAINT invoice_reminders_status_check CHECK (status IN ('pending', 'sent', 'failed', 'skipped'));
@@ -0,0 +1,5 @@
+-- migrations/000023_late_fee_charge_guard.down.sql
+
+DROP INDEX IF EXISTS idx_late_fee_charges_flat;
+DROP INDEX IF EXISTS idx_late_fee_charges_invoice;
+CREATE INDEX idx_late_fee_charges_invoice ON late_fee_charges (invoice_id, period_end);
@@ -0,0 +1,7 @@
+-- migrations/000023_late_fee_charge_guard.up.sql
+
+-- An invoice is charged once per period, and a flat fee only once, however
+-- many workers run
+DROP INDEX IF EXISTS idx_late_fee_charges_invoice;
+CREATE UNIQUE INDEX idx_late_fee_charges_invoice ON late_fee_charges (invoice_id, period_end);
+CREATE UNIQUE INDEX idx_late_fee_charges_flat ON late_fee_charges (invoice_id) WHERE fee_type = 'flat';
@@ -0,0 +1,9 @@
+-- migrations/000024_late_fee_items.down.sql
+
+DELETE FROM invoice_items WHERE is_late_fee;
+
+ALTER TABLE late_fee_charges
+    DROP COLUMN IF EXISTS invoice_item_id;
+
+ALTER TABLE invoice_items
+    DROP COLUMN IF EXISTS is_late_fee;
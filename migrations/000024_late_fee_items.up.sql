@@ -0,0 +1,17 @@
+-- migrations/000024_late_fee_items.up.sql
+
+-- Late fees are lines of their own on the overdue invoice, kept out of its
+-- subtotal, discounts and tax
+ALTER TABLE invoice_items
+    ADD COLUMN is_late_fee BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE late_fee_charges
+    ADD COLUMN invoice_item_id UUID REFERENCES invoice_items (id) ON DELETE SET NULL;
+
+-- Fees charged before now become one line per invoice
+INSERT INTO invoice_items (id, invoice_id, description, quantity, unit_price, amount, tax_exempt, tax_amount,
+                           sort_order, is_late_fee)
+SELECT uuid_generate_v4(), i.id, 'Late fees', 1, i.late_fees, i.late_fees, TRUE, 0,
+       (SELECT COALESCE(MAX(it.sort_order) + 1, 0) FROM invoice_items it WHERE it.invoice_id = i.id), TRUE
+FROM invoices i
+WHERE i.late_fees > 0;
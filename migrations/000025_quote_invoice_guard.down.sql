@@ -0,0 +1,3 @@
+-- migrations/000025_quote_invoice_guard.down.sql
+
+DROP INDEX IF EXISTS idx_invoices_quote_id;
@@ -0,0 +1,4 @@
+-- migrations/000025_quote_invoice_guard.up.sql
+
+-- A quote converts into one invoice, however often conversion is retried
+CREATE UNIQUE INDEX idx_invoices_quote_id ON invoices (quote_id) WHERE quote_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- migrations/000026_timesheet_reviewers.down.sql
+
+ALTER TABLE timesheets
+    DROP COLUMN IF EXISTS reviewer_email;
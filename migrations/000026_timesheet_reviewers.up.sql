@@ -0,0 +1,6 @@
+-- migrations/000026_timesheet_reviewers.up.sql
+
+-- The client a submitted week's review link is emailed to. Only weeks with
+-- one can be reviewed through a link.
+ALTER TABLE timesheets
+    ADD COLUMN reviewer_email VARCHAR(255);
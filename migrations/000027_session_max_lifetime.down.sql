@@ -0,0 +1,4 @@
+-- migrations/000027_session_max_lifetime.down.sql
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS max_expires_at;
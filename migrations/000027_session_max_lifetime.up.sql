@@ -0,0 +1,12 @@
+-- migrations/000027_session_max_lifetime.up.sql
+
+-- When a session ends however often it is refreshed. Existing sessions get
+-- the default 90 days from when they started, or their current expiry if
+-- that is later.
+ALTER TABLE sessions
+    ADD COLUMN max_expires_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE sessions SET max_expires_at = GREATEST(expires_at, created_at + INTERVAL '90 days');
+
+ALTER TABLE sessions
+    ALTER COLUMN max_expires_at SET NOT NULL;
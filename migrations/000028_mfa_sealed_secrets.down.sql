@@ -0,0 +1,5 @@
+-- migrations/000028_mfa_sealed_secrets.down.sql
+
+-- Sealed secrets can't be opened here, so this fails while any are stored
+ALTER TABLE user_mfa
+    ALTER COLUMN secret TYPE VARCHAR(64);
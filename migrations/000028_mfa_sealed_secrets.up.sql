@@ -0,0 +1,7 @@
+-- migrations/000028_mfa_sealed_secrets.up.sql
+
+-- Authenticator secrets are stored encrypted with auth.mfa_encryption_key.
+-- Sealed values are longer than the base32 secrets; the API seals those
+-- stored before this on start.
+ALTER TABLE user_mfa
+    ALTER COLUMN secret TYPE VARCHAR(128);
@@ -8,6 +8,12 @@ echo "=== Setting up development environment ==="
 # Create config directory if it doesn't exist
 mkdir -p config
 
+# Create a key to sign access tokens with
+if [ ! -f config/jwt-signing-key.pem ]; then
+  openssl genpkey -algorithm ed25519 -out config/jwt-signing-key.pem
+  echo "✓ Signing key created"
+fi
+
 # Create config file for local development (non-Docker)
 cat > config/config.yaml <<EOF
 server:
@@ -28,7 +34,9 @@ database:
 
 auth:
   jwt_secret: your-secret-key-change-in-production
-  token_duration: 24h
+  token_duration: 15m
+  refresh_token_duration: 720h
+  signing_key_file: config/jwt-signing-key.pem
 
 jira:
   base_url: ""
@@ -37,13 +45,42 @@ jira:
 square:
   access_token: ""
   environment: sandbox
+  location_id: ""
   enabled: false
 
+fx:
+  rates_file: ""
+
+worker:
+  interval: 1h
+  timer_limit: 12h
+
+email:
+  host: localhost
+  port: 1025
+  username: ""
+  password: ""
+  from: invoices@example.com
+  from_name: Invoices
+  enabled: false
+
+portal:
+  base_url: http://localhost:8080/api/v1/portal
+  token_duration: 720h
+
 redis:
   host: localhost
   port: 6379
   password: ""
   db: 0
+
+# Single sign-on; run "make run-mock-oidc" to try it with a local provider
+oidc:
+  providers: []
+  #  - name: mock
+  #    issuer: http://localhost:9090
+  #    client_id: invoice-app
+  #    redirect_url: http://localhost:3000/sso/callback
 EOF
 
 echo "✓ Config file created"